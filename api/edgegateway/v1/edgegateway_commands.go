@@ -110,20 +110,26 @@ func init() {
 		ModelType:          types.ModelEdgeGateways{},
 		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
 			cc := client.(*Client)
+			// The params are only set by ListEdgeGatewayWithLimit.
+			p, _ := params.(types.ParamsListEdgeGateway)
 			ep := endpoints.ListEdgeGateway()
 
 			logger := cc.logger.WithGroup("ListEdgeGateways")
 
-			resp, err := cc.c.Do(
+			values, err := cav.CollectPages(cav.Paginate(
 				ctx,
+				cc.c,
 				ep,
-			)
+				func(resp *resty.Response) []itypes.ApiResponseEdgegateway {
+					return resp.Result().(*itypes.ApiResponseEdgegateways).Values
+				},
+			), p.Limit)
 			if err != nil {
 				logger.Error("Failed to list edge gateways", "error", err)
 				return nil, err
 			}
 
-			return resp.Result().(*itypes.ApiResponseEdgegateways).ToModel(), nil
+			return (&itypes.ApiResponseEdgegateways{Values: values}).ToModel(), nil
 		},
 		AutoGenerate: true,
 	})
@@ -252,7 +258,7 @@ func init() {
 				}
 				if t0.Name == "" {
 					logger.Error("T0 router not found", "t0Name", p.T0Name)
-					return nil, errors.New("T0 router not found: " + p.T0Name)
+					return nil, errors.WithKind(errors.New("T0 router not found: "+p.T0Name), errors.ErrNotFound)
 				}
 			}
 
@@ -267,7 +273,7 @@ func init() {
 				}
 				if !slices.Contains(t0.Bandwidth.AllowedBandwidthValues, p.Bandwidth) {
 					logger.Error("Invalid bandwidth value for SHARED T0", "bandwidth", p.Bandwidth, "allowedValues", t0.Bandwidth.AllowedBandwidthValues, "remaining", t0.Bandwidth.Remaining)
-					return nil, errors.New("Invalid bandwidth value for SHARED T0")
+					return nil, errors.WithKind(errors.New("Invalid bandwidth value for SHARED T0"), errors.ErrValidation)
 				}
 			}
 
@@ -279,7 +285,7 @@ func init() {
 			// * OwnerName (VDC Or VDCGroup)
 
 			if (vdcs == nil || len(vdcs.Records) == 0) && (vdcGroups == nil || len(vdcGroups.Values) == 0) {
-				return nil, errors.New("No VDCs or VDC Groups found for owner: " + p.OwnerName)
+				return nil, errors.WithKind(errors.New("No VDCs or VDC Groups found for owner: "+p.OwnerName), errors.ErrNotFound)
 			}
 
 			if (vdcs != nil && len(vdcs.Records) >= 1) && (vdcGroups != nil && len(vdcGroups.Values) >= 1) {
@@ -0,0 +1,31 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package edgegateway
+
+import (
+	"context"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// ListEdgeGatewayWithLimit retrieves the first edge gateways, at most limit.
+// All the edge gateways are returned if limit is 0, as ListEdgeGateway does.
+func (c *Client) ListEdgeGatewayWithLimit(ctx context.Context, limit int) (*types.ModelEdgeGateways, error) {
+	if limit < 0 {
+		return nil, errors.WithKind(errors.Newf("limit must be greater than or equal to 0, got %d", limit), errors.ErrValidation)
+	}
+
+	x, err := cmds.Get("EdgeGateway", "", "List").Run(ctx, c, types.ParamsListEdgeGateway{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelEdgeGateways), nil
+}
@@ -25,6 +25,8 @@ import (
 )
 
 func TestGetEdgeGateway(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name                    string
 		params                  *types.ParamsEdgeGateway
@@ -86,22 +88,23 @@ func TestGetEdgeGateway(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
 			ep := endpoints.GetEdgeGateway()
 			epQuery := endpoints.QueryEdgeGateway()
+			mC, eC := newTestClient(t)
 
 			if tt.mockResponse != nil || tt.mockResponseStatus != 0 {
 				t.Logf("Setting mock response for endpoint %s with status %d", ep.Name, tt.mockResponseStatus)
 				// If we expect a valid response, we need to set the mock response
-				mock.SetMockResponse(ep, tt.mockResponse, &tt.mockResponseStatus)
+				mC.SetMockResponse(ep, tt.mockResponse, &tt.mockResponseStatus)
 			}
 
 			if tt.mockQueryResponse != nil || tt.mockQueryResponseStatus != 0 {
 				// If we expect a query response, we need to set the mock response for the
-				mock.SetMockResponse(epQuery, tt.mockQueryResponse, &tt.mockQueryResponseStatus)
+				mC.SetMockResponse(epQuery, tt.mockQueryResponse, &tt.mockQueryResponseStatus)
 			}
 
-			eC := newClient(t)
-
 			// Call the GetEdgeGateway method
 			result, err := eC.GetEdgeGateway(t.Context(), *tt.params)
 			if tt.expectedErr {
@@ -132,11 +135,9 @@ func TestGetEdgeGateway_ContextDeadlineExceeded(t *testing.T) {
 }
 
 func TestRetrieveEdgeGatewayIDByName(t *testing.T) {
-	mC, err := mock.NewClient()
-	assert.Nil(t, err, "Error creating mock client")
+	t.Parallel()
 
-	eC, err := New(mC)
-	assert.Nil(t, err, "Error creating edgegateway client")
+	mC, eC := newTestClient(t)
 
 	// Mock the QueryEdgeGateway endpoint
 	epQuery := endpoints.QueryEdgeGateway()
@@ -165,7 +166,7 @@ func TestRetrieveEdgeGatewayIDByName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mock.SetMockResponse(epQuery, tt.queryResp, &tt.queryStatus)
+			mC.SetMockResponse(epQuery, tt.queryResp, &tt.queryStatus)
 
 			id, err := eC.retrieveEdgeGatewayIDByName(t.Context(), tt.edgeName)
 			if tt.expectedErr {
@@ -180,6 +181,8 @@ func TestRetrieveEdgeGatewayIDByName(t *testing.T) {
 }
 
 func TestDeleteEdgeGateway(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name                    string
 		params                  *types.ParamsEdgeGateway
@@ -234,13 +237,13 @@ func TestDeleteEdgeGateway(t *testing.T) {
 			expectedErr:        false,
 		},
 		{
-			name: "Error 401",
+			name: "Error 403",
 			params: &types.ParamsEdgeGateway{
 				ID: generator.MustGenerate("{urn:edgegateway}"),
 			},
 			mockResponse:       nil,
-			mockResponseStatus: 401,
-			expectedErr:        true,
+			mockResponseStatus: 403,
+			expectedErr:        true, // Error HTTP 401 is not used because the session is renewed and the request replayed.
 		},
 		{
 			name: "error 404 edge gateway name and id not found",
@@ -256,21 +259,23 @@ func TestDeleteEdgeGateway(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mC, eC := newTestClient(t)
+
 			epDelete := endpoints.DeleteEdgeGateway()
 			if tt.mockResponse != nil || tt.mockResponseStatus != 0 {
 				t.Logf("Setting mock response for endpoint %s with status %d", epDelete.Name, tt.mockResponseStatus)
 				// If we expect a valid response, we need to set the mock response
-				mock.SetMockResponse(epDelete, tt.mockResponse, &tt.mockResponseStatus)
+				mC.SetMockResponse(epDelete, tt.mockResponse, &tt.mockResponseStatus)
 			}
 
 			epQuery := endpoints.QueryEdgeGateway()
 			if tt.mockQueryResponseStatus != 0 {
 				t.Logf("Setting mock response for query endpoint %s with status %d", epQuery.Name, tt.mockQueryResponseStatus)
 				// If we expect a query response, we need to set the mock response for the
-				mock.SetMockResponse(epQuery, nil, &tt.mockQueryResponseStatus)
+				mC.SetMockResponse(epQuery, nil, &tt.mockQueryResponseStatus)
 			}
-
-			eC := newClient(t)
 			err := eC.DeleteEdgeGateway(t.Context(), *tt.params)
 			if tt.expectedErr {
 				assert.NotNil(t, err, "Expected error for params: %v", tt.params)
@@ -706,14 +711,26 @@ func TestCreateEdgeGateway(t *testing.T) {
 func TestListEdgeGateay(t *testing.T) {
 	tests := []struct {
 		name               string
+		limit              int
 		mockResponse       any
 		mockResponseStatus int
+		renewSession       bool
 		expectedErr        bool
 	}{
 		{
 			name:               "Valid List Edge Gateways",
 			mockResponseStatus: 200,
 		},
+		{
+			name:               "Valid List Edge Gateways with limit",
+			limit:              1,
+			mockResponseStatus: 200,
+		},
+		{
+			name:        "Error negative limit",
+			limit:       -1,
+			expectedErr: true,
+		},
 		{
 			name:               "Error 500",
 			mockResponse:       struct{}{},
@@ -726,6 +743,11 @@ func TestListEdgeGateay(t *testing.T) {
 			mockResponseStatus: 401,
 			expectedErr:        true,
 		},
+		{
+			name:         "Session renewed",
+			renewSession: true,
+			expectedErr:  false, // The session is renewed and the request replayed.
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -734,16 +756,32 @@ func TestListEdgeGateay(t *testing.T) {
 				t.Logf("Setting mock response for endpoint %s with status %d", ep.Name, tt.mockResponseStatus)
 				mock.SetMockResponse(ep, tt.mockResponse, &tt.mockResponseStatus)
 			}
+			if tt.renewSession {
+				ep.SetMockResponseFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+				})
+			}
 
 			eC := newClient(t)
 
-			result, err := eC.ListEdgeGateway(t.Context())
+			var (
+				result *types.ModelEdgeGateways
+				err    error
+			)
+			if tt.limit != 0 {
+				result, err = eC.ListEdgeGatewayWithLimit(t.Context(), tt.limit)
+			} else {
+				result, err = eC.ListEdgeGateway(t.Context())
+			}
 			if tt.expectedErr {
 				assert.NotNil(t, err, "Expected error but got nil")
 				assert.Nil(t, result, "Result should be nil when error is expected")
 			} else {
 				assert.Nil(t, err, "Unexpected error: %v", tt.name)
 				assert.NotNil(t, result, "Result should not be nil")
+				if tt.limit > 0 {
+					assert.LessOrEqual(t, len(result.EdgeGateways), tt.limit)
+				}
 			}
 		})
 	}
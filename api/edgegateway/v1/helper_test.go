@@ -47,3 +47,15 @@ func newClient(t *testing.T) *Client {
 	assert.Nil(t, err, "Error creating edgegateway client")
 	return eC
 }
+
+// newTestClient returns a client backed by its own mock client, so the test can set
+// mock responses and run in parallel with the other tests using newTestClient.
+func newTestClient(t *testing.T) (*mock.Client, *Client) {
+	t.Helper()
+
+	mC := mock.NewTestClient(t)
+
+	eC, err := New(mC)
+	assert.Nil(t, err, "Error creating edgegateway client")
+	return mC, eC
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
 	"github.com/orange-cloudavenue/common-go/extractor"
 	"github.com/orange-cloudavenue/common-go/validators"
@@ -281,7 +282,7 @@ func init() {
 				Name: p.Name,
 			})
 			if data == nil || len(data.PublicIP) == 0 {
-				return nil, fmt.Errorf("no public IPs found for edge gateway %s", p.ID)
+				return nil, errors.WithKind(errors.Newf("no public IPs found for edge gateway %s", p.ID), errors.ErrNotFound)
 			}
 
 			for _, publicip := range data.PublicIP {
@@ -294,7 +295,7 @@ func init() {
 				}
 			}
 
-			return nil, fmt.Errorf("public IP %s not found in edge gateway %s", p.IP, p.ID)
+			return nil, errors.WithKind(errors.Newf("public IP %s not found in edge gateway %s", p.IP, p.ID), errors.ErrNotFound)
 		},
 	})
 
@@ -20,6 +20,7 @@ import (
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
 	"github.com/orange-cloudavenue/common-go/generator"
 	"github.com/orange-cloudavenue/common-go/urn"
@@ -93,7 +94,7 @@ func init() {
 
 			data := resp.Result().(*itypes.ApiResponseNetworkServices).ToModel(p)
 			if data == nil {
-				return nil, fmt.Errorf("no network services found for edge gateway %s", p.ID)
+				return nil, errors.WithKind(errors.Newf("no network services found for edge gateway %s", p.ID), errors.ErrNotFound)
 			}
 
 			return data, nil
@@ -243,6 +243,8 @@ func TestDisableCloudavenueServices(t *testing.T) {
 		mockGetNetworkServicesResponse       any
 		mockGetNetworkServicesResponseStatus int
 
+		renewSession bool
+
 		expectedErr bool
 	}{
 		{
@@ -292,6 +294,14 @@ func TestDisableCloudavenueServices(t *testing.T) {
 			mockResponseStatus: http.StatusUnauthorized,
 			expectedErr:        true,
 		},
+		{
+			name: "Session renewed",
+			params: types.ParamsEdgeGateway{
+				ID: generator.MustGenerate("{urn:edgegateway}"),
+			},
+			renewSession: true,
+			expectedErr:  false, // The session is renewed and the request replayed.
+		},
 	}
 
 	for _, tt := range tests {
@@ -303,6 +313,11 @@ func TestDisableCloudavenueServices(t *testing.T) {
 				ep.CleanMockResponse()
 				ep.SetMockResponse(tt.mockResponse, &tt.mockResponseStatus)
 			}
+			if tt.renewSession {
+				ep.SetMockResponseFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+				})
+			}
 
 			epGetNetworkServices := endpoints.GetEdgeGatewayServices()
 			// Set up mock response for GetNetworkServices
@@ -366,12 +381,12 @@ func TestGetCloudavenueServices(t *testing.T) {
 			expectedErr:        false, // Error HTTP 500 does not return an error because a retry is performed.
 		},
 		{
-			name: "Error 401",
+			name: "Error 403",
 			params: types.ParamsEdgeGateway{
 				ID: generator.MustGenerate("{urn:edgegateway}"),
 			},
-			mockResponseStatus: http.StatusUnauthorized,
-			expectedErr:        true,
+			mockResponseStatus: http.StatusForbidden,
+			expectedErr:        true, // Error HTTP 401 is not used because the session is renewed and the request replayed.
 		},
 	}
 
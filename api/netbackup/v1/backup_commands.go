@@ -0,0 +1,136 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/pspecs"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+//go:generate command-generator -path backup_commands.go
+
+func init() {
+	// * VMBackup
+	cmds.Register(commands.Command{
+		Namespace: "Netbackup",
+		Resource:  "VMBackup",
+	})
+
+	// * ListVMBackups
+	cmds.Register(commands.Command{
+		Namespace:                  "Netbackup",
+		Resource:                   "VMBackup",
+		Verb:                       "List",
+		ShortDocumentation:         "List the backups of a VM",
+		LongDocumentation:          "List the backups of a VM available for a restore",
+		AutoGenerate:               true,
+		AutoGenerateCustomFuncName: "ListVMBackups",
+		ModelType:                  types.ModelListNetbackupBackups{},
+		ParamsType:                 types.ParamsListNetbackupBackups{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "vm_id",
+				Description: "The unique identifier of the VM.",
+				Required:    true,
+				Validators: []validator.Validator{
+					validator.ValidatorURN("vm"),
+				},
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsListNetbackupBackups)
+			ep := endpoints.ListNetbackupVmBackups()
+
+			resp, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.VMID),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list Netbackup backups of the VM: %w", err)
+			}
+
+			return resp.Result().(*itypes.ApiResponseListNetbackupBackups).ToModel(p.VMID), nil
+		},
+	})
+
+	// * RestoreVMBackup
+	cmds.Register(commands.Command{
+		Namespace:          "Netbackup",
+		Resource:           "VMBackup",
+		Verb:               "Restore",
+		ShortDocumentation: "Restore a VM from a backup",
+		LongDocumentation:  "Restore a VM from a backup. The VM is restored in place, or as a new VM if a new VM name is provided",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsRestoreNetbackupVM{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "vm_id",
+				Description: "The unique identifier of the backed up VM.",
+				Required:    true,
+				Validators: []validator.Validator{
+					validator.ValidatorURN("vm"),
+				},
+			},
+			&pspecs.String{
+				Name:        "backup_id",
+				Description: "The unique identifier of the backup to restore.",
+				Required:    true,
+			},
+			&pspecs.String{
+				Name:        "new_vm_name",
+				Description: "The name of the VM created by the restore. If empty, the VM is restored in place.",
+				Required:    false,
+				Example:     "my-vm-restored",
+			},
+			&pspecs.Bool{
+				Name:        "power_on",
+				Description: "Power on the VM after the restore.",
+				Required:    false,
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsRestoreNetbackupVM)
+			ep := endpoints.RestoreNetbackupVm()
+
+			body := itypes.ApiRequestRestoreNetbackupVM{
+				RestoreType: "InPlace",
+				PowerOn:     p.PowerOn,
+			}
+			if p.NewVMName != "" {
+				body.RestoreType = "NewVM"
+				body.NewVMName = p.NewVMName
+			}
+
+			_, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.VMID),
+				cav.WithPathParam(ep.PathParams[1], p.BackupID),
+				cav.SetBody(body),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore the VM from the Netbackup backup: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+}
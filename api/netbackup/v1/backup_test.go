@@ -0,0 +1,153 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+func TestListVMBackups(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsListNetbackupBackups
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "ListVMBackups OK",
+			params: types.ParamsListNetbackupBackups{
+				VMID: generator.MustGenerate("{urn:vm}"),
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+		{
+			name: "Invalid VM ID",
+			params: types.ParamsListNetbackupBackups{
+				VMID: "invalid-id",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "VM Not Found",
+			params: types.ParamsListNetbackupBackups{
+				VMID: generator.MustGenerate("{urn:vm}"),
+			},
+			mockResponseStatus: http.StatusNotFound,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				endpoints.ListNetbackupVmBackups().CleanMockResponse()
+				endpoints.ListNetbackupVmBackups().SetMockResponse(nil, &tt.mockResponseStatus)
+				t.Cleanup(endpoints.ListNetbackupVmBackups().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			resp, err := client.ListVMBackups(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.NotNil(t, resp, "Response should not be nil")
+			assert.Equal(t, tt.params.VMID, resp.VMID)
+			assert.NotEmpty(t, resp.Backups, "Backups should not be empty")
+			for _, backup := range resp.Backups {
+				assert.NotEmpty(t, backup.ID)
+			}
+		})
+	}
+}
+
+func TestRestoreVMBackup(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsRestoreNetbackupVM
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "Restore in place",
+			params: types.ParamsRestoreNetbackupVM{
+				VMID:     generator.MustGenerate("{urn:vm}"),
+				BackupID: generator.MustGenerate("{uuid}"),
+			},
+			expectedErr: false,
+		},
+		{
+			name: "Restore as a new VM",
+			params: types.ParamsRestoreNetbackupVM{
+				VMID:      generator.MustGenerate("{urn:vm}"),
+				BackupID:  generator.MustGenerate("{uuid}"),
+				NewVMName: "my-vm-restored",
+				PowerOn:   true,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "No backup ID provided",
+			params: types.ParamsRestoreNetbackupVM{
+				VMID: generator.MustGenerate("{urn:vm}"),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Invalid VM ID",
+			params: types.ParamsRestoreNetbackupVM{
+				VMID:     "invalid-id",
+				BackupID: generator.MustGenerate("{uuid}"),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Conflict",
+			params: types.ParamsRestoreNetbackupVM{
+				VMID:     generator.MustGenerate("{urn:vm}"),
+				BackupID: generator.MustGenerate("{uuid}"),
+			},
+			mockResponseStatus: http.StatusConflict,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				endpoints.RestoreNetbackupVm().CleanMockResponse()
+				endpoints.RestoreNetbackupVm().SetMockResponse(nil, &tt.mockResponseStatus)
+				t.Cleanup(endpoints.RestoreNetbackupVm().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			err := client.RestoreVMBackup(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+		})
+	}
+}
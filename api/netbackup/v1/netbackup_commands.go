@@ -0,0 +1,22 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
+)
+
+func init() {
+	// * Netbackup
+	cmds.Register(commands.Command{
+		Namespace:         "Netbackup",
+		LongDocumentation: "This command allows you to manage the backups of your VMs and vApps with Netbackup.",
+	})
+}
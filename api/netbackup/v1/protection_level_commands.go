@@ -0,0 +1,272 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/pspecs"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+//go:generate command-generator -path protection_level_commands.go
+
+func init() {
+	// * ProtectionLevel
+	cmds.Register(commands.Command{
+		Namespace: "Netbackup",
+		Resource:  "ProtectionLevel",
+	})
+
+	// * ListProtectionLevels
+	cmds.Register(commands.Command{
+		Namespace:                  "Netbackup",
+		Resource:                   "ProtectionLevel",
+		Verb:                       "List",
+		ShortDocumentation:         "List the protection levels",
+		LongDocumentation:          "List the protection levels (backup policies) available for the organization",
+		AutoGenerate:               true,
+		AutoGenerateCustomFuncName: "ListProtectionLevels",
+		ModelType:                  types.ModelListNetbackupProtectionLevels{},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			ep := endpoints.ListNetbackupProtectionLevels()
+
+			resp, err := cc.c.Do(
+				ctx,
+				ep,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list Netbackup protection levels: %w", err)
+			}
+
+			return resp.Result().(*itypes.ApiResponseListNetbackupProtectionLevels).ToModel(), nil
+		},
+	})
+
+	// * VMProtectionLevel
+	cmds.Register(commands.Command{
+		Namespace: "Netbackup",
+		Resource:  "VMProtectionLevel",
+	})
+
+	// * AssignVMProtectionLevel
+	cmds.Register(commands.Command{
+		Namespace:          "Netbackup",
+		Resource:           "VMProtectionLevel",
+		Verb:               "Assign",
+		ShortDocumentation: "Assign a protection level to a VM",
+		LongDocumentation:  "Assign a protection level (backup policy) to a VM. The VM is backed up according to the schedule of the protection level",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsNetbackupVMProtectionLevel{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "vm_id",
+				Description: "The unique identifier of the VM.",
+				Required:    true,
+				Validators: []validator.Validator{
+					validator.ValidatorURN("vm"),
+				},
+			},
+			&pspecs.String{
+				Name:        "protection_level_name",
+				Description: "The name of the protection level.",
+				Required:    true,
+				Example:     "GOLD-D6-H22-R1",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsNetbackupVMProtectionLevel)
+			ep := endpoints.AssignNetbackupVmProtectionLevel()
+
+			levelID, err := cc.retrieveProtectionLevelIDByName(ctx, p.ProtectionLevelName)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.VMID),
+				cav.SetBody(itypes.ApiRequestNetbackupProtectionLevel{
+					ProtectionLevelID: levelID,
+				}),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to assign Netbackup protection level to the VM: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+
+	// * UnassignVMProtectionLevel
+	cmds.Register(commands.Command{
+		Namespace:          "Netbackup",
+		Resource:           "VMProtectionLevel",
+		Verb:               "Unassign",
+		ShortDocumentation: "Unassign a protection level from a VM",
+		LongDocumentation:  "Unassign a protection level (backup policy) from a VM. The existing backups are kept until their expiration",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsNetbackupVMProtectionLevel{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "vm_id",
+				Description: "The unique identifier of the VM.",
+				Required:    true,
+				Validators: []validator.Validator{
+					validator.ValidatorURN("vm"),
+				},
+			},
+			&pspecs.String{
+				Name:        "protection_level_name",
+				Description: "The name of the protection level.",
+				Required:    true,
+				Example:     "GOLD-D6-H22-R1",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsNetbackupVMProtectionLevel)
+			ep := endpoints.UnassignNetbackupVmProtectionLevel()
+
+			levelID, err := cc.retrieveProtectionLevelIDByName(ctx, p.ProtectionLevelName)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.VMID),
+				cav.WithPathParam(ep.PathParams[1], strconv.Itoa(levelID)),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unassign Netbackup protection level from the VM: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+
+	// * VAppProtectionLevel
+	cmds.Register(commands.Command{
+		Namespace: "Netbackup",
+		Resource:  "VAppProtectionLevel",
+	})
+
+	// * AssignVAppProtectionLevel
+	cmds.Register(commands.Command{
+		Namespace:          "Netbackup",
+		Resource:           "VAppProtectionLevel",
+		Verb:               "Assign",
+		ShortDocumentation: "Assign a protection level to a vApp",
+		LongDocumentation:  "Assign a protection level (backup policy) to a vApp. All the VMs of the vApp are backed up according to the schedule of the protection level",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsNetbackupVAppProtectionLevel{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "vapp_id",
+				Description: "The unique identifier of the vApp.",
+				Required:    true,
+				Validators: []validator.Validator{
+					validator.ValidatorURN("vapp"),
+				},
+			},
+			&pspecs.String{
+				Name:        "protection_level_name",
+				Description: "The name of the protection level.",
+				Required:    true,
+				Example:     "GOLD-D6-H22-R1",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsNetbackupVAppProtectionLevel)
+			ep := endpoints.AssignNetbackupVappProtectionLevel()
+
+			levelID, err := cc.retrieveProtectionLevelIDByName(ctx, p.ProtectionLevelName)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.VAppID),
+				cav.SetBody(itypes.ApiRequestNetbackupProtectionLevel{
+					ProtectionLevelID: levelID,
+				}),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to assign Netbackup protection level to the vApp: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+
+	// * UnassignVAppProtectionLevel
+	cmds.Register(commands.Command{
+		Namespace:          "Netbackup",
+		Resource:           "VAppProtectionLevel",
+		Verb:               "Unassign",
+		ShortDocumentation: "Unassign a protection level from a vApp",
+		LongDocumentation:  "Unassign a protection level (backup policy) from a vApp. The existing backups are kept until their expiration",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsNetbackupVAppProtectionLevel{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "vapp_id",
+				Description: "The unique identifier of the vApp.",
+				Required:    true,
+				Validators: []validator.Validator{
+					validator.ValidatorURN("vapp"),
+				},
+			},
+			&pspecs.String{
+				Name:        "protection_level_name",
+				Description: "The name of the protection level.",
+				Required:    true,
+				Example:     "GOLD-D6-H22-R1",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsNetbackupVAppProtectionLevel)
+			ep := endpoints.UnassignNetbackupVappProtectionLevel()
+
+			levelID, err := cc.retrieveProtectionLevelIDByName(ctx, p.ProtectionLevelName)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.VAppID),
+				cav.WithPathParam(ep.PathParams[1], strconv.Itoa(levelID)),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unassign Netbackup protection level from the vApp: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+}
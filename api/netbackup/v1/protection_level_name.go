@@ -0,0 +1,32 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"context"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+)
+
+// retrieveProtectionLevelIDByName returns the ID of the protection level named name.
+func (c *Client) retrieveProtectionLevelIDByName(ctx context.Context, name string) (int, error) {
+	levels, err := c.ListProtectionLevels(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, level := range levels.ProtectionLevels {
+		if level.Name == name {
+			return level.ID, nil
+		}
+	}
+
+	return 0, errors.WithKind(errors.Newf("protection level %q not found", name), errors.ErrNotFound)
+}
@@ -0,0 +1,239 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+const testProtectionLevelName = "GOLD-D6-H22-R1"
+
+// mockProtectionLevels sets the protection levels returned by the next call to the
+// ListNetbackupProtectionLevels endpoint, so the protection level can be found by name.
+func mockProtectionLevels(t *testing.T) {
+	t.Helper()
+
+	endpoints.ListNetbackupProtectionLevels().SetMockResponse(&itypes.ApiResponseListNetbackupProtectionLevels{
+		Data: []itypes.ApiResponseNetbackupProtectionLevel{
+			{
+				ID:   1,
+				Name: testProtectionLevelName,
+			},
+		},
+	}, nil)
+	t.Cleanup(endpoints.ListNetbackupProtectionLevels().CleanMockResponse)
+}
+
+func TestListProtectionLevels(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockResponseStatus int
+		mockResponse       any
+		expectedErr        bool
+	}{
+		{
+			name:        "ListProtectionLevels OK",
+			expectedErr: false,
+		},
+		{
+			name:               "ListProtectionLevels Not Found",
+			mockResponseStatus: http.StatusNotFound,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				endpoints.ListNetbackupProtectionLevels().CleanMockResponse()
+				endpoints.ListNetbackupProtectionLevels().SetMockResponse(tt.mockResponse, &tt.mockResponseStatus)
+				t.Cleanup(endpoints.ListNetbackupProtectionLevels().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			resp, err := client.ListProtectionLevels(t.Context())
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.NotNil(t, resp, "Response should not be nil")
+			assert.NotEmpty(t, resp.ProtectionLevels, "Protection levels should not be empty")
+			for _, level := range resp.ProtectionLevels {
+				assert.NotEmpty(t, level.Name)
+			}
+		})
+	}
+}
+
+func TestAssignUnassignVMProtectionLevel(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsNetbackupVMProtectionLevel
+		unknownLevel       bool
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "VMProtectionLevel OK",
+			params: types.ParamsNetbackupVMProtectionLevel{
+				VMID: generator.MustGenerate("{urn:vm}"),
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+		{
+			name: "Invalid VM ID",
+			params: types.ParamsNetbackupVMProtectionLevel{
+				VMID: generator.MustGenerate("{urn:vapp}"),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Unknown protection level",
+			params: types.ParamsNetbackupVMProtectionLevel{
+				VMID:                generator.MustGenerate("{urn:vm}"),
+				ProtectionLevelName: "UNKNOWN",
+			},
+			unknownLevel: true,
+			expectedErr:  true,
+		},
+		{
+			name: "Bad request",
+			params: types.ParamsNetbackupVMProtectionLevel{
+				VMID: generator.MustGenerate("{urn:vm}"),
+			},
+			mockResponseStatus: http.StatusBadRequest,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t)
+
+			if tt.params.VMID != "" && !tt.unknownLevel {
+				tt.params.ProtectionLevelName = testProtectionLevelName
+			}
+
+			if tt.mockResponseStatus != 0 {
+				for _, ep := range []interface {
+					CleanMockResponse()
+					SetMockResponse(any, *int)
+				}{endpoints.AssignNetbackupVmProtectionLevel(), endpoints.UnassignNetbackupVmProtectionLevel()} {
+					ep.CleanMockResponse()
+					ep.SetMockResponse(nil, &tt.mockResponseStatus)
+					t.Cleanup(ep.CleanMockResponse)
+				}
+			}
+
+			mockProtectionLevels(t)
+			err := client.AssignVMProtectionLevel(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, "Unexpected error: %v", err)
+			}
+
+			mockProtectionLevels(t)
+			err = client.UnassignVMProtectionLevel(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, "Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAssignUnassignVAppProtectionLevel(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsNetbackupVAppProtectionLevel
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "VAppProtectionLevel OK",
+			params: types.ParamsNetbackupVAppProtectionLevel{
+				VAppID: generator.MustGenerate("{urn:vapp}"),
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+		{
+			name: "Invalid vApp ID",
+			params: types.ParamsNetbackupVAppProtectionLevel{
+				VAppID: generator.MustGenerate("{urn:vm}"),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Bad request",
+			params: types.ParamsNetbackupVAppProtectionLevel{
+				VAppID: generator.MustGenerate("{urn:vapp}"),
+			},
+			mockResponseStatus: http.StatusBadRequest,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t)
+
+			if tt.params.VAppID != "" {
+				tt.params.ProtectionLevelName = testProtectionLevelName
+			}
+
+			if tt.mockResponseStatus != 0 {
+				for _, ep := range []interface {
+					CleanMockResponse()
+					SetMockResponse(any, *int)
+				}{endpoints.AssignNetbackupVappProtectionLevel(), endpoints.UnassignNetbackupVappProtectionLevel()} {
+					ep.CleanMockResponse()
+					ep.SetMockResponse(nil, &tt.mockResponseStatus)
+					t.Cleanup(ep.CleanMockResponse)
+				}
+			}
+
+			mockProtectionLevels(t)
+			err := client.AssignVAppProtectionLevel(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, "Unexpected error: %v", err)
+			}
+
+			mockProtectionLevels(t)
+			err = client.UnassignVAppProtectionLevel(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, "Unexpected error: %v", err)
+			}
+		})
+	}
+}
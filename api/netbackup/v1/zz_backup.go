@@ -0,0 +1,31 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"context"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// List the backups of a VM available for a restore
+func (c *Client) ListVMBackups(ctx context.Context, params types.ParamsListNetbackupBackups) (*types.ModelListNetbackupBackups, error) {
+	x, err := cmds.Get("Netbackup", "VMBackup", "List").Run(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelListNetbackupBackups), nil
+}
+
+// Restore a VM from a backup. The VM is restored in place, or as a new VM if a new VM name is provided
+func (c *Client) RestoreVMBackup(ctx context.Context, params types.ParamsRestoreNetbackupVM) error {
+	_, err := cmds.Get("Netbackup", "VMBackup", "Restore").Run(ctx, c, params)
+	return err
+}
@@ -0,0 +1,49 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package netbackup
+
+import (
+	"context"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// List the protection levels (backup policies) available for the organization
+func (c *Client) ListProtectionLevels(ctx context.Context) (*types.ModelListNetbackupProtectionLevels, error) {
+	x, err := cmds.Get("Netbackup", "ProtectionLevel", "List").Run(ctx, c, nil)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelListNetbackupProtectionLevels), nil
+}
+
+// Assign a protection level (backup policy) to a VM. The VM is backed up according to the schedule of the protection level
+func (c *Client) AssignVMProtectionLevel(ctx context.Context, params types.ParamsNetbackupVMProtectionLevel) error {
+	_, err := cmds.Get("Netbackup", "VMProtectionLevel", "Assign").Run(ctx, c, params)
+	return err
+}
+
+// Unassign a protection level (backup policy) from a VM. The existing backups are kept until their expiration
+func (c *Client) UnassignVMProtectionLevel(ctx context.Context, params types.ParamsNetbackupVMProtectionLevel) error {
+	_, err := cmds.Get("Netbackup", "VMProtectionLevel", "Unassign").Run(ctx, c, params)
+	return err
+}
+
+// Assign a protection level (backup policy) to a vApp. All the VMs of the vApp are backed up according to the schedule of the protection level
+func (c *Client) AssignVAppProtectionLevel(ctx context.Context, params types.ParamsNetbackupVAppProtectionLevel) error {
+	_, err := cmds.Get("Netbackup", "VAppProtectionLevel", "Assign").Run(ctx, c, params)
+	return err
+}
+
+// Unassign a protection level (backup policy) from a vApp. The existing backups are kept until their expiration
+func (c *Client) UnassignVAppProtectionLevel(ctx context.Context, params types.ParamsNetbackupVAppProtectionLevel) error {
+	_, err := cmds.Get("Netbackup", "VAppProtectionLevel", "Unassign").Run(ctx, c, params)
+	return err
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
 )
 
@@ -72,7 +73,7 @@ func init() {
 				}
 				orgDetails = resp.Result().(*itypes.ApiResponseGetOrgs).ToModel()
 				if nil == orgDetails {
-					return fmt.Errorf("error: organization not found")
+					return errors.WithKind(errors.New("error: organization not found"), errors.ErrNotFound)
 				}
 				return nil
 			})
@@ -160,7 +161,7 @@ func init() {
 
 			// Check at least one parameter is provided
 			if p.FullName == "" && p.Email == "" && p.InternetBillingMode == "" && p.Description == nil {
-				return nil, fmt.Errorf("no parameters provided for organization update")
+				return nil, errors.WithKind(errors.New("no parameters provided for organization update"), errors.ErrValidation)
 			}
 
 			logger := cc.logger.WithGroup("UpdateOrganization")
@@ -0,0 +1,169 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package s3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/pspecs"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+//go:generate command-generator -path bucket_commands.go
+
+func init() {
+	// * Bucket
+	cmds.Register(commands.Command{
+		Namespace: "S3",
+		Resource:  "Bucket",
+	})
+
+	// * ListBuckets
+	cmds.Register(commands.Command{
+		Namespace:                  "S3",
+		Resource:                   "Bucket",
+		Verb:                       "List",
+		ShortDocumentation:         "List the buckets",
+		LongDocumentation:          "List the S3 buckets of the organization",
+		AutoGenerate:               true,
+		AutoGenerateCustomFuncName: "ListBuckets",
+		ModelType:                  types.ModelListS3Buckets{},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			ep := endpoints.ListS3Buckets()
+
+			resp, err := cc.c.Do(
+				ctx,
+				ep,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list S3 buckets: %w", err)
+			}
+
+			return resp.Result().(*itypes.ApiResponseListS3Buckets).ToModel(), nil
+		},
+	})
+
+	// * CreateBucket
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "Bucket",
+		Verb:               "Create",
+		ShortDocumentation: "Create a bucket",
+		LongDocumentation:  "Create a S3 bucket. The name of the bucket must be unique across the S3 service",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsS3Bucket{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsS3Bucket)
+			ep := endpoints.CreateS3Bucket()
+
+			_, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create S3 bucket: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+
+	// * DeleteBucket
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "Bucket",
+		Verb:               "Delete",
+		ShortDocumentation: "Delete a bucket",
+		LongDocumentation:  "Delete a S3 bucket. The bucket must be empty",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsS3Bucket{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsS3Bucket)
+			ep := endpoints.DeleteS3Bucket()
+
+			_, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete S3 bucket: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+
+	// * BucketUsage
+	cmds.Register(commands.Command{
+		Namespace: "S3",
+		Resource:  "BucketUsage",
+	})
+
+	// * GetBucketUsage
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "BucketUsage",
+		Verb:               "Get",
+		ShortDocumentation: "Get the usage of a bucket",
+		LongDocumentation:  "Get the number of objects and the size used by a S3 bucket",
+		AutoGenerate:       true,
+		ModelType:          types.ModelS3BucketUsage{},
+		ParamsType:         types.ParamsS3Bucket{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsS3Bucket)
+			ep := endpoints.GetS3BucketUsage()
+
+			resp, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get S3 bucket usage: %w", err)
+			}
+
+			return resp.Result().(*itypes.ApiResponseGetS3BucketUsage).ToModel(p.BucketName), nil
+		},
+	})
+}
@@ -0,0 +1,296 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/pspecs"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+//go:generate command-generator -path bucket_configuration_commands.go
+
+// s3ErrorNoSuchLifecycleConfiguration is the code returned by S3 when the bucket has no lifecycle configuration.
+const s3ErrorNoSuchLifecycleConfiguration = "NoSuchLifecycleConfiguration"
+
+func init() {
+	// * BucketVersioning
+	cmds.Register(commands.Command{
+		Namespace: "S3",
+		Resource:  "BucketVersioning",
+	})
+
+	// * GetBucketVersioning
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "BucketVersioning",
+		Verb:               "Get",
+		ShortDocumentation: "Get the versioning of a bucket",
+		LongDocumentation:  "Get the versioning status of a S3 bucket. The status is empty if the versioning has never been enabled",
+		AutoGenerate:       true,
+		ModelType:          types.ModelS3BucketVersioning{},
+		ParamsType:         types.ParamsS3Bucket{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsS3Bucket)
+			ep := endpoints.GetS3BucketVersioning()
+
+			resp, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get S3 bucket versioning: %w", err)
+			}
+
+			return resp.Result().(*itypes.ApiS3BucketVersioning).ToModel(p.BucketName), nil
+		},
+	})
+
+	// * PutBucketVersioning
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "BucketVersioning",
+		Verb:               "Put",
+		ShortDocumentation: "Set the versioning of a bucket",
+		LongDocumentation:  "Enable or suspend the versioning of a S3 bucket. Once enabled, the versioning can only be suspended",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsPutS3BucketVersioning{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+			&pspecs.String{
+				Name:        "status",
+				Description: "The versioning status of the bucket.",
+				Required:    true,
+				Example:     "Enabled",
+				Validators: []validator.Validator{
+					validator.ValidatorOneOf("Enabled", "Suspended"),
+				},
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsPutS3BucketVersioning)
+			ep := endpoints.PutS3BucketVersioning()
+
+			_, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+				cav.SetBody(itypes.ApiS3BucketVersioning{
+					Status: p.Status,
+				}),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set S3 bucket versioning: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+
+	// * BucketLifecycle
+	cmds.Register(commands.Command{
+		Namespace: "S3",
+		Resource:  "BucketLifecycle",
+	})
+
+	// * GetBucketLifecycle
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "BucketLifecycle",
+		Verb:               "Get",
+		ShortDocumentation: "Get the lifecycle of a bucket",
+		LongDocumentation:  "Get the lifecycle rules of a S3 bucket. The rules are empty if the bucket has no lifecycle configuration",
+		AutoGenerate:       true,
+		ModelType:          types.ModelS3BucketLifecycle{},
+		ParamsType:         types.ParamsS3Bucket{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsS3Bucket)
+			ep := endpoints.GetS3BucketLifecycle()
+
+			resp, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+			)
+			if err != nil {
+				// S3 returns a 404 when the bucket has no lifecycle configuration.
+				var apiErr *errors.APIError
+				if errors.As(err, &apiErr) && apiErr.IsNotFound() && strings.HasPrefix(apiErr.Message, s3ErrorNoSuchLifecycleConfiguration) {
+					return &types.ModelS3BucketLifecycle{
+						BucketName: p.BucketName,
+						Rules:      []types.ModelS3BucketLifecycleRule{},
+					}, nil
+				}
+				return nil, fmt.Errorf("failed to get S3 bucket lifecycle: %w", err)
+			}
+
+			return resp.Result().(*itypes.ApiS3BucketLifecycle).ToModel(p.BucketName), nil
+		},
+	})
+
+	// * PutBucketLifecycle
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "BucketLifecycle",
+		Verb:               "Put",
+		ShortDocumentation: "Set the lifecycle of a bucket",
+		LongDocumentation:  "Set the lifecycle rules of a S3 bucket. The rules replace the existing lifecycle configuration",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsPutS3BucketLifecycle{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+			&pspecs.ListNested{
+				Name:        "rules",
+				Description: "The lifecycle rules of the bucket.",
+				Required:    true,
+				ItemsSpec: []pspecs.ParamSpec{
+					&pspecs.String{
+						Name:        "id",
+						Description: "The ID of the rule.",
+						Required:    true,
+						Example:     "expire-logs",
+					},
+					&pspecs.String{
+						Name:        "prefix",
+						Description: "The prefix of the objects concerned by the rule. If empty, the rule applies to all the objects.",
+						Required:    false,
+						Example:     "logs/",
+					},
+					&pspecs.Bool{
+						Name:        "disabled",
+						Description: "Disable the rule.",
+						Required:    false,
+						Example:     false,
+					},
+					&pspecs.Int{
+						Name:        "expiration_days",
+						Description: "The number of days after which the objects expire.",
+						Required:    false,
+						Example:     30,
+						Validators: []validator.Validator{
+							validator.ValidatorOmitempty(),
+							validator.ValidatorBetween(1, 3650),
+						},
+					},
+					&pspecs.Int{
+						Name:        "noncurrent_version_expiration_days",
+						Description: "The number of days after which the noncurrent versions of the objects expire.",
+						Required:    false,
+						Example:     7,
+						Validators: []validator.Validator{
+							validator.ValidatorOmitempty(),
+							validator.ValidatorBetween(1, 3650),
+						},
+					},
+					&pspecs.Int{
+						Name:        "abort_incomplete_multipart_upload_days",
+						Description: "The number of days after which the incomplete multipart uploads are aborted.",
+						Required:    false,
+						Example:     1,
+						Validators: []validator.Validator{
+							validator.ValidatorOmitempty(),
+							validator.ValidatorBetween(1, 3650),
+						},
+					},
+				},
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsPutS3BucketLifecycle)
+			ep := endpoints.PutS3BucketLifecycle()
+
+			_, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+				cav.SetBody(itypes.NewApiS3BucketLifecycle(p.Rules)),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set S3 bucket lifecycle: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+
+	// * DeleteBucketLifecycle
+	cmds.Register(commands.Command{
+		Namespace:          "S3",
+		Resource:           "BucketLifecycle",
+		Verb:               "Delete",
+		ShortDocumentation: "Delete the lifecycle of a bucket",
+		LongDocumentation:  "Delete all the lifecycle rules of a S3 bucket",
+		AutoGenerate:       true,
+		ParamsType:         types.ParamsS3Bucket{},
+		ParamsSpecs: pspecs.Params{
+			&pspecs.String{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Required:    true,
+				Example:     "my-bucket",
+			},
+		},
+		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
+			cc := client.(*Client)
+			p := params.(types.ParamsS3Bucket)
+			ep := endpoints.DeleteS3BucketLifecycle()
+
+			_, err := cc.c.Do(
+				ctx,
+				ep,
+				cav.WithPathParam(ep.PathParams[0], p.BucketName),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete S3 bucket lifecycle: %w", err)
+			}
+
+			return nil, nil
+		},
+	})
+}
@@ -0,0 +1,227 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package s3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+func TestBucketVersioning(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsPutS3BucketVersioning
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "Enable versioning",
+			params: types.ParamsPutS3BucketVersioning{
+				BucketName: "my-bucket",
+				Status:     "Enabled",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "Suspend versioning",
+			params: types.ParamsPutS3BucketVersioning{
+				BucketName: "my-bucket",
+				Status:     "Suspended",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "Invalid status",
+			params: types.ParamsPutS3BucketVersioning{
+				BucketName: "my-bucket",
+				Status:     "Disabled",
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+		{
+			name: "Bucket Not Found",
+			params: types.ParamsPutS3BucketVersioning{
+				BucketName: "my-bucket",
+				Status:     "Enabled",
+			},
+			mockResponseStatus: http.StatusNotFound,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				endpoints.PutS3BucketVersioning().CleanMockResponse()
+				endpoints.PutS3BucketVersioning().SetMockResponse(nil, &tt.mockResponseStatus)
+				t.Cleanup(endpoints.PutS3BucketVersioning().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			err := client.PutBucketVersioning(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+
+			resp, err := client.GetBucketVersioning(t.Context(), types.ParamsS3Bucket{BucketName: tt.params.BucketName})
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.Equal(t, tt.params.BucketName, resp.BucketName)
+			assert.Contains(t, []string{"Enabled", "Suspended"}, resp.Status)
+		})
+	}
+}
+
+func TestPutBucketLifecycle(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      types.ParamsPutS3BucketLifecycle
+		expectedErr bool
+	}{
+		{
+			name: "PutBucketLifecycle OK",
+			params: types.ParamsPutS3BucketLifecycle{
+				BucketName: "my-bucket",
+				Rules: []types.ParamsS3BucketLifecycleRule{
+					{
+						ID:             "expire-logs",
+						Prefix:         "logs/",
+						ExpirationDays: 30,
+					},
+					{
+						ID:                                 "abort-uploads",
+						Disabled:                           true,
+						AbortIncompleteMultipartUploadDays: 1,
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "No rules provided",
+			params: types.ParamsPutS3BucketLifecycle{
+				BucketName: "my-bucket",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Rule without ID",
+			params: types.ParamsPutS3BucketLifecycle{
+				BucketName: "my-bucket",
+				Rules: []types.ParamsS3BucketLifecycleRule{
+					{
+						ExpirationDays: 30,
+					},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t)
+
+			err := client.PutBucketLifecycle(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+		})
+	}
+}
+
+func TestGetBucketLifecycle(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        types.ParamsS3Bucket
+		mockErrorCode string
+		mockStatus    int
+		expectedRules bool
+		expectedErr   bool
+	}{
+		{
+			name: "GetBucketLifecycle OK",
+			params: types.ParamsS3Bucket{
+				BucketName: "my-bucket",
+			},
+			expectedRules: true,
+		},
+		{
+			name: "No lifecycle configuration",
+			params: types.ParamsS3Bucket{
+				BucketName: "my-bucket",
+			},
+			mockErrorCode: "NoSuchLifecycleConfiguration",
+			mockStatus:    http.StatusNotFound,
+			expectedRules: false,
+		},
+		{
+			name: "Bucket Not Found",
+			params: types.ParamsS3Bucket{
+				BucketName: "my-bucket",
+			},
+			mockErrorCode: "NoSuchBucket",
+			mockStatus:    http.StatusNotFound,
+			expectedErr:   true,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockErrorCode != "" {
+				endpoints.GetS3BucketLifecycle().CleanMockResponse()
+				endpoints.GetS3BucketLifecycle().SetMockResponseFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "application/xml")
+					w.WriteHeader(tt.mockStatus)
+					w.Write([]byte(`<Error><Code>` + tt.mockErrorCode + `</Code><Message>error</Message></Error>`)) //nolint:errcheck
+				})
+				t.Cleanup(endpoints.GetS3BucketLifecycle().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			resp, err := client.GetBucketLifecycle(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.Equal(t, tt.params.BucketName, resp.BucketName)
+			if tt.expectedRules {
+				assert.NotEmpty(t, resp.Rules, "Rules should not be empty")
+			} else {
+				assert.Empty(t, resp.Rules, "Rules should be empty")
+			}
+		})
+	}
+}
+
+func TestDeleteBucketLifecycle(t *testing.T) {
+	client := newClient(t)
+
+	err := client.DeleteBucketLifecycle(t.Context(), types.ParamsS3Bucket{BucketName: "my-bucket"})
+	assert.NoError(t, err, "Unexpected error: %v", err)
+}
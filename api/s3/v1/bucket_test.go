@@ -0,0 +1,176 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package s3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+func TestListBuckets(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name:        "ListBuckets OK",
+			expectedErr: false,
+		},
+		{
+			name:               "Access denied",
+			mockResponseStatus: http.StatusForbidden,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				endpoints.ListS3Buckets().CleanMockResponse()
+				endpoints.ListS3Buckets().SetMockResponse(nil, &tt.mockResponseStatus)
+				t.Cleanup(endpoints.ListS3Buckets().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			resp, err := client.ListBuckets(t.Context())
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.NotNil(t, resp, "Response should not be nil")
+			assert.NotEmpty(t, resp.Buckets, "Buckets should not be empty")
+			for _, bucket := range resp.Buckets {
+				assert.NotEmpty(t, bucket.Name)
+			}
+		})
+	}
+}
+
+func TestCreateAndDeleteBucket(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsS3Bucket
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "Bucket OK",
+			params: types.ParamsS3Bucket{
+				BucketName: "my-bucket",
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+		{
+			name: "Invalid bucket name",
+			params: types.ParamsS3Bucket{
+				BucketName: "My_Bucket",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Bucket Not Found",
+			params: types.ParamsS3Bucket{
+				BucketName: "my-bucket",
+			},
+			mockResponseStatus: http.StatusNotFound,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				for _, ep := range []func() *cav.Endpoint{endpoints.CreateS3Bucket, endpoints.DeleteS3Bucket} {
+					ep().CleanMockResponse()
+					ep().SetMockResponse(nil, &tt.mockResponseStatus)
+					t.Cleanup(ep().CleanMockResponse)
+				}
+			}
+
+			client := newClient(t)
+
+			err := client.CreateBucket(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, "Unexpected error: %v", err)
+			}
+
+			err = client.DeleteBucket(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+		})
+	}
+}
+
+func TestGetBucketUsage(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsS3Bucket
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "GetBucketUsage OK",
+			params: types.ParamsS3Bucket{
+				BucketName: "my-bucket",
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+		{
+			name: "Bucket Not Found",
+			params: types.ParamsS3Bucket{
+				BucketName: "my-bucket",
+			},
+			mockResponseStatus: http.StatusNotFound,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				endpoints.GetS3BucketUsage().CleanMockResponse()
+				endpoints.GetS3BucketUsage().SetMockResponse(nil, &tt.mockResponseStatus)
+				t.Cleanup(endpoints.GetS3BucketUsage().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			resp, err := client.GetBucketUsage(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.NotNil(t, resp, "Response should not be nil")
+			assert.Equal(t, tt.params.BucketName, resp.BucketName)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package s3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+func TestListUsers(t *testing.T) {
+	client := newClient(t)
+
+	resp, err := client.ListUsers(t.Context())
+	assert.NoError(t, err, "Unexpected error: %v", err)
+	assert.NotNil(t, resp, "Response should not be nil")
+	assert.NotEmpty(t, resp.Users, "Users should not be empty")
+	for _, user := range resp.Users {
+		assert.NotEmpty(t, user.ID)
+		assert.NotEmpty(t, user.Name)
+	}
+}
+
+func TestCreateAndDeleteUser(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             types.ParamsS3User
+		mockResponseStatus int
+		expectedErr        bool
+	}{
+		{
+			name: "User OK",
+			params: types.ParamsS3User{
+				UserName: "backup-user",
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+		{
+			name: "User Conflict",
+			params: types.ParamsS3User{
+				UserName: "backup-user",
+			},
+			mockResponseStatus: http.StatusConflict,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockResponseStatus != 0 {
+				endpoints.CreateS3User().CleanMockResponse()
+				endpoints.CreateS3User().SetMockResponse(nil, &tt.mockResponseStatus)
+				t.Cleanup(endpoints.CreateS3User().CleanMockResponse)
+			}
+
+			client := newClient(t)
+
+			user, err := client.CreateUser(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.NotEmpty(t, user.ID)
+
+			err = client.DeleteUser(t.Context(), tt.params)
+			assert.NoError(t, err, "Unexpected error: %v", err)
+		})
+	}
+}
+
+func TestUserKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      types.ParamsS3User
+		expectedErr bool
+	}{
+		{
+			name: "UserKeys OK",
+			params: types.ParamsS3User{
+				UserName: "backup-user",
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "No params provided",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t)
+
+			key, err := client.CreateUserKey(t.Context(), tt.params)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.NotEmpty(t, key.AccessKey)
+			assert.NotEmpty(t, key.SecretKey, "The secret key should be returned on creation")
+
+			keys, err := client.ListUserKeys(t.Context(), tt.params)
+			assert.NoError(t, err, "Unexpected error: %v", err)
+			assert.Equal(t, tt.params.UserName, keys.UserName)
+			assert.NotEmpty(t, keys.Keys, "Keys should not be empty")
+			for _, k := range keys.Keys {
+				assert.NotEmpty(t, k.AccessKey)
+				assert.Empty(t, k.SecretKey, "The secret keys should never be listed")
+			}
+
+			err = client.DeleteUserKey(t.Context(), types.ParamsDeleteS3UserKey{
+				UserName:  tt.params.UserName,
+				AccessKey: key.AccessKey,
+			})
+			assert.NoError(t, err, "Unexpected error: %v", err)
+		})
+	}
+}
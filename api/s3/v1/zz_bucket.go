@@ -0,0 +1,46 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package s3
+
+import (
+	"context"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// List the S3 buckets of the organization
+func (c *Client) ListBuckets(ctx context.Context) (*types.ModelListS3Buckets, error) {
+	x, err := cmds.Get("S3", "Bucket", "List").Run(ctx, c, nil)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelListS3Buckets), nil
+}
+
+// Create a S3 bucket. The name of the bucket must be unique across the S3 service
+func (c *Client) CreateBucket(ctx context.Context, params types.ParamsS3Bucket) error {
+	_, err := cmds.Get("S3", "Bucket", "Create").Run(ctx, c, params)
+	return err
+}
+
+// Delete a S3 bucket. The bucket must be empty
+func (c *Client) DeleteBucket(ctx context.Context, params types.ParamsS3Bucket) error {
+	_, err := cmds.Get("S3", "Bucket", "Delete").Run(ctx, c, params)
+	return err
+}
+
+// Get the number of objects and the size used by a S3 bucket
+func (c *Client) GetBucketUsage(ctx context.Context, params types.ParamsS3Bucket) (*types.ModelS3BucketUsage, error) {
+	x, err := cmds.Get("S3", "BucketUsage", "Get").Run(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelS3BucketUsage), nil
+}
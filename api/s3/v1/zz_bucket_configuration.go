@@ -0,0 +1,52 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package s3
+
+import (
+	"context"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// Get the versioning status of a S3 bucket. The status is empty if the versioning has never been enabled
+func (c *Client) GetBucketVersioning(ctx context.Context, params types.ParamsS3Bucket) (*types.ModelS3BucketVersioning, error) {
+	x, err := cmds.Get("S3", "BucketVersioning", "Get").Run(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelS3BucketVersioning), nil
+}
+
+// Enable or suspend the versioning of a S3 bucket. Once enabled, the versioning can only be suspended
+func (c *Client) PutBucketVersioning(ctx context.Context, params types.ParamsPutS3BucketVersioning) error {
+	_, err := cmds.Get("S3", "BucketVersioning", "Put").Run(ctx, c, params)
+	return err
+}
+
+// Get the lifecycle rules of a S3 bucket. The rules are empty if the bucket has no lifecycle configuration
+func (c *Client) GetBucketLifecycle(ctx context.Context, params types.ParamsS3Bucket) (*types.ModelS3BucketLifecycle, error) {
+	x, err := cmds.Get("S3", "BucketLifecycle", "Get").Run(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelS3BucketLifecycle), nil
+}
+
+// Set the lifecycle rules of a S3 bucket. The rules replace the existing lifecycle configuration
+func (c *Client) PutBucketLifecycle(ctx context.Context, params types.ParamsPutS3BucketLifecycle) error {
+	_, err := cmds.Get("S3", "BucketLifecycle", "Put").Run(ctx, c, params)
+	return err
+}
+
+// Delete all the lifecycle rules of a S3 bucket
+func (c *Client) DeleteBucketLifecycle(ctx context.Context, params types.ParamsS3Bucket) error {
+	_, err := cmds.Get("S3", "BucketLifecycle", "Delete").Run(ctx, c, params)
+	return err
+}
@@ -0,0 +1,64 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package s3
+
+import (
+	"context"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// List the S3 users of the organization
+func (c *Client) ListUsers(ctx context.Context) (*types.ModelListS3Users, error) {
+	x, err := cmds.Get("S3", "User", "List").Run(ctx, c, nil)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelListS3Users), nil
+}
+
+// Create a S3 user. The user has no key until a key is created
+func (c *Client) CreateUser(ctx context.Context, params types.ParamsS3User) (*types.ModelS3User, error) {
+	x, err := cmds.Get("S3", "User", "Create").Run(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelS3User), nil
+}
+
+// Delete a S3 user and all its keys
+func (c *Client) DeleteUser(ctx context.Context, params types.ParamsS3User) error {
+	_, err := cmds.Get("S3", "User", "Delete").Run(ctx, c, params)
+	return err
+}
+
+// List the keys of a S3 user. The secret keys are not returned
+func (c *Client) ListUserKeys(ctx context.Context, params types.ParamsS3User) (*types.ModelListS3UserKeys, error) {
+	x, err := cmds.Get("S3", "UserKey", "List").Run(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelListS3UserKeys), nil
+}
+
+// Create a key for a S3 user. The secret key is only returned by this command, it cannot be retrieved later
+func (c *Client) CreateUserKey(ctx context.Context, params types.ParamsS3User) (*types.ModelS3UserKey, error) {
+	x, err := cmds.Get("S3", "UserKey", "Create").Run(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+	return x.(*types.ModelS3UserKey), nil
+}
+
+// Delete a key of a S3 user. The requests signed with the key are rejected
+func (c *Client) DeleteUserKey(ctx context.Context, params types.ParamsDeleteS3UserKey) error {
+	_, err := cmds.Get("S3", "UserKey", "Delete").Run(ctx, c, params)
+	return err
+}
@@ -14,6 +14,8 @@ import (
 	"fmt"
 	"slices"
 
+	"resty.dev/v3"
+
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/pspecs"
@@ -74,6 +76,20 @@ func init() { //nolint:gocyclo
 				Required:    false,
 				Example:     "gold",
 			},
+			&pspecs.String{
+				Name:        "filter",
+				Description: "FIQL filter to apply to the storage profiles, combined with the other parameters",
+				Required:    false,
+				Example:     "isDefaultStorageProfile==true",
+			},
+			&pspecs.Int{
+				Name:        "limit",
+				Description: "Maximum number of storage profiles to return. If not provided, all storage profiles are returned",
+				Required:    false,
+				Validators: []validator.Validator{
+					validator.ValidatorOmitempty(),
+				},
+			},
 		},
 		ModelType: types.ModelListStorageProfiles{},
 		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
@@ -82,48 +98,43 @@ func init() { //nolint:gocyclo
 
 			ep := endpoints.ListStorageProfile()
 
-			// Set the filter query parameter based on the provided parameters
-			// When Required, Validators and TransformFunc are used, use a temp variable to build the filter (ref: QueryParam)
-			var value string
-
-			var filters []string
+			// The fields are checked and transformed by the FilterFields of the endpoint.
+			q := cav.NewQuery()
 			if p.ID != "" {
-				filters = append(filters, "id=="+p.ID)
+				q.Where(cav.Eq("id", p.ID))
 			}
 			if p.Class != "" {
-				filters = append(filters, "name=="+p.Class) // Corrected from 'name' to 'class'
+				q.Where(cav.Eq("name", p.Class))
 			}
 			if p.VdcID != "" {
-				filters = append(filters, "vdc=="+p.VdcID)
+				q.Where(cav.Eq("vdc", p.VdcID))
 			}
 			if p.VdcName != "" {
-				filters = append(filters, "vdcName=="+p.VdcName)
+				q.Where(cav.Eq("vdcName", p.VdcName))
 			}
-			if len(filters) > 0 {
-				// Join all filters with ';' to allow multiple filters in the query param
-				value = filters[0]
-				if len(filters) > 1 {
-					value = ""
-					for i, f := range filters {
-						if i > 0 {
-							value += ";"
-						}
-						value += f
-					}
+			if p.Filter != "" {
+				f, err := cav.ParseFilter(p.Filter)
+				if err != nil {
+					return nil, err
 				}
+				q.Where(f)
 			}
 
 			// Execute the request with the query parameters
-			resp, err := cc.c.Do(
+			storageProfiles, err := cav.CollectPages(cav.Paginate(
 				ctx,
+				cc.c,
 				ep,
-				cav.WithQueryParam(ep.QueryParams[0], value),
-			)
+				func(resp *resty.Response) []itypes.ApiResponseListStorageProfile {
+					return resp.Result().(*itypes.ApiResponseListStorageProfiles).StorageProfiles
+				},
+				cav.WithQuery(q),
+			), p.Limit)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list VDC Storage Profiles: %w", err)
 			}
 
-			return resp.Result().(*itypes.ApiResponseListStorageProfiles).ToModel(), nil
+			return (&itypes.ApiResponseListStorageProfiles{StorageProfiles: storageProfiles}).ToModel(), nil
 		},
 	})
 
@@ -334,7 +345,7 @@ func init() { //nolint:gocyclo
 				return nil, err
 			}
 			if len(listSP.VDCS) == 0 {
-				return nil, errors.New("no VDC found with the provided ID or Name")
+				return nil, errors.WithKind(errors.New("no VDC found with the provided ID or Name"), errors.ErrNotFound)
 			}
 			if len(listSP.VDCS) > 1 {
 				return nil, errors.New("multiple VDCs found with the provided ID or Name, please specify a unique VDC")
@@ -347,7 +358,7 @@ func init() { //nolint:gocyclo
 
 			// Check if the storage profile to delete is the only one left
 			if len(vdc.StorageProfiles) == 1 {
-				return nil, errors.New("cannot delete storage profile, at least one storage profile must exist for a VDC")
+				return nil, errors.WithKind(errors.New("cannot delete storage profile, at least one storage profile must exist for a VDC"), errors.ErrValidation)
 			}
 
 			// Create api VDC request to delete all storage profiles
@@ -371,10 +382,10 @@ func init() { //nolint:gocyclo
 					if sp.Class == pSP.Class {
 						found = true
 						if sp.Default {
-							return nil, errors.Newf("cannot delete the default storage profile %s from VDC %s", sp.Class, p.VdcName)
+							return nil, errors.WithKind(errors.Newf("cannot delete the default storage profile %s from VDC %s", sp.Class, p.VdcName), errors.ErrValidation)
 						}
 						if sp.Used > 0 {
-							return nil, errors.Newf("cannot delete a non-empty storage profile %s from VDC %s", sp.Class, p.VdcName)
+							return nil, errors.WithKind(errors.Newf("cannot delete a non-empty storage profile %s from VDC %s", sp.Class, p.VdcName), errors.ErrConflict)
 						}
 						logger.DebugContext(ctx, "Deleting storage profile from VDC", "vdc_name", p.VdcName, "storage_profile_class", sp.Class)
 						apiR.VDC.StorageProfiles = append(apiR.VDC.StorageProfiles, itypes.ApiRequestVDCStorageProfile{
@@ -385,7 +396,7 @@ func init() { //nolint:gocyclo
 					}
 				}
 				if !found {
-					return nil, errors.Newf("storage profile class %s not found in VDC %s", pSP.Class, p.VdcName)
+					return nil, errors.WithKind(errors.Newf("storage profile class %s not found in VDC %s", pSP.Class, p.VdcName), errors.ErrNotFound)
 				}
 			}
 
@@ -489,7 +500,7 @@ func init() { //nolint:gocyclo
 				return nil, err
 			}
 			if len(listSP.VDCS) == 0 {
-				return nil, errors.New("no VDC found with the provided ID or Name")
+				return nil, errors.WithKind(errors.New("no VDC found with the provided ID or Name"), errors.ErrNotFound)
 			}
 			if len(listSP.VDCS) > 1 {
 				return nil, errors.New("multiple VDCs found with the provided ID or Name, please specify a unique VDC")
@@ -522,12 +533,12 @@ func init() { //nolint:gocyclo
 			for _, sp := range p.StorageProfiles {
 				// Check if the storage profile to update exists in the current storage profiles
 				if _, ok := currentStorageProfiles[sp.Class]; !ok {
-					return nil, fmt.Errorf("storage profile class %s not found in VDC %s", sp.Class, p.VdcName)
+					return nil, errors.WithKind(errors.Newf("storage profile class %s not found in VDC %s", sp.Class, p.VdcName), errors.ErrNotFound)
 				}
 
 				// If limit is set, check if the new limit is not less than the current used storage
 				if sp.Limit < currentStorageProfiles[sp.Class].Used && sp.Limit > 0 {
-					return nil, fmt.Errorf("new limit for storage profile %s cannot be less than the current used (%d GiB)", sp.Class, currentStorageProfiles[sp.Class].Used)
+					return nil, errors.WithKind(errors.Newf("new limit for storage profile %s cannot be less than the current used (%d GiB)", sp.Class, currentStorageProfiles[sp.Class].Used), errors.ErrValidation)
 				}
 
 				// Set the storage profile in the API request
@@ -557,7 +568,7 @@ func init() { //nolint:gocyclo
 			}
 
 			if defaultCount > 1 {
-				return nil, fmt.Errorf("multiple storage profiles have default=true, only one is allowed")
+				return nil, errors.WithKind(errors.New("multiple storage profiles have default=true, only one is allowed"), errors.ErrValidation)
 			}
 
 			_, err = cc.c.Do(
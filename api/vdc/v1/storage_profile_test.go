@@ -10,6 +10,7 @@
 package vdc
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -171,6 +172,7 @@ func TestAddStorageProfile(t *testing.T) {
 		// Specific mock response for ListVDC endpoint
 		mockResponseVDC       any
 		mockResponseVDCStatus int
+		renewSession          bool
 		expectedErr           bool
 	}{
 		{
@@ -224,6 +226,22 @@ func TestAddStorageProfile(t *testing.T) {
 			mockResponseStatus: 401,
 			expectedErr:        true,
 		},
+		{
+			name: "Session renewed",
+			params: types.ParamsAddStorageProfile{
+				VdcID:   generator.MustGenerate("{urn:vdc}"),
+				VdcName: "my-vdc",
+				StorageProfiles: []types.ParamsCreateVDCStorageProfile{
+					{
+						Class:   "gold",
+						Limit:   500,
+						Default: false,
+					},
+				},
+			},
+			renewSession: true,
+			expectedErr:  false, // The session is renewed and the request replayed.
+		},
 		{
 			name: "Error 404 VDC Not Found",
 			params: types.ParamsAddStorageProfile{
@@ -241,6 +259,11 @@ func TestAddStorageProfile(t *testing.T) {
 				endpoints.UpdateVdc().CleanMockResponse()
 				endpoints.UpdateVdc().SetMockResponse(tt.mockResponse, &tt.mockResponseStatus)
 			}
+			if tt.renewSession {
+				endpoints.UpdateVdc().SetMockResponseFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+				})
+			}
 
 			if tt.mockResponseVDCStatus != 0 {
 				endpoints.ListVdc().CleanMockResponse()
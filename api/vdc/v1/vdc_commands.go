@@ -15,6 +15,7 @@ import (
 	"slices"
 
 	"golang.org/x/sync/errgroup"
+	"resty.dev/v3"
 
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
@@ -22,6 +23,7 @@ import (
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
 )
 
@@ -62,6 +64,20 @@ func init() { //nolint:gocyclo
 					validator.ValidatorResourceName("vdc"),
 				},
 			},
+			&pspecs.String{
+				Name:        "filter",
+				Description: "FIQL filter to apply to the VDCs, combined with the id and the name",
+				Required:    false,
+				Example:     "name==vdc-*;numberOfVms=gt=2",
+			},
+			&pspecs.Int{
+				Name:        "limit",
+				Description: "Maximum number of VDCs to return. If not provided, all VDCs are returned",
+				Required:    false,
+				Validators: []validator.Validator{
+					validator.ValidatorOmitempty(),
+				},
+			},
 		},
 		ModelType: types.ModelListVDC{},
 		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
@@ -71,25 +87,36 @@ func init() { //nolint:gocyclo
 
 			logger := cc.logger.WithGroup("ListVDC")
 
-			qP := ""
+			q := cav.NewQuery()
 			if p.Name != "" {
-				qP = fmt.Sprintf("name==%s", p.Name)
+				q.Where(cav.Eq("name", p.Name))
 			}
 			if p.ID != "" {
-				qP = fmt.Sprintf("id==%s", p.ID)
+				q.Where(cav.Eq("id", p.ID))
+			}
+			if p.Filter != "" {
+				f, err := cav.ParseFilter(p.Filter)
+				if err != nil {
+					return nil, err
+				}
+				q.Where(f)
 			}
 
-			resp, err := cc.c.Do(
+			records, err := cav.CollectPages(cav.Paginate(
 				ctx,
+				cc.c,
 				ep,
-				cav.WithQueryParam(ep.QueryParams[0], qP),
-			)
+				func(resp *resty.Response) []itypes.ApiResponseListVDCRecord {
+					return resp.Result().(*itypes.ApiResponseListVDC).Records
+				},
+				cav.WithQuery(q),
+			), p.Limit)
 			if err != nil {
 				logger.ErrorContext(ctx, "Failed to get VDC", "error", err)
 				return nil, err
 			}
 
-			return resp.Result().(*itypes.ApiResponseListVDC).ToModel(), nil
+			return (&itypes.ApiResponseListVDC{Records: records}).ToModel(), nil
 		},
 		AutoGenerate: true,
 	})
@@ -144,7 +171,7 @@ func init() { //nolint:gocyclo
 
 			if len(results.VDCS) == 0 {
 				logger.ErrorContext(ctx, "No VDC found with the provided parameters", "id", p.ID, "name", p.Name)
-				return nil, fmt.Errorf("no VDC found with the provided parameters")
+				return nil, errors.WithKind(errors.New("no VDC found with the provided parameters"), errors.ErrNotFound)
 			}
 			vdc := results.VDCS[0]
 
@@ -363,7 +390,7 @@ func init() { //nolint:gocyclo
 				}
 			}
 			if !haveOneDefaultStorageProfile {
-				return nil, fmt.Errorf("at least one storage profile must be marked as default")
+				return nil, errors.WithKind(errors.New("at least one storage profile must be marked as default"), errors.ErrValidation)
 			}
 
 			ep := endpoints.CreateVdc()
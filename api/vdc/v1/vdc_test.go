@@ -10,6 +10,7 @@
 package vdc
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -418,6 +419,8 @@ func TestDeleteVDC(t *testing.T) {
 
 		mockGetVDCResponseStatus int
 
+		renewSession bool
+
 		expectedErr bool
 	}{
 		{
@@ -443,6 +446,14 @@ func TestDeleteVDC(t *testing.T) {
 			mockGetVDCResponseStatus: 404,
 			expectedErr:              true,
 		},
+		{
+			name: "Session renewed",
+			params: types.ParamsDeleteVDC{
+				ID: generator.MustGenerate("{urn:vdc}"),
+			},
+			renewSession: true,
+			expectedErr:  false, // The session is renewed and the request replayed.
+		},
 	}
 
 	for _, tt := range tests {
@@ -452,6 +463,11 @@ func TestDeleteVDC(t *testing.T) {
 				endpoints.DeleteVdc().CleanMockResponse()
 				endpoints.DeleteVdc().SetMockResponse(tt.mockResponse, &tt.mockResponseStatus)
 			}
+			if tt.renewSession {
+				endpoints.DeleteVdc().SetMockResponseFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+				})
+			}
 
 			if tt.mockGetVDCResponseStatus != 0 {
 				endpoints.ListVdc().CleanMockResponse()
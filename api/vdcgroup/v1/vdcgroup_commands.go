@@ -14,12 +14,15 @@ import (
 	"fmt"
 	"slices"
 
+	"resty.dev/v3"
+
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/pspecs"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/commands/validator"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
 )
 
@@ -59,6 +62,20 @@ func init() {
 					validator.ValidatorOmitempty(),
 				},
 			},
+			&pspecs.String{
+				Name:        "filter",
+				Description: "FIQL filter to apply to the Vdc Groups, combined with the id and the name",
+				Required:    false,
+				Example:     "name==group-*",
+			},
+			&pspecs.Int{
+				Name:        "limit",
+				Description: "Maximum number of Vdc Groups to return. If not provided, all Vdc Groups are returned",
+				Required:    false,
+				Validators: []validator.Validator{
+					validator.ValidatorOmitempty(),
+				},
+			},
 		},
 		ModelType: types.ModelListVdcGroup{},
 		RunnerFunc: func(ctx context.Context, cmd *commands.Command, client, params any) (any, error) {
@@ -68,25 +85,36 @@ func init() {
 
 			logger := cc.logger.WithGroup("ListVdcGroup")
 
-			qP := ""
+			q := cav.NewQuery()
 			if p.Name != "" {
-				qP = fmt.Sprintf("name==%s", p.Name)
+				q.Where(cav.Eq("name", p.Name))
 			}
 			if p.ID != "" {
-				qP = fmt.Sprintf("id==%s", p.ID)
+				q.Where(cav.Eq("id", p.ID))
+			}
+			if p.Filter != "" {
+				f, err := cav.ParseFilter(p.Filter)
+				if err != nil {
+					return nil, err
+				}
+				q.Where(f)
 			}
 
-			resp, err := cc.c.Do(
+			values, err := cav.CollectPages(cav.Paginate(
 				ctx,
+				cc.c,
 				ep,
-				cav.WithQueryParam(ep.QueryParams[0], qP),
-			)
+				func(resp *resty.Response) []itypes.ApiResponseListVdcGroupDetails {
+					return resp.Result().(*itypes.ApiResponseListVdcGroup).Values
+				},
+				cav.WithQuery(q),
+			), p.Limit)
 			if err != nil {
 				logger.Error("Failed to get Vdc", "error", err)
 				return nil, err
 			}
 
-			return resp.Result().(*itypes.ApiResponseListVdcGroup).ToModel(), nil
+			return (&itypes.ApiResponseListVdcGroup{Values: values}).ToModel(), nil
 		},
 		AutoGenerate: true,
 	})
@@ -135,7 +163,7 @@ func init() {
 			}
 
 			if vdcgroups == nil || len(vdcgroups.VdcGroups) == 0 {
-				return nil, fmt.Errorf("Vdc Group not found")
+				return nil, errors.WithKind(errors.New("Vdc Group not found"), errors.ErrNotFound)
 			}
 
 			if len(vdcgroups.VdcGroups) > 1 {
@@ -215,7 +243,7 @@ func init() {
 			rL := respList.Result().(*itypes.ApiResponseListVdcGroup)
 
 			if len(rL.Values) > 0 {
-				return nil, fmt.Errorf("Vdc Group already exists")
+				return nil, errors.WithKind(errors.New("Vdc Group already exists"), errors.ErrConflict)
 			}
 
 			cd := cav.GetExtraDataFromContext(respList.Request.Context())
@@ -240,16 +268,12 @@ func init() {
 
 			listVdc := make(map[string]string)
 			if necessaryRequestVdcID {
-				respListVdc, err := cc.c.Do(ctx, endpoints.ListVdc())
+				var err error
+				listVdc, err = cc.retrieveVdcIDsByName(ctx, p.Vdcs)
 				if err != nil {
 					cc.logger.Error("Failed to list Vdcs", "error", err)
 					return nil, err
 				}
-
-				// For each vdc
-				for _, vdc := range respListVdc.Result().(*itypes.ApiResponseListVDC).Records {
-					listVdc[vdc.Name] = vdc.ID
-				}
 			}
 
 			for _, vdc := range p.Vdcs {
@@ -415,16 +439,12 @@ func init() {
 
 				listVdc := make(map[string]string)
 				if necessaryRequestVdcID {
-					respListVdc, err := cc.c.Do(ctx, endpoints.ListVdc())
+					var err error
+					listVdc, err = cc.retrieveVdcIDsByName(ctx, p.Vdcs)
 					if err != nil {
 						cc.logger.Error("Failed to list Vdcs", "error", err)
 						return nil, err
 					}
-
-					// For each vdc
-					for _, vdc := range respListVdc.Result().(*itypes.ApiResponseListVDC).Records {
-						listVdc[vdc.Name] = vdc.ID
-					}
 				}
 
 				for _, vdc := range p.Vdcs {
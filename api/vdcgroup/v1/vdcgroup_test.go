@@ -191,7 +191,7 @@ func TestCreateVdcGroup(t *testing.T) {
 				Description: generator.MustGenerate("{sentence}"),
 				Vdcs: []types.ParamsCreateVdcGroupVdc{
 					{
-						Name: generator.MustGenerate("{word}"),
+						Name: "my-vdc",
 					},
 				},
 			},
@@ -199,7 +199,37 @@ func TestCreateVdcGroup(t *testing.T) {
 				Values: []itypes.ApiResponseListVdcGroupDetails{},
 			},
 			mockListVdcGroupResponseStatus: 200,
-			expectedErr:                    false,
+			mockListVdcResponse: &itypes.ApiResponseListVDC{
+				Records: []itypes.ApiResponseListVDCRecord{
+					{
+						HREF: generator.MustGenerate("{href_uuid}"),
+						Name: "my-vdc",
+					},
+				},
+			},
+			mockListVdcResponseStatus: 200,
+			expectedErr:               false,
+		},
+		{
+			name: "Error VDC not found",
+			params: types.ParamsCreateVdcGroup{
+				Name:        generator.MustGenerate("{word}"),
+				Description: generator.MustGenerate("{sentence}"),
+				Vdcs: []types.ParamsCreateVdcGroupVdc{
+					{
+						Name: "unknown-vdc",
+					},
+				},
+			},
+			mockListVdcGroupResponse: &itypes.ApiResponseListVdcGroup{
+				Values: []itypes.ApiResponseListVdcGroupDetails{},
+			},
+			mockListVdcGroupResponseStatus: 200,
+			mockListVdcResponse: &itypes.ApiResponseListVDC{
+				Records: []itypes.ApiResponseListVDCRecord{},
+			},
+			mockListVdcResponseStatus: 200,
+			expectedErr:               true,
 		},
 		{
 			name: "Error List VDCGroup",
@@ -399,11 +429,36 @@ func TestAddVdcToVdcGroup(t *testing.T) {
 				Name: generator.MustGenerate("{word}"),
 				Vdcs: []types.ParamsCreateVdcGroupVdc{
 					{
-						Name: generator.MustGenerate("{word}"),
+						Name: "my-vdc",
 					},
 				},
 			},
-			expectedErr: false,
+			mockListVdcResponse: &itypes.ApiResponseListVDC{
+				Records: []itypes.ApiResponseListVDCRecord{
+					{
+						HREF: generator.MustGenerate("{href_uuid}"),
+						Name: "my-vdc",
+					},
+				},
+			},
+			mockListVdcResponseStatus: 200,
+			expectedErr:               false,
+		},
+		{
+			name: "Failed VDC doesn't exist",
+			params: types.ParamsAddVdcToVdcGroup{
+				Name: generator.MustGenerate("{word}"),
+				Vdcs: []types.ParamsCreateVdcGroupVdc{
+					{
+						Name: "unknown-vdc",
+					},
+				},
+			},
+			mockListVdcResponse: &itypes.ApiResponseListVDC{
+				Records: []itypes.ApiResponseListVDCRecord{},
+			},
+			mockListVdcResponseStatus: 200,
+			expectedErr:               true,
 		},
 
 		{
@@ -0,0 +1,51 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package vdcgroup
+
+import (
+	"context"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// retrieveVdcIDsByName returns the IDs of the Vdcs without ID, indexed by name.
+// All the pages of the Vdcs are read, an error is returned if a Vdc does not exist.
+func (c *Client) retrieveVdcIDsByName(ctx context.Context, vdcs []types.ParamsCreateVdcGroupVdc) (map[string]string, error) {
+	records, err := cav.CollectPages(cav.Paginate(
+		ctx,
+		c.c,
+		endpoints.ListVdc(),
+		func(resp *resty.Response) []itypes.ApiResponseListVDCRecord {
+			return resp.Result().(*itypes.ApiResponseListVDC).Records
+		},
+	), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(records))
+	for _, record := range records {
+		ids[record.Name] = record.ID
+	}
+
+	for _, vdc := range vdcs {
+		if vdc.ID == "" && ids[vdc.Name] == "" {
+			return nil, errors.WithKind(errors.Newf("Vdc %s not found", vdc.Name), errors.ErrNotFound)
+		}
+	}
+
+	return ids, nil
+}
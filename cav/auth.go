@@ -11,6 +11,9 @@ package cav
 
 import (
 	"context"
+	"net/http"
+
+	"resty.dev/v3"
 )
 
 // Auth implements methods required for authentication.
@@ -24,6 +27,12 @@ type auth interface {
 	// IsInitialized checks if the authentication is initialized.
 	IsInitialized() bool
 
+	// renew forces a new authentication after the API rejected the session.
+	// rejected contains the headers of the rejected request, they are used to
+	// detect if another goroutine has already renewed the session in the meantime.
+	// Calls are serialized, so only one Refresh is done for concurrent callers.
+	renew(ctx context.Context, rejected http.Header) error
+
 	// Get session is used to retrieve the current session information.
 	// Usually, this would include details like the organization Name and token
 	// It will be used for storing session-related data in a secure cache.
@@ -34,4 +43,7 @@ type auth interface {
 
 	// getExtraData returns any additional data that should be included in the session.
 	getExtraData() map[string]string
+
+	// getHTTPClient returns the HTTP client used to authenticate.
+	getHTTPClient() *resty.Client
 }
@@ -0,0 +1,273 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"resty.dev/v3"
+
+	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
+	"github.com/orange-cloudavenue/common-go/validators"
+)
+
+var _ auth = (*cloudavenueAPIToken)(nil)
+
+// cloudavenueAPITokenExpiryMargin is the time before the expiry of the access token
+// from which the access token is refreshed.
+const cloudavenueAPITokenExpiryMargin = time.Minute
+
+// cloudavenueAPIToken implements the auth interface
+// for Cloudavenue authentication using a VMware API token (OAuth refresh token).
+type cloudavenueAPIToken struct {
+	// mu protects the session data (refreshToken, accessToken, expiresAt, organizationID, siteID)
+	// and serializes the calls to Refresh.
+	mu sync.RWMutex
+
+	logger       *slog.Logger
+	httpC        *resty.Client
+	refreshToken string
+	// rotated is true once the API has replaced the API token by a new refresh token,
+	// which is then stored in the session.
+	rotated        bool
+	accessToken    string
+	expiresAt      time.Time
+	organization   string
+	organizationID string
+	siteID         string
+	console        consoles.ConsoleName
+}
+
+func newCloudavenueAPIToken(c consoles.ConsoleName, organization, token string) (auth, error) {
+	at := &cloudavenueAPIToken{
+		logger:       xlogger.WithGroup("auth"),
+		console:      c,
+		organization: organization,
+		refreshToken: token,
+	}
+
+	if err := validators.New().Var(at.refreshToken, "required"); err != nil {
+		at.logger.Error("Failed to validate API token", "error", err)
+		return nil, err
+	}
+
+	if err := validators.New().Var(at.organization, "required"); err != nil {
+		at.logger.Error("Failed to validate organization", "error", err)
+		return nil, err
+	}
+
+	if ok := consoles.IsValidOrganizationName(organization); !ok {
+		at.logger.Error("Invalid organization name", "organization", organization)
+		return nil, errors.New("invalid organization name")
+	}
+
+	at.logger = at.logger.With("organization", at.organization)
+	at.httpC = httpclient.NewHTTPClient().SetBaseURL(c.GetAPIVCDEndpoint())
+
+	return at, nil
+}
+
+// Headers returns the HTTP headers required for authentication
+// using the access token.
+func (a *cloudavenueAPIToken) Headers() map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return map[string]string{
+		"Authorization": "Bearer " + a.accessToken,
+	}
+}
+
+// Refresh exchanges the API token for a new access token.
+// If the access token has already been refreshed by another goroutine, nothing is done.
+func (a *cloudavenueAPIToken) Refresh(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.isValid() {
+		a.logger.DebugContext(ctx, "Access token already refreshed by another request")
+		return nil
+	}
+
+	return a.refresh(ctx)
+}
+
+// renew exchanges the API token for a new access token after the API rejected the access token.
+// If the access token has already been renewed by another goroutine, nothing is done.
+func (a *cloudavenueAPIToken) renew(ctx context.Context, rejected http.Header) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.accessToken != "" && rejected.Get("Authorization") != "Bearer "+a.accessToken {
+		a.logger.DebugContext(ctx, "Access token already renewed by another request")
+		return nil
+	}
+
+	a.logger.DebugContext(ctx, "Access token rejected by the API, requesting a new one")
+	return a.refresh(ctx)
+}
+
+// refresh exchanges the API token for a new access token and retrieves the session information.
+// The caller must hold the lock.
+func (a *cloudavenueAPIToken) refresh(ctx context.Context) error {
+	logger := a.logger.WithGroup("refresh")
+
+	epToken, err := GetEndpoint("TokenVmware")
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get endpoint for TokenVmware", "error", err)
+		return fmt.Errorf("failed to get endpoint for TokenVmware: %w", err)
+	}
+
+	resp, err := epToken.requestInternalFunc(ctx, a.httpC, epToken,
+		WithPathParam(epToken.PathParams[0], a.organization),
+		SetCustomRestyOption(func(r *resty.Request) {
+			r.SetFormData(map[string]string{
+				"grant_type":    "refresh_token",
+				"refresh_token": a.refreshToken,
+			})
+		}),
+		SetCustomRestyOption(func(r *resty.Request) { r.SetError(&vmwareError{}) }),
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to exchange the API token", "error", err)
+		return err
+	}
+
+	if err := (&vmware{}).parseAPIError(epToken.Description, resp); err != nil {
+		a.accessToken = ""
+		logger.ErrorContext(ctx, "Failed to exchange the API token", "error", err)
+		return err
+	}
+
+	token := resp.Result().(*apiResponseTokenVmware)
+	if token.AccessToken == "" {
+		a.accessToken = ""
+		return errors.New("failed to exchange the API token: empty access token")
+	}
+
+	a.accessToken = token.AccessToken
+	a.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	if token.RefreshToken != "" && token.RefreshToken != a.refreshToken {
+		// The API can rotate the refresh token.
+		a.refreshToken = token.RefreshToken
+		a.rotated = true
+	}
+
+	// Retrieve the organization and site identifiers of the session.
+	epSession, err := GetEndpoint("SessionCurrentVmware")
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get endpoint for SessionCurrentVmware", "error", err)
+		return fmt.Errorf("failed to get endpoint for SessionCurrentVmware: %w", err)
+	}
+
+	resp, err = epSession.requestInternalFunc(ctx, a.httpC, epSession,
+		SetCustomRestyOption(func(r *resty.Request) { r.SetAuthToken(a.accessToken) }),
+		SetCustomRestyOption(func(r *resty.Request) { r.SetError(&vmwareError{}) }),
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve the session", "error", err)
+		return err
+	}
+
+	if err := (&vmware{}).parseAPIError(epSession.Description, resp); err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve the session", "error", err)
+		return err
+	}
+
+	session := resp.Result().(*apiResponseSessionVmware)
+	a.organizationID = session.Org.ID
+	a.siteID = session.Site.ID
+
+	logger.DebugContext(ctx, "Successfully exchanged the API token", "expiresAt", a.expiresAt)
+
+	return nil
+}
+
+// IsInitialized checks if the access token is set and not about to expire.
+// An access token about to expire is reported as not initialized to be refreshed before the request.
+func (a *cloudavenueAPIToken) IsInitialized() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.isValid()
+}
+
+// isValid checks if the access token is set and not about to expire.
+// The caller must hold the lock.
+func (a *cloudavenueAPIToken) isValid() bool {
+	return a.accessToken != "" && time.Now().Add(cloudavenueAPITokenExpiryMargin).Before(a.expiresAt)
+}
+
+// getSession retrieves the current session information.
+// The API token given to the client is not part of the session. Once the API has rotated it,
+// the new refresh token is stored, the API token given to the client being no longer valid.
+func (a *cloudavenueAPIToken) getSession() map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	session := map[string]string{
+		"organization":   a.organization,
+		"organizationID": a.organizationID,
+		"siteID":         a.siteID,
+		"bearer":         a.accessToken,
+		"expiresAt":      a.expiresAt.Format(time.RFC3339),
+	}
+	if a.rotated {
+		session["refreshToken"] = a.refreshToken
+	}
+	return session
+}
+
+// restoreSession restores session-related data from a secure cache.
+func (a *cloudavenueAPIToken) restoreSession(data map[string]string) error {
+	if data == nil {
+		return errors.New("invalid session data")
+	}
+
+	// A session without a valid expiry date is considered expired,
+	// the access token is refreshed before the first request.
+	expiresAt, _ := time.Parse(time.RFC3339, data["expiresAt"])
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.organization = data["organization"]
+	a.accessToken = data["bearer"]
+	a.expiresAt = expiresAt
+	a.organizationID = data["organizationID"]
+	a.siteID = data["siteID"]
+	if data["refreshToken"] != "" {
+		a.refreshToken = data["refreshToken"]
+		a.rotated = true
+	}
+	return nil
+}
+
+func (a *cloudavenueAPIToken) getExtraData() map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return map[string]string{
+		"organizationID": a.organizationID,
+		"siteID":         a.siteID,
+	}
+}
+
+// getHTTPClient returns the HTTP client exchanging the API token for a session.
+func (a *cloudavenueAPIToken) getHTTPClient() *resty.Client {
+	return a.httpC
+}
@@ -0,0 +1,121 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/doc/operations/POST-Oauth-Tenant-Token.html",
+		Name:             "TokenVmware",
+		Description:      "Exchange a VMware API token for an access token",
+		Method:           MethodPOST,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/oauth/tenant/{organization}/token",
+		PathParams: []PathParam{
+			{
+				Name:        "organization",
+				Description: "The name of the organization.",
+				Required:    true,
+			},
+		},
+		QueryParams: []QueryParam{},
+		RequestFunc: nil,
+		requestInternalFunc: func(ctx context.Context, client *resty.Client, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
+			r := client.R().
+				SetContext(ctx).
+				SetHeader("Accept", "application/json").
+				SetResult(&apiResponseTokenVmware{})
+
+			for _, opt := range opts {
+				if err := opt(endpoint, r); err != nil {
+					return nil, err
+				}
+			}
+
+			if isMockFromContext(ctx) {
+				// If the client is a mock client, we return a mock response.
+				return r.Post(endpoint.MockPath())
+			}
+
+			return r.Post(endpoint.PathTemplate)
+		},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") == "" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_request"}`))
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(apiResponseTokenVmware{
+				AccessToken: "mock-access-token",
+				TokenType:   "Bearer",
+				ExpiresIn:   3600,
+			})
+		}),
+	}.Register()
+
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-openapi/v38.1/cloudapi/1.0.0/sessions/current/get/",
+		Name:             "SessionCurrentVmware",
+		Description:      "Get the current VMware session",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/cloudapi/1.0.0/sessions/current",
+		PathParams:       []PathParam{},
+		QueryParams:      []QueryParam{},
+		RequestFunc:      nil,
+		requestInternalFunc: func(ctx context.Context, client *resty.Client, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
+			r := client.R().
+				SetContext(ctx).
+				SetHeader("Accept", "application/json;version="+vmwareVCDVersion).
+				SetResult(&apiResponseSessionVmware{})
+
+			for _, opt := range opts {
+				if err := opt(endpoint, r); err != nil {
+					return nil, err
+				}
+			}
+
+			if isMockFromContext(ctx) {
+				// If the client is a mock client, we return a mock response.
+				return r.Get(endpoint.MockPath())
+			}
+
+			return r.Get(endpoint.PathTemplate)
+		},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			resp := apiResponseSessionVmware{}
+
+			generator.MustStruct(&resp)
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resp)
+		}),
+	}.Register()
+}
+
+// apiResponseTokenVmware is the response of the OAuth token endpoint.
+type apiResponseTokenVmware struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
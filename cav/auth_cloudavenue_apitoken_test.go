@@ -0,0 +1,171 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
+)
+
+func Test_newCloudavenueAPIToken(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	tests := []struct {
+		name         string
+		organization string
+		token        string
+		expectError  bool
+	}{
+		{
+			name:         "Valid token",
+			organization: mockOrg,
+			token:        "test-token",
+			expectError:  false,
+		},
+		{
+			name:         "Empty token",
+			organization: mockOrg,
+			token:        "",
+			expectError:  true,
+		},
+		{
+			name:         "Empty organization",
+			organization: "",
+			token:        "test-token",
+			expectError:  true,
+		},
+		{
+			name:         "Bad org format",
+			organization: "bad-org-format",
+			token:        "test-token",
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := newCloudavenueAPIToken(console, tt.organization, tt.token)
+			if (err != nil) != tt.expectError {
+				t.Errorf("newCloudavenueAPIToken() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+			if !tt.expectError && auth == nil {
+				t.Error("Expected non-nil auth object")
+			}
+		})
+	}
+}
+
+func Test_CloudavenueAPIToken_Refresh(t *testing.T) {
+	c, err := newMockClient(WithCloudAvenueAPIToken("mock-api-token"))
+	assert.Nil(t, err, "Error creating mock client")
+
+	sc, err := c.(*client).identifyClient(t.Context(), ClientVmware)
+	assert.Nil(t, err)
+
+	cred, ok := sc.getCredential().(*cloudavenueAPIToken)
+	assert.True(t, ok, "The credential must be an API token")
+	assert.False(t, cred.IsInitialized())
+
+	// The access token is requested before the first request.
+	_, err = sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.True(t, cred.IsInitialized())
+	assert.Equal(t, "Bearer mock-access-token", cred.Headers()["Authorization"])
+	assert.NotEmpty(t, cred.getExtraData()["organizationID"])
+	assert.NotEmpty(t, cred.getExtraData()["siteID"])
+
+	// The access token about to expire must be refreshed.
+	cred.expiresAt = time.Now().Add(cloudavenueAPITokenExpiryMargin / 2)
+	assert.False(t, cred.IsInitialized())
+	_, err = sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.True(t, cred.IsInitialized())
+}
+
+func Test_CloudavenueAPIToken_Renew_AlreadyRenewed(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	a, err := newCloudavenueAPIToken(console, mockOrg, "test-token")
+	assert.Nil(t, err)
+
+	cred := a.(*cloudavenueAPIToken)
+	cred.accessToken = "renewed-access-token"
+
+	// The rejected request used an old access token, no call to the API is expected.
+	rejected := http.Header{}
+	rejected.Set("Authorization", "Bearer old-access-token")
+
+	assert.Nil(t, cred.renew(t.Context(), rejected))
+	assert.Equal(t, "renewed-access-token", cred.accessToken)
+}
+
+func Test_CloudavenueAPIToken_Refresh_AlreadyRefreshed(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	a, err := newCloudavenueAPIToken(console, mockOrg, "test-token")
+	assert.Nil(t, err)
+
+	// The access token has been refreshed by another goroutine while waiting for the lock,
+	// no call to the API is expected.
+	cred := a.(*cloudavenueAPIToken)
+	cred.accessToken = "refreshed-access-token"
+	cred.expiresAt = time.Now().Add(time.Hour)
+
+	assert.Nil(t, cred.Refresh(t.Context()))
+	assert.Equal(t, "refreshed-access-token", cred.accessToken)
+}
+
+func Test_CloudavenueAPIToken_Session(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	a, err := newCloudavenueAPIToken(console, mockOrg, "test-token")
+	assert.Nil(t, err)
+
+	cred := a.(*cloudavenueAPIToken)
+	cred.accessToken = "access-token"
+	cred.expiresAt = time.Now().Add(time.Hour).Truncate(time.Second)
+	cred.organizationID = "urn:vcloud:org:1"
+	cred.siteID = "urn:vcloud:site:1"
+
+	session := cred.getSession()
+	assert.NotContains(t, session, "refreshToken", "The API token must not be stored in the session")
+
+	restored, err := newCloudavenueAPIToken(console, mockOrg, "test-token")
+	assert.Nil(t, err)
+	assert.Nil(t, restored.restoreSession(session))
+	assert.True(t, restored.IsInitialized())
+	assert.Equal(t, cred.Headers(), restored.Headers())
+	assert.Equal(t, cred.getExtraData(), restored.getExtraData())
+	assert.True(t, cred.expiresAt.Equal(restored.(*cloudavenueAPIToken).expiresAt))
+
+	// A session without expiry date is considered expired.
+	delete(session, "expiresAt")
+	assert.Nil(t, restored.restoreSession(session))
+	assert.False(t, restored.IsInitialized())
+
+	assert.NotNil(t, restored.restoreSession(nil))
+
+	// The refresh token rotated by the API is stored in the session.
+	cred.refreshToken = "rotated-token"
+	cred.rotated = true
+	session = cred.getSession()
+	assert.Equal(t, "rotated-token", session["refreshToken"])
+
+	restored, err = newCloudavenueAPIToken(console, mockOrg, "test-token")
+	assert.Nil(t, err)
+	assert.Nil(t, restored.restoreSession(session))
+	assert.Equal(t, "rotated-token", restored.(*cloudavenueAPIToken).refreshToken)
+}
@@ -12,7 +12,10 @@ package cav
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"sync"
 
 	"resty.dev/v3"
 
@@ -26,6 +29,10 @@ var _ auth = (*cloudavenueCredential)(nil)
 // cloudavenueCredential implements the auth interface
 // for Cloudavenue authentication using a username and password.
 type cloudavenueCredential struct {
+	// mu protects the session data (bearer, organizationID, siteID)
+	// and serializes the calls to Refresh.
+	mu sync.RWMutex
+
 	logger         *slog.Logger
 	httpC          *resty.Client
 	username       string `validate:"required"`
@@ -80,6 +87,9 @@ func newCloudavenueCredential(c consoles.ConsoleName, organization, username, pa
 // Headers returns the HTTP headers required for authentication
 // using the CloudavenueCredential.
 func (c *cloudavenueCredential) Headers() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	headers := make(map[string]string)
 	headers["Authorization"] = "Bearer " + c.bearer
 	return headers
@@ -87,11 +97,39 @@ func (c *cloudavenueCredential) Headers() map[string]string {
 
 // Refresh is a placeholder method for refreshing the authentication token.
 func (c *cloudavenueCredential) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.refresh(ctx)
+}
+
+// renew drops the rejected bearer token and creates a new session
+// with the username and password.
+// If the bearer has already been renewed by another goroutine, nothing is done.
+func (c *cloudavenueCredential) renew(ctx context.Context, rejected http.Header) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.bearer != "" && rejected.Get("Authorization") != "Bearer "+c.bearer {
+		c.logger.DebugContext(ctx, "Session already renewed by another request")
+		return nil
+	}
+
+	c.logger.DebugContext(ctx, "Session rejected by the API, creating a new session")
+	// Reset the bearer to force the authentication with the username and password.
+	c.bearer = ""
+
+	return c.refresh(ctx)
+}
+
+// refresh refreshes the authentication token.
+// The caller must hold the lock.
+func (c *cloudavenueCredential) refresh(ctx context.Context) error {
 	logger := c.logger.WithGroup("refresh")
 	ep, err := GetEndpoint("SessionVmware")
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to get endpoint for CreateSessionVmware", "error", err)
-		return errors.New("failed to get endpoint for CreateSessionVmware: " + err.Error())
+		return fmt.Errorf("failed to get endpoint for CreateSessionVmware: %w", err)
 	}
 
 	opts := []EndpointRequestOption{}
@@ -141,11 +179,17 @@ func (c *cloudavenueCredential) Refresh(ctx context.Context) error {
 
 // IsInitialized checks if the CloudavenueCredential is initialized.
 func (c *cloudavenueCredential) IsInitialized() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.bearer != ""
 }
 
 // getSession retrieves the current session information.
 func (c *cloudavenueCredential) getSession() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return map[string]string{
 		"organization":   c.organization,
 		"organizationID": c.organizationID,
@@ -162,6 +206,9 @@ func (c *cloudavenueCredential) restoreSession(data map[string]string) error {
 
 	xlogger.Debug("Restoring session from cache", "data", data)
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.organization = data["organization"]
 	c.bearer = data["bearer"]
 	c.organizationID = data["organizationID"]
@@ -170,8 +217,16 @@ func (c *cloudavenueCredential) restoreSession(data map[string]string) error {
 }
 
 func (c *cloudavenueCredential) getExtraData() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return map[string]string{
 		"organizationID": c.organizationID,
 		"siteID":         c.siteID,
 	}
 }
+
+// getHTTPClient returns the HTTP client creating the sessions.
+func (c *cloudavenueCredential) getHTTPClient() *resty.Client {
+	return c.httpC
+}
@@ -42,7 +42,7 @@ func init() {
 				}
 			}
 
-			if isMockClient {
+			if isMockFromContext(ctx) {
 				// If the client is a mock client, we return a mock response.
 				return r.Post(endpoint.MockPath())
 			}
@@ -10,6 +10,7 @@
 package cav
 
 import (
+	"net/http"
 	"testing"
 
 	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
@@ -102,3 +103,30 @@ func Test_CloudavenueCredential_Refresh_with_Bearer(t *testing.T) {
 	// Ignore the error for this test case, as we are just testing the method call
 	_ = auth.Refresh(t.Context())
 }
+
+func Test_CloudavenueCredential_Renew_AlreadyRenewed(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	auth := &cloudavenueCredential{
+		logger:       xlog.GetGlobalLogger(),
+		httpC:        httpclient.NewHTTPClient().SetBaseURL(console.GetAPIVCDEndpoint()),
+		console:      console,
+		organization: mockOrg,
+		username:     "test-user",
+		password:     "test-pass",
+		bearer:       "renewed-bearer-token",
+	}
+
+	// The rejected request used an old bearer, the session has already been renewed
+	// by another request so no call to the API is expected.
+	rejected := http.Header{}
+	rejected.Set("Authorization", "Bearer old-bearer-token")
+
+	if err := auth.renew(t.Context(), rejected); err != nil {
+		t.Fatalf("renew() unexpected error: %v", err)
+	}
+
+	if auth.bearer != "renewed-bearer-token" {
+		t.Errorf("renew() bearer = %q, want %q", auth.bearer, "renewed-bearer-token")
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"resty.dev/v3"
+
+	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
+	"github.com/orange-cloudavenue/common-go/validators"
+)
+
+var _ auth = (*netbackupCredential)(nil)
+
+// netbackupCredentialExpiryMargin is the time before the expiry of the token
+// from which the token is refreshed.
+const netbackupCredentialExpiryMargin = time.Minute
+
+// netbackupCredential implements the auth interface
+// for Netbackup authentication using a username and password.
+// Netbackup has its own users, they are different from the Cloudavenue users.
+type netbackupCredential struct {
+	// mu protects the session data (token, expiresAt)
+	// and serializes the calls to Refresh.
+	mu sync.RWMutex
+
+	logger       *slog.Logger
+	httpC        *resty.Client
+	username     string
+	password     string
+	token        string
+	expiresAt    time.Time
+	organization string
+	console      consoles.ConsoleName
+}
+
+func newNetbackupCredential(c consoles.ConsoleName, organization, username, password string) (auth, error) {
+	nc := &netbackupCredential{
+		logger:       xlogger.WithGroup("auth").WithGroup("netbackup"),
+		console:      c,
+		organization: organization,
+		username:     username,
+		password:     password,
+	}
+
+	if err := validators.New().Var(nc.username, "required"); err != nil {
+		nc.logger.Error("Failed to validate username", "error", err)
+		return nil, err
+	}
+
+	if err := validators.New().Var(nc.password, "required"); err != nil {
+		nc.logger.Error("Failed to validate password", "error", err)
+		return nil, err
+	}
+
+	if !c.Services().Netbackup.IsEnabled() {
+		nc.logger.Error("Netbackup is not available on the console", "console", c.GetSiteID())
+		return nil, errors.New("netbackup is not available on the console " + string(c.GetSiteID()))
+	}
+
+	nc.logger = nc.logger.With("organization", nc.organization)
+	nc.httpC = httpclient.NewHTTPClient().SetBaseURL(c.GetNetbackupEndpoint())
+
+	return nc, nil
+}
+
+// Headers returns the HTTP headers required for authentication
+// using the Netbackup token.
+func (n *netbackupCredential) Headers() map[string]string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return map[string]string{
+		"Authorization": "Bearer " + n.token,
+	}
+}
+
+// Refresh creates a new Netbackup session with the username and password.
+func (n *netbackupCredential) Refresh(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.refresh(ctx)
+}
+
+// renew creates a new Netbackup session after the API rejected the token.
+// If the token has already been renewed by another goroutine, nothing is done.
+func (n *netbackupCredential) renew(ctx context.Context, rejected http.Header) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.token != "" && rejected.Get("Authorization") != "Bearer "+n.token {
+		n.logger.DebugContext(ctx, "Token already renewed by another request")
+		return nil
+	}
+
+	n.logger.DebugContext(ctx, "Token rejected by the API, creating a new session")
+	return n.refresh(ctx)
+}
+
+// refresh creates a new Netbackup session.
+// The caller must hold the lock.
+func (n *netbackupCredential) refresh(ctx context.Context) error {
+	logger := n.logger.WithGroup("refresh")
+
+	ep, err := GetEndpoint("TokenNetbackup")
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get endpoint for TokenNetbackup", "error", err)
+		return fmt.Errorf("failed to get endpoint for TokenNetbackup: %w", err)
+	}
+
+	resp, err := ep.requestInternalFunc(ctx, n.httpC, ep,
+		SetCustomRestyOption(func(r *resty.Request) { r.SetBasicAuth(n.username, n.password) }),
+		SetCustomRestyOption(func(r *resty.Request) { r.SetError(&netbackupError{}) }),
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to create the session", "error", err)
+		return err
+	}
+
+	if err := (&netbackup{}).parseAPIError(ep.Description, resp); err != nil {
+		n.token = ""
+		logger.ErrorContext(ctx, "Failed to create the session", "error", err)
+		return err
+	}
+
+	token := resp.Result().(*apiResponseTokenNetbackup)
+	if token.AccessToken == "" {
+		n.token = ""
+		return errors.New("failed to create the netbackup session: empty token")
+	}
+
+	n.token = token.AccessToken
+	n.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	logger.DebugContext(ctx, "Successfully created the session", "expiresAt", n.expiresAt)
+
+	return nil
+}
+
+// IsInitialized checks if the token is set and not about to expire.
+func (n *netbackupCredential) IsInitialized() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return n.token != "" && time.Now().Add(netbackupCredentialExpiryMargin).Before(n.expiresAt)
+}
+
+// getSession retrieves the current session information.
+// The password is not part of the session.
+func (n *netbackupCredential) getSession() map[string]string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return map[string]string{
+		"username":  n.username,
+		"bearer":    n.token,
+		"expiresAt": n.expiresAt.Format(time.RFC3339),
+	}
+}
+
+// restoreSession restores session-related data from a secure cache.
+// The session of another user is ignored.
+func (n *netbackupCredential) restoreSession(data map[string]string) error {
+	if data == nil {
+		return errors.New("invalid session data")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if data["username"] != n.username {
+		n.logger.Debug("Ignoring the session of another user")
+		return nil
+	}
+
+	// A session without a valid expiry date is considered expired.
+	expiresAt, _ := time.Parse(time.RFC3339, data["expiresAt"])
+
+	n.token = data["bearer"]
+	n.expiresAt = expiresAt
+	return nil
+}
+
+// getExtraData returns no data, the Netbackup session does not carry any organization information.
+func (n *netbackupCredential) getExtraData() map[string]string {
+	return map[string]string{}
+}
+
+// getHTTPClient returns the HTTP client requesting the Netbackup tokens.
+func (n *netbackupCredential) getHTTPClient() *resty.Client {
+	return n.httpC
+}
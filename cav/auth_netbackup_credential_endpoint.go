@@ -0,0 +1,73 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"resty.dev/v3"
+)
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/postToken",
+		Name:             "TokenNetbackup",
+		Description:      "Create a Netbackup session",
+		Method:           MethodPOST,
+		SubClient:        ClientNetbackup,
+		PathTemplate:     netbackupAPIPath + "/token",
+		PathParams:       []PathParam{},
+		QueryParams:      []QueryParam{},
+		RequestFunc:      nil,
+		requestInternalFunc: func(ctx context.Context, client *resty.Client, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
+			r := client.R().
+				SetContext(ctx).
+				SetHeader("Accept", "application/json").
+				SetResult(&apiResponseTokenNetbackup{})
+
+			for _, opt := range opts {
+				if err := opt(endpoint, r); err != nil {
+					return nil, err
+				}
+			}
+
+			if isMockFromContext(ctx) {
+				// If the client is a mock client, we return a mock response.
+				return r.Post(endpoint.MockPath())
+			}
+
+			return r.Post(endpoint.PathTemplate)
+		},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if _, _, ok := r.BasicAuth(); !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"Message":"Authorization has been denied for this request."}`))
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(apiResponseTokenNetbackup{
+				AccessToken: "mock-netbackup-token",
+				TokenType:   "Bearer",
+				ExpiresIn:   3600,
+			})
+		}),
+	}.Register()
+}
+
+// apiResponseTokenNetbackup is the response of the Netbackup token endpoint.
+type apiResponseTokenNetbackup struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
@@ -0,0 +1,140 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
+)
+
+func Test_newNetbackupCredential(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	tests := []struct {
+		name        string
+		console     consoles.ConsoleName
+		username    string
+		password    string
+		expectError bool
+	}{
+		{
+			name:     "Valid credential",
+			console:  console,
+			username: "user",
+			password: "password",
+		},
+		{
+			name:        "Empty username",
+			console:     console,
+			password:    "password",
+			expectError: true,
+		},
+		{
+			name:        "Empty password",
+			console:     console,
+			username:    "user",
+			expectError: true,
+		},
+		{
+			name:        "Netbackup disabled on the console",
+			console:     consoles.Console9,
+			username:    "user",
+			password:    "password",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cred, err := newNetbackupCredential(tt.console, mockOrg, tt.username, tt.password)
+			if tt.expectError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.NotNil(t, cred)
+		})
+	}
+}
+
+func Test_NetbackupCredential_Refresh(t *testing.T) {
+	c, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+
+	sc, err := c.(*client).identifyClient(t.Context(), ClientNetbackup)
+	assert.Nil(t, err)
+
+	cred, ok := sc.getCredential().(*netbackupCredential)
+	assert.True(t, ok, "The credential must be a Netbackup credential")
+	assert.False(t, cred.IsInitialized())
+
+	// The token is requested before the first request.
+	_, err = sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.True(t, cred.IsInitialized())
+	assert.Equal(t, "Bearer mock-netbackup-token", cred.Headers()["Authorization"])
+
+	// The token about to expire must be refreshed.
+	cred.expiresAt = time.Now().Add(netbackupCredentialExpiryMargin / 2)
+	assert.False(t, cred.IsInitialized())
+	_, err = sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.True(t, cred.IsInitialized())
+}
+
+func Test_NetbackupCredential_Renew_AlreadyRenewed(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	a, err := newNetbackupCredential(console, mockOrg, "user", "password")
+	assert.Nil(t, err)
+
+	cred := a.(*netbackupCredential)
+	cred.token = "renewed-token"
+
+	// The rejected request used an old token, no call to the API is expected.
+	rejected := http.Header{}
+	rejected.Set("Authorization", "Bearer old-token")
+
+	assert.Nil(t, cred.renew(t.Context(), rejected))
+	assert.Equal(t, "renewed-token", cred.token)
+}
+
+func Test_NetbackupCredential_Session(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	a, err := newNetbackupCredential(console, mockOrg, "user", "password")
+	assert.Nil(t, err)
+
+	cred := a.(*netbackupCredential)
+	cred.token = "token"
+	cred.expiresAt = time.Now().Add(time.Hour).Truncate(time.Second)
+
+	session := cred.getSession()
+	assert.NotContains(t, session, "password", "The password must not be stored in the session")
+
+	restored, err := newNetbackupCredential(console, mockOrg, "user", "password")
+	assert.Nil(t, err)
+	assert.Nil(t, restored.restoreSession(session))
+	assert.True(t, restored.IsInitialized())
+	assert.Equal(t, cred.Headers(), restored.Headers())
+
+	// The session of another user is ignored.
+	other, err := newNetbackupCredential(console, mockOrg, "other", "password")
+	assert.Nil(t, err)
+	assert.Nil(t, other.restoreSession(session))
+	assert.False(t, other.IsInitialized())
+
+	assert.NotNil(t, restored.restoreSession(nil))
+}
@@ -0,0 +1,191 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"resty.dev/v3"
+
+	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
+)
+
+var _ auth = (*s3Credential)(nil)
+
+// s3Credential implements the auth interface for the S3 object storage.
+// The S3 keys of the user are obtained from the Cloud Avenue S3 user API
+// with the Cloudavenue credential, they are used to sign the S3 requests.
+type s3Credential struct {
+	// mu protects the keys and serializes the calls to Refresh.
+	mu sync.RWMutex
+
+	logger       *slog.Logger
+	httpC        *resty.Client
+	cloudavenue  auth
+	accessKey    string
+	secretKey    string
+	organization string
+	console      consoles.ConsoleName
+}
+
+func newS3Credential(c consoles.ConsoleName, organization string, cloudavenue auth) (auth, error) {
+	sc := &s3Credential{
+		logger:       xlogger.WithGroup("auth").WithGroup("s3"),
+		console:      c,
+		organization: organization,
+		cloudavenue:  cloudavenue,
+	}
+
+	if cloudavenue == nil {
+		return nil, errors.New("the S3 credential requires a Cloudavenue credential")
+	}
+
+	if !c.Services().S3.IsEnabled() {
+		sc.logger.Error("S3 is not available on the console", "console", c.GetSiteID())
+		return nil, errors.New("s3 is not available on the console " + string(c.GetSiteID()))
+	}
+
+	sc.logger = sc.logger.With("organization", sc.organization)
+	sc.httpC = httpclient.NewHTTPClient().SetBaseURL(c.GetAPICerberusEndpoint())
+
+	return sc, nil
+}
+
+// Headers returns no header, the S3 requests are signed by the transport of the S3 sub-client.
+func (s *s3Credential) Headers() map[string]string {
+	return map[string]string{}
+}
+
+// keys returns the keys used to sign the S3 requests.
+func (s *s3Credential) keys() (accessKey, secretKey string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.accessKey, s.secretKey
+}
+
+// Refresh retrieves the S3 keys of the user.
+func (s *s3Credential) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.refresh(ctx)
+}
+
+// renew retrieves the S3 keys again after S3 rejected the access key.
+// If the keys have already been renewed by another goroutine, nothing is done.
+func (s *s3Credential) renew(ctx context.Context, rejected http.Header) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessKey != "" && !strings.Contains(rejected.Get("Authorization"), "Credential="+s.accessKey+"/") {
+		s.logger.DebugContext(ctx, "S3 keys already renewed by another request")
+		return nil
+	}
+
+	s.logger.DebugContext(ctx, "Access key rejected by S3, retrieving the keys")
+	return s.refresh(ctx)
+}
+
+// refresh retrieves the S3 keys of the user with the Cloudavenue session.
+// The Cloudavenue session is renewed once if it is rejected.
+// The caller must hold the lock.
+func (s *s3Credential) refresh(ctx context.Context) error {
+	logger := s.logger.WithGroup("refresh")
+
+	if !s.cloudavenue.IsInitialized() {
+		if err := s.cloudavenue.Refresh(ctx); err != nil {
+			return err
+		}
+	}
+
+	ep, err := GetEndpoint("GetCredentialS3")
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get endpoint for GetCredentialS3", "error", err)
+		return fmt.Errorf("failed to get endpoint for GetCredentialS3: %w", err)
+	}
+
+	request := func() (*resty.Response, error) {
+		return ep.requestInternalFunc(ctx, s.httpC, ep,
+			SetCustomRestyOption(func(r *resty.Request) { r.SetHeaders(s.cloudavenue.Headers()) }),
+			SetCustomRestyOption(func(r *resty.Request) { r.SetError(&cerberusError{}) }),
+		)
+	}
+
+	resp, err := request()
+	if err == nil && (&cerberus{}).sessionExpired(resp) {
+		logger.DebugContext(ctx, "Cloudavenue session rejected, renewing it")
+		if err := s.cloudavenue.renew(ctx, resp.Request.Header); err != nil {
+			return err
+		}
+		resp, err = request()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve the S3 keys", "error", err)
+		return err
+	}
+
+	if err := (&cerberus{}).parseAPIError(ep.Description, resp); err != nil {
+		s.accessKey, s.secretKey = "", ""
+		logger.ErrorContext(ctx, "Failed to retrieve the S3 keys", "error", err)
+		return err
+	}
+
+	keys := resp.Result().(*apiResponseCredentialS3)
+	if keys.AccessKey == "" || keys.SecretKey == "" {
+		s.accessKey, s.secretKey = "", ""
+		return errors.New("failed to retrieve the S3 keys: empty keys")
+	}
+
+	s.accessKey, s.secretKey = keys.AccessKey, keys.SecretKey
+
+	logger.DebugContext(ctx, "Successfully retrieved the S3 keys", "accessKey", s.accessKey)
+
+	return nil
+}
+
+// IsInitialized checks if the S3 keys are set.
+func (s *s3Credential) IsInitialized() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.accessKey != "" && s.secretKey != ""
+}
+
+// getSession returns no data, the secret key is not stored in the session.
+// The keys are retrieved again by the next client.
+func (s *s3Credential) getSession() map[string]string {
+	return map[string]string{}
+}
+
+// restoreSession does nothing, the S3 session does not contain the keys.
+func (s *s3Credential) restoreSession(data map[string]string) error {
+	if data == nil {
+		return errors.New("invalid session data")
+	}
+	return nil
+}
+
+// getExtraData returns no data, the organization information is provided by the Cloudavenue credential.
+func (s *s3Credential) getExtraData() map[string]string {
+	return map[string]string{}
+}
+
+// getHTTPClient returns the HTTP client retrieving the S3 keys.
+func (s *s3Credential) getHTTPClient() *resty.Client {
+	return s.httpC
+}
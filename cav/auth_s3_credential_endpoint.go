@@ -0,0 +1,73 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/getS3Credentials",
+		Name:             "GetCredentialS3",
+		Description:      "Get the S3 keys of the authenticated user",
+		Method:           MethodGET,
+		SubClient:        ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/credentials",
+		PathParams:       []PathParam{},
+		QueryParams:      []QueryParam{},
+		RequestFunc:      nil,
+		requestInternalFunc: func(ctx context.Context, client *resty.Client, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
+			r := client.R().
+				SetContext(ctx).
+				SetHeader("Accept", "application/json;version="+cerberusVCDVersion).
+				SetResult(&apiResponseCredentialS3{})
+
+			for _, opt := range opts {
+				if err := opt(endpoint, r); err != nil {
+					return nil, err
+				}
+			}
+
+			if isMockFromContext(ctx) {
+				// If the client is a mock client, we return a mock response.
+				return r.Get(endpoint.MockPath())
+			}
+
+			return r.Get(endpoint.PathTemplate)
+		},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"code":"err-0001","reason":"Unauthorized","message":"not authenticated"}`))
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(apiResponseCredentialS3{
+				AccessKey: generator.MustGenerate("{regex:[A-Z0-9]{20}}"),
+				SecretKey: generator.MustGenerate("{regex:[A-Za-z0-9]{40}}"),
+			})
+		}),
+	}.Register()
+}
+
+// apiResponseCredentialS3 is the response of the S3 credentials endpoint.
+type apiResponseCredentialS3 struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
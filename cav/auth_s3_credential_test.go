@@ -0,0 +1,127 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package cav
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
+)
+
+func Test_newS3Credential(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	cloudavenue, err := newCloudavenueCredential(console, mockOrg, "user", "password")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name        string
+		console     consoles.ConsoleName
+		cloudavenue auth
+		expectError bool
+	}{
+		{
+			name:        "Valid credential",
+			console:     console,
+			cloudavenue: cloudavenue,
+		},
+		{
+			name:        "No Cloudavenue credential",
+			console:     console,
+			expectError: true,
+		},
+		{
+			name:        "S3 disabled on the console",
+			console:     consoles.Console4,
+			cloudavenue: cloudavenue,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cred, err := newS3Credential(tt.console, mockOrg, tt.cloudavenue)
+			if tt.expectError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.NotNil(t, cred)
+		})
+	}
+}
+
+func Test_S3Credential_Refresh(t *testing.T) {
+	c, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+
+	sc, err := c.(*client).identifyClient(t.Context(), ClientS3)
+	assert.Nil(t, err)
+
+	cred, ok := sc.getCredential().(*s3Credential)
+	assert.True(t, ok, "The credential must be a S3 credential")
+	assert.False(t, cred.IsInitialized())
+
+	// The keys are retrieved before the first request.
+	_, err = sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.True(t, cred.IsInitialized())
+	assert.Empty(t, cred.Headers(), "The S3 requests are signed by the transport")
+
+	accessKey, secretKey := cred.keys()
+	assert.NotEmpty(t, accessKey)
+	assert.NotEmpty(t, secretKey)
+}
+
+func Test_S3Credential_Renew_AlreadyRenewed(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	cloudavenue, err := newCloudavenueCredential(console, mockOrg, "user", "password")
+	assert.Nil(t, err)
+
+	a, err := newS3Credential(console, mockOrg, cloudavenue)
+	assert.Nil(t, err)
+
+	cred := a.(*s3Credential)
+	cred.accessKey, cred.secretKey = "RENEWEDKEY", "secret"
+
+	// The rejected request was signed with an old key, no call to the API is expected.
+	rejected := http.Header{}
+	rejected.Set("Authorization", "AWS4-HMAC-SHA256 Credential=OLDKEY/20250101/region01/s3/aws4_request")
+
+	assert.Nil(t, cred.renew(t.Context(), rejected))
+	accessKey, _ := cred.keys()
+	assert.Equal(t, "RENEWEDKEY", accessKey)
+}
+
+func Test_S3Credential_Session(t *testing.T) {
+	console, _ := consoles.FindByOrganizationName(mockOrg)
+
+	cloudavenue, err := newCloudavenueCredential(console, mockOrg, "user", "password")
+	assert.Nil(t, err)
+
+	a, err := newS3Credential(console, mockOrg, cloudavenue)
+	assert.Nil(t, err)
+
+	cred := a.(*s3Credential)
+	cred.accessKey, cred.secretKey = "ACCESSKEY", "secret"
+
+	session := cred.getSession()
+	assert.Empty(t, session, "The keys must not be stored in the session")
+
+	restored, err := newS3Credential(console, mockOrg, cloudavenue)
+	assert.Nil(t, err)
+	assert.Nil(t, restored.restoreSession(session))
+	assert.False(t, restored.IsInitialized(), "The keys are retrieved again by the next client")
+
+	assert.NotNil(t, restored.restoreSession(nil))
+}
@@ -10,143 +10,101 @@
 package cav
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"encoding/base64"
-	"encoding/gob"
-	"encoding/json"
-	"errors"
-	"io"
-	"os"
+	"context"
+	"maps"
+	"time"
 )
 
-type cachedSessions struct {
-	Sessions map[string]map[string]string `json:"sessions"`
-}
+// defaultSessionTTL is the lifetime of a stored session when the credential does not
+// provide the expiry date of its session.
+const defaultSessionTTL = 30 * time.Minute
 
-func (c *client) storeSessionsToCache(passphrase, path string) error {
-	cs := cachedSessions{
-		Sessions: map[string]map[string]string{},
+// currentSession returns the session of the sub-clients.
+func (c *client) currentSession() Session {
+	session := Session{
+		SubClients: make(map[string]map[string]string, len(c.clientsInitialized)),
+		UpdatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(defaultSessionTTL),
 	}
 
-	for _, value := range c.clientsInitialized {
-		cs.Sessions[value.getID()] = value.getCredential().getSession()
-	}
+	for _, sc := range c.clientsInitialized {
+		// A sub-client without session has nothing to store.
+		if !sc.getCredential().IsInitialized() {
+			continue
+		}
 
-	csJSON, err := json.Marshal(cs)
-	if err != nil {
-		return err
-	}
+		data := sc.getCredential().getSession()
+		session.SubClients[sc.getID()] = data
 
-	csEncrypted, err := encryptSessions([]byte(passphrase), string(csJSON))
-	if err != nil {
-		return err
+		// Use the expiry date of the credential if it is known.
+		if expiresAt, err := time.Parse(time.RFC3339, data["expiresAt"]); err == nil && expiresAt.Before(session.ExpiresAt) {
+			session.ExpiresAt = expiresAt
+		}
 	}
 
-	return writeGobFile(path, csEncrypted)
+	return session
 }
 
-func (c *client) restoreSessionsFromCache(passphrase, path string) error {
-	c.cachePassphrase = passphrase
-	c.cachePath = path
-
-	// check if cache file exist. If not exist ignore
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		c.logger.Warn("Cache file does not exist, skipping restoration", "path", path)
+// storeSessions saves the session of the sub-clients in the session store
+// if it has changed since the last save.
+func (c *client) storeSessions(ctx context.Context) error {
+	if c.sessionStore == nil {
 		return nil
 	}
 
-	var csEncrypted string
+	session := c.currentSession()
 
-	err := readGobFile(path, &csEncrypted)
-	if err != nil {
-		return err
-	}
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
 
-	csJSON, err := decryptSessions([]byte(passphrase), csEncrypted)
-	if err != nil {
-		return err
+	if sessionDataEqual(c.sessionSaved, session.SubClients) {
+		return nil
 	}
 
-	var cs cachedSessions
-	if err := json.Unmarshal([]byte(csJSON), &cs); err != nil {
+	if err := c.sessionStore.Save(ctx, c.organization, session); err != nil {
 		return err
 	}
 
-	for id, session := range cs.Sessions {
-		for _, value := range c.clientsInitialized {
-			if value.getID() == id {
-				if err := value.getCredential().restoreSession(session); err != nil {
-					return err
-				}
-			}
-		}
-	}
-
+	c.sessionSaved = session.SubClients
 	return nil
 }
 
-func encryptSessions(key []byte, plaintext string) (string, error) {
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
-	}
-
-	plaintextBytes := []byte(plaintext)
-	ciphertext := make([]byte, aes.BlockSize+len(plaintextBytes))
-
-	iv := ciphertext[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
-		return "", err
+// restoreSessions restores the session of the sub-clients from the session store.
+func (c *client) restoreSessions(ctx context.Context) error {
+	if c.sessionStore == nil {
+		return nil
 	}
 
-	stream := cipher.NewCTR(block, iv)
-	stream.XORKeyStream(ciphertext[aes.BlockSize:], plaintextBytes)
-
-	return base64.URLEncoding.EncodeToString(ciphertext), nil
-}
-
-func decryptSessions(key []byte, ciphertext string) (string, error) {
-	block, err := aes.NewCipher(key)
+	session, err := c.sessionStore.Load(ctx, c.organization)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	ciphertextBytes, err := base64.URLEncoding.DecodeString(ciphertext)
-	if err != nil {
-		return "", err
+	if session == nil {
+		c.logger.Debug("No session found in the session store")
+		return nil
 	}
 
-	if len(ciphertextBytes) < aes.BlockSize {
-		return "", errors.New("ciphertext is too short")
+	for id, data := range session.SubClients {
+		for _, sc := range c.clientsInitialized {
+			if sc.getID() == id {
+				if err := sc.getCredential().restoreSession(data); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
-	iv := ciphertextBytes[:aes.BlockSize]
-	ciphertextBytes = ciphertextBytes[aes.BlockSize:]
-
-	stream := cipher.NewCTR(block, iv)
-	stream.XORKeyStream(ciphertextBytes, ciphertextBytes)
-
-	return string(ciphertextBytes), nil
-}
+	c.sessionMu.Lock()
+	c.sessionSaved = session.SubClients
+	c.sessionMu.Unlock()
 
-func writeGobFile(path string, data interface{}) error {
-	file, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	encoder := gob.NewEncoder(file)
-	return encoder.Encode(data)
+	return nil
 }
 
-func readGobFile(path string, data interface{}) error {
-	file, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	decoder := gob.NewDecoder(file)
-	return decoder.Decode(data)
+// sessionDataEqual returns true if the session data of the sub-clients are equal.
+func sessionDataEqual(a, b map[string]map[string]string) bool {
+	return maps.EqualFunc(a, b, func(x, y map[string]string) bool {
+		return maps.Equal(x, y)
+	})
 }
@@ -0,0 +1,60 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SessionStore(t *testing.T) {
+	store := NewMemorySessionStore()
+
+	c, err := newMockClient(WithSessionStore(store))
+	assert.Nil(t, err)
+
+	// No session before the first request.
+	session, err := store.Load(t.Context(), mockOrg)
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	sc, err := c.(*client).identifyClient(t.Context(), ClientVmware)
+	assert.Nil(t, err)
+	_, err = sc.httpClient(t.Context())
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.(*client).storeSessions(t.Context()))
+
+	session, err = store.Load(t.Context(), mockOrg)
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+	assert.Equal(t, sc.getCredential().getSession(), session.SubClients[sc.getID()])
+
+	// A new client restores the session without authentication.
+	c2, err := newMockClient(WithSessionStore(store))
+	assert.Nil(t, err)
+
+	sc2, err := c2.(*client).identifyClient(t.Context(), ClientVmware)
+	assert.Nil(t, err)
+	assert.True(t, sc2.getCredential().IsInitialized())
+	assert.Equal(t, sc.getCredential().Headers(), sc2.getCredential().Headers())
+}
+
+func TestClient_WithSessionStore(t *testing.T) {
+	_, err := newMockClient(WithSessionStore(nil))
+	assert.NotNil(t, err)
+
+	_, err = newMockClient(WithCache("", ""))
+	assert.NotNil(t, err)
+
+	_, err = newMockClient(WithCache("passphrase", t.TempDir()+"/sessions"))
+	assert.Nil(t, err)
+}
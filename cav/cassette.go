@@ -0,0 +1,381 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"resty.dev/v3"
+)
+
+type (
+	// Cassette records the HTTP interactions of a client in a file and replays them.
+	// In record mode, the requests are sent to the API and each interaction is saved in the file.
+	// In replay mode, no request is sent: the responses are served from the file.
+	// The credentials are redacted from the recorded headers and bodies. See WithCassette.
+	Cassette struct {
+		path string
+		mode CassetteMode
+
+		mu           sync.Mutex
+		interactions []CassetteInteraction
+		// replayed marks the interactions already served in replay mode.
+		replayed []bool
+	}
+
+	// CassetteMode is the mode of a cassette.
+	CassetteMode int
+
+	// CassetteInteraction is a request and its response.
+	CassetteInteraction struct {
+		Request  CassetteRequest  `json:"request"`
+		Response CassetteResponse `json:"response"`
+	}
+
+	// CassetteRequest is a recorded request.
+	CassetteRequest struct {
+		Method string      `json:"method"`
+		Path   string      `json:"path"`
+		Query  string      `json:"query,omitempty"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	}
+
+	// CassetteResponse is a recorded response.
+	CassetteResponse struct {
+		StatusCode int         `json:"statusCode"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	}
+
+	// cassetteTransport sends the requests through the cassette.
+	cassetteTransport struct {
+		cassette *Cassette
+		next     http.RoundTripper
+	}
+)
+
+const (
+	// CassetteRecord sends the requests and records the interactions in the cassette file.
+	CassetteRecord CassetteMode = iota + 1
+	// CassetteReplay serves the interactions of the cassette file without sending the requests.
+	CassetteReplay
+)
+
+// cassetteRedacted replaces the redacted values.
+const cassetteRedacted = "REDACTED"
+
+var (
+	// cassetteRedactedHeaders are the headers holding credentials.
+	cassetteRedactedHeaders = []string{
+		"Authorization",
+		"Cookie",
+		"Set-Cookie",
+		cloudavenueCredentialXVmwareAccessToken,
+		"X-Vcloud-Authorization",
+		"X-Amz-Security-Token",
+	}
+
+	// cassetteRedactedFields are the JSON and form fields holding credentials, in lower case.
+	cassetteRedactedFields = map[string]struct{}{
+		"password":      {},
+		"secret":        {},
+		"secretkey":     {},
+		"secret_key":    {},
+		"token":         {},
+		"accesstoken":   {},
+		"access_token":  {},
+		"refreshtoken":  {},
+		"refresh_token": {},
+		"apitoken":      {},
+		"api_token":     {},
+	}
+)
+
+// NewCassette creates a cassette stored in the file at path.
+// In record mode, the file is created or truncated by the first interaction.
+// In replay mode, the file is read and must exist.
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	if path == "" {
+		return nil, errors.New("the cassette path cannot be empty")
+	}
+
+	c := &Cassette{
+		path: path,
+		mode: mode,
+	}
+
+	switch mode {
+	case CassetteRecord:
+	case CassetteReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &c.interactions); err != nil {
+			return nil, fmt.Errorf("failed to decode the cassette %s: %w", path, err)
+		}
+		c.replayed = make([]bool, len(c.interactions))
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %d", mode)
+	}
+
+	return c, nil
+}
+
+// Interactions returns the interactions of the cassette.
+func (c *Cassette) Interactions() []CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	interactions := make([]CassetteInteraction, len(c.interactions))
+	copy(interactions, c.interactions)
+	return interactions
+}
+
+// Transport returns a transport sending the requests through the cassette.
+// In record mode, the requests are sent with next (http.DefaultTransport if nil).
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+// wrap sends the requests of the HTTP client through the cassette.
+func (c *Cassette) wrap(hC *resty.Client) {
+	hC.SetTransport(c.Transport(hC.Transport()))
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recReq, err := newCassetteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.cassette.mode == CassetteReplay {
+		return t.cassette.replay(req, recReq)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := redactHeader(resp.Header)
+	header.Del("Date")
+
+	if err := t.cassette.record(CassetteInteraction{
+		Request: recReq,
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       redactBody(resp.Header.Get("Content-Type"), body),
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// record appends the interaction to the cassette and saves the cassette file.
+// The file is saved after each interaction, so it is complete even if the client is not closed.
+func (c *Cassette) record(interaction CassetteInteraction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, interaction)
+
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the cassette: %w", err)
+	}
+
+	// Write in a temporary file renamed afterwards, so a crash does not leave a truncated cassette.
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save the cassette: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save the cassette: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save the cassette: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to save the cassette: %w", err)
+	}
+
+	return nil
+}
+
+// replay returns the response of the first interaction matching the request not replayed yet.
+// When all the matching interactions have been replayed, the last one is replayed again,
+// so a job polled more times than during the recording keeps its final status.
+func (c *Cassette) replay(req *http.Request, recReq CassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+	for i, interaction := range c.interactions {
+		if !interaction.Request.matches(recReq) {
+			continue
+		}
+		match = i
+		if !c.replayed[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("cassette %s: no interaction recorded for %s %s", c.path, recReq.Method, req.URL.RequestURI())
+	}
+	c.replayed[match] = true
+
+	recResp := c.interactions[match].Response
+	header := recResp.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recResp.StatusCode, http.StatusText(recResp.StatusCode)),
+		StatusCode:    recResp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recResp.Body)),
+		ContentLength: int64(len(recResp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches returns true if the request has the same method, path, query and body.
+// The headers are ignored.
+func (r CassetteRequest) matches(other CassetteRequest) bool {
+	return r.Method == other.Method &&
+		r.Path == other.Path &&
+		r.Query == other.Query &&
+		r.Body == other.Body
+}
+
+// newCassetteRequest creates the redacted record of the request.
+// The body of the request is read and replaced to be sent afterwards.
+func newCassetteRequest(req *http.Request) (CassetteRequest, error) {
+	recReq := CassetteRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Header: redactHeader(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return CassetteRequest{}, fmt.Errorf("failed to read the request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		recReq.Body = redactBody(req.Header.Get("Content-Type"), body)
+	}
+
+	return recReq, nil
+}
+
+// redactHeader returns a copy of the header with the credentials redacted.
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, k := range cassetteRedactedHeaders {
+		if redacted.Get(k) != "" {
+			redacted.Set(k, cassetteRedacted)
+		}
+	}
+	return redacted
+}
+
+// redactBody returns the body with the credentials redacted.
+// The JSON and form bodies are normalized, so the bodies of the same request always match.
+func redactBody(contentType string, body []byte) string {
+	switch {
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		for k := range form {
+			if isRedactedField(k) {
+				form.Set(k, cassetteRedacted)
+			}
+		}
+		return form.Encode()
+
+	case json.Valid(body):
+		var v any
+		if err := json.Unmarshal(body, &v); err != nil {
+			return string(body)
+		}
+		normalized, err := json.Marshal(redactJSON(v))
+		if err != nil {
+			return string(body)
+		}
+		return string(normalized)
+
+	default:
+		return string(body)
+	}
+}
+
+// redactJSON redacts the credentials of the decoded JSON value.
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if isRedactedField(k) {
+				v[k] = cassetteRedacted
+				continue
+			}
+			v[k] = redactJSON(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return v
+}
+
+// isRedactedField returns true if the field holds a credential.
+func isRedactedField(name string) bool {
+	_, ok := cassetteRedactedFields[strings.ToLower(name)]
+	return ok
+}
@@ -0,0 +1,144 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	_, err := NewCassette("", CassetteRecord)
+	assert.NotNil(t, err, "Empty path must be rejected")
+
+	_, err = NewCassette(path, CassetteMode(0))
+	assert.NotNil(t, err, "Invalid mode must be rejected")
+
+	_, err = NewCassette(path, CassetteReplay)
+	assert.NotNil(t, err, "Missing cassette must be rejected in replay mode")
+
+	assert.Nil(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = NewCassette(path, CassetteReplay)
+	assert.NotNil(t, err, "Invalid cassette must be rejected in replay mode")
+
+	c, err := NewCassette(path, CassetteRecord)
+	assert.Nil(t, err)
+	assert.Empty(t, c.Interactions())
+}
+
+func Test_WithCassette(t *testing.T) {
+	s := newSettings(mockOrg)
+	assert.NotNil(t, WithCassette(nil)(s))
+}
+
+func Test_redactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{
+			name:        "json",
+			contentType: "application/json",
+			body:        `{"name":"bucket","secretKey":"s3cr3t","users":[{"Password":"p4ss","id":1}]}`,
+			expected:    `{"name":"bucket","secretKey":"REDACTED","users":[{"Password":"REDACTED","id":1}]}`,
+		},
+		{
+			name:        "json normalized",
+			contentType: "application/json",
+			body:        "{\n  \"b\": 1,\n  \"a\": 2\n}",
+			expected:    `{"a":2,"b":1}`,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=refresh_token&refresh_token=abc",
+			expected:    "grant_type=refresh_token&refresh_token=REDACTED",
+		},
+		{
+			name:        "xml",
+			contentType: "application/xml",
+			body:        "<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>",
+			expected:    "<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactBody(tt.contentType, []byte(tt.body)))
+		})
+	}
+}
+
+func Test_Do_Cassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	getEp, err := GetEndpoint("TestDryRunGet")
+	assert.Nil(t, err)
+	deleteEp, err := GetEndpoint("TestDryRunDelete")
+	assert.Nil(t, err)
+
+	// * Record
+	recorder, err := NewCassette(path, CassetteRecord)
+	assert.Nil(t, err)
+
+	client, err := newMockClient(WithCassette(recorder))
+	assert.Nil(t, err, "Error creating mock client")
+
+	dryRunCalls.Store(0)
+
+	_, err = client.Do(t.Context(), getEp, WithPathParam(getEp.PathParams[0], "abc"))
+	assert.Nil(t, err)
+	_, err = client.Do(t.Context(), deleteEp, WithPathParam(deleteEp.PathParams[0], "abc"))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), dryRunCalls.Load())
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "mockpassword")
+	assert.NotContains(t, string(data), "Basic ")
+	assert.Contains(t, string(data), cassetteRedacted)
+
+	// The authentication request is recorded with the requests of the endpoints.
+	interactions := recorder.Interactions()
+	assert.Greater(t, len(interactions), 2)
+	for _, interaction := range interactions {
+		if auth := interaction.Request.Header.Get("Authorization"); auth != "" {
+			assert.Equal(t, cassetteRedacted, auth)
+		}
+	}
+
+	// * Replay
+	player, err := NewCassette(path, CassetteReplay)
+	assert.Nil(t, err)
+
+	client, err = newMockClient(WithCassette(player))
+	assert.Nil(t, err, "Error creating mock client")
+
+	dryRunCalls.Store(0)
+
+	resp, err := client.Do(t.Context(), getEp, WithPathParam(getEp.PathParams[0], "abc"))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode())
+	_, err = client.Do(t.Context(), deleteEp, WithPathParam(deleteEp.PathParams[0], "abc"))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), dryRunCalls.Load(), "No request must be sent in replay mode")
+
+	// A request not recorded fails.
+	_, err = client.Do(t.Context(), getEp, WithPathParam(getEp.PathParams[0], "other"))
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(0), dryRunCalls.Load())
+}
@@ -13,22 +13,50 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 
+	"golang.org/x/sync/singleflight"
 	"resty.dev/v3"
 
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 )
 
-// isMockClient is a boolean flag to indicate if the client is a mock client.
-var isMockClient bool
-
 type client struct {
 	logger             *slog.Logger
 	console            consoles.ConsoleName
 	clientsInitialized map[subClientName]subClientInterface
 
-	cachePassphrase, cachePath string
+	organization string
+
+	// mock is true if the client is a mock client, its requests are sent to the mock path of the endpoints.
+	mock bool
+
+	// sessionStore stores the sessions of the sub-clients, it can be nil.
+	sessionStore SessionStore
+	// sessionSaved is the session data saved for the last time in the session store.
+	sessionSaved map[string]map[string]string
+	sessionMu    sync.Mutex
+
+	// retryPolicy is the retry policy of the requests, it can be overridden per endpoint.
+	retryPolicy RetryPolicy
+
+	// dryRun blocks the mutating requests, they are recorded in plan instead of being sent.
+	dryRun bool
+	plan   []PlannedRequest
+	planMu sync.Mutex
+
+	// telemetry receives the spans and the metrics of the calls.
+	telemetry Telemetry
+
+	// cache caches the responses of the GET requests, it is nil if the response cache is not enabled.
+	cache *responseCache
+
+	// inflight coalesces the identical GET requests sent concurrently.
+	inflight singleflight.Group
+	// inflightRequests are the contexts of the coalesced requests in flight, indexed by request key.
+	inflightRequests map[string]*coalescedRequest
+	inflightMu       sync.Mutex
 }
 
 type Client interface {
@@ -36,6 +64,12 @@ type Client interface {
 	Logger() *slog.Logger
 	Do(ctx context.Context, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error)
 	GetConsole() consoles.ConsoleName
+	// PlannedRequests returns the mutating requests not sent in dry-run mode (see WithDryRun).
+	PlannedRequests() []PlannedRequest
+	// CacheStats returns the statistics of the response cache (see WithResponseCache).
+	CacheStats() CacheStats
+	// CancelJob cancels a job returned by an asynchronous call (see WithAsyncJobs).
+	CancelJob(ctx context.Context, job *Job) error
 	Close() error
 }
 
@@ -55,7 +89,8 @@ func NewClient(organization string, opts ...ClientOption) (Client, error) {
 	}
 
 	client := &client{
-		console: settings.Console,
+		console:      settings.Console,
+		organization: settings.Organization,
 	}
 
 	for _, opt := range opts {
@@ -64,23 +99,55 @@ func NewClient(organization string, opts ...ClientOption) (Client, error) {
 		}
 	}
 
-	client.logger = xlogger.WithGroup("client").With("organization", settings.Organization)
-	client.clientsInitialized = settings.SubClients
+	client.dryRun = settings.DryRun
+
+	if settings.ResponseCacheTTL > 0 {
+		client.cache = newResponseCache(settings.ResponseCacheTTL)
+	}
 
 	// Detect if the client is a mock client based on the organization name.
 	// This is a simple heuristic to determine if the client is a mock client.
-	if organization == "cav01ev01ocb0001234" {
-		isMockClient = true
+	client.mock = organization == "cav01ev01ocb0001234"
+
+	client.telemetry = settings.Telemetry
+	if client.telemetry == nil {
+		client.telemetry = noopTelemetry{}
 	}
 
-	// Cache
-	// If caching is enabled, store the client in the cache.
-	if settings.CachePassphrase != "" && settings.CachePath != "" {
-		if err := client.restoreSessionsFromCache(settings.CachePassphrase, settings.CachePath); err != nil {
-			return nil, err
+	// The HTTP client settings are applied after all the options because the sub-clients
+	// are created by the credential options.
+	httpSettings := settings.HTTPClient
+	httpSettings.cassette = settings.Cassette
+	for _, sc := range settings.SubClients {
+		sc.setHTTPClientSettings(httpSettings)
+		sc.setMock(client.mock)
+
+		// The authentication requests are recorded or replayed with the other requests.
+		if cred := sc.getCredential(); settings.Cassette != nil && cred != nil {
+			settings.Cassette.wrap(cred.getHTTPClient())
 		}
 	}
 
+	client.logger = xlogger.WithGroup("client").With("organization", settings.Organization)
+	client.clientsInitialized = settings.SubClients
+
+	switch {
+	case settings.RetryPolicy != nil:
+		client.retryPolicy = *settings.RetryPolicy
+	case client.mock:
+		// The mock client uses short wait times to keep the tests fast.
+		client.retryPolicy = mockRetryPolicy()
+	default:
+		client.retryPolicy = DefaultRetryPolicy()
+	}
+
+	// Session store
+	// If a session store is defined, restore the sessions of the sub-clients from it.
+	client.sessionStore = settings.SessionStore
+	if err := client.restoreSessions(context.Background()); err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
 
@@ -138,11 +205,9 @@ func (c *client) Close() error {
 		return fmt.Errorf("failed to close some subclients: %v", errGroup)
 	}
 
-	if c.cachePassphrase != "" && c.cachePath != "" {
-		if err := c.storeSessionsToCache(c.cachePassphrase, c.cachePath); err != nil {
-			c.logger.Error("Failed to store sessions to cache", "error", err)
-			return err
-		}
+	if err := c.storeSessions(context.Background()); err != nil {
+		c.logger.Error("Failed to store sessions in the session store", "error", err)
+		return err
 	}
 
 	c.logger.Debug("Closing client", "console", c.console)
@@ -156,3 +221,11 @@ func (c *client) identifyClient(_ context.Context, cN subClientName) (subClientI
 	}
 	return c.clientsInitialized[cN], nil
 }
+
+// withMock marks the requests of the context as sent by a mock client if the client is a mock client.
+func (c *client) withMock(ctx context.Context) context.Context {
+	if !c.mock || isMockFromContext(ctx) {
+		return ctx
+	}
+	return storeMockInContext(ctx)
+}
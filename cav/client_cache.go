@@ -0,0 +1,187 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"resty.dev/v3"
+)
+
+type (
+	// CacheStats are the statistics of the response cache of a client (see WithResponseCache).
+	CacheStats struct {
+		// Hits is the number of GET requests answered from the cache.
+		Hits uint64
+		// Misses is the number of GET requests sent because the response was not in the cache or expired.
+		Misses uint64
+		// Invalidations is the number of responses removed from the cache by a mutating request.
+		Invalidations uint64
+		// Entries is the number of responses in the cache, including the expired ones not removed yet.
+		Entries int
+	}
+
+	// responseCache caches the responses of the GET requests for a TTL.
+	// The responses are indexed by endpoint and resolved URL.
+	responseCache struct {
+		ttl time.Duration
+
+		mu      sync.Mutex
+		entries map[string]responseCacheEntry
+		stats   CacheStats
+
+		// generations counts the invalidations of each family. A response received
+		// after an invalidation of its family, for a request sent before, is not cached.
+		generations map[string]uint64
+	}
+
+	responseCacheEntry struct {
+		resp    *resty.Response
+		family  string
+		expires time.Time
+	}
+)
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:         ttl,
+		entries:     make(map[string]responseCacheEntry),
+		generations: make(map[string]uint64),
+	}
+}
+
+// cacheable returns true if the responses of the endpoint can be cached.
+func (e *Endpoint) cacheable() bool {
+	return e.Method == MethodGET && !e.DisableCache
+}
+
+// cacheable returns true if the response of the request can be answered from the cache.
+// The headers are not part of the request key, they may change the response.
+func (ro *requestOption) cacheable() bool {
+	return len(ro.headers) == 0
+}
+
+// resourceFamily returns the family of the resources of the endpoint: its name without the verb,
+// e.g. EdgeGateway for ListEdgeGateway, UpdateEdgeGatewayBandwidth is in the EdgeGatewayBandwidth family.
+func (e *Endpoint) resourceFamily() string {
+	for i, r := range e.Name {
+		if i > 0 && unicode.IsUpper(r) {
+			return e.Name[i:]
+		}
+	}
+	return e.Name
+}
+
+// sameFamily returns true if a family is a prefix of the other,
+// e.g. the EdgeGateway and EdgeGatewayServices families.
+func sameFamily(a, b string) bool {
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// get returns the response cached for the key if it has not expired.
+func (c *responseCache) get(key string) (*resty.Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.stats.Hits++
+	return entry.resp, true
+}
+
+// generation returns the generation of the family of the endpoint, to capture before sending
+// the request and to pass to set with its response.
+func (c *responseCache) generation(endpoint *Endpoint) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generationLocked(endpoint.resourceFamily())
+}
+
+// generationLocked returns the number of invalidations of the family and of its related families.
+func (c *responseCache) generationLocked(family string) uint64 {
+	var generation uint64
+	for f, n := range c.generations {
+		if sameFamily(f, family) {
+			generation += n
+		}
+	}
+	return generation
+}
+
+// set caches the response unless the family of the endpoint has been invalidated
+// since the generation was captured: the response may be stale.
+func (c *responseCache) set(key string, endpoint *Endpoint, resp *resty.Response, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	family := endpoint.resourceFamily()
+	if c.generationLocked(family) != generation {
+		return
+	}
+
+	c.entries[key] = responseCacheEntry{
+		resp:    resp,
+		family:  family,
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+// invalidate removes the responses of the families modified by the mutating endpoint:
+// its own family and its InvalidatedFamilies.
+func (c *responseCache) invalidate(endpoint *Endpoint) {
+	families := append([]string{endpoint.resourceFamily()}, endpoint.InvalidatedFamilies...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, family := range families {
+		c.generations[family]++
+	}
+
+	for key, entry := range c.entries {
+		for _, family := range families {
+			if sameFamily(entry.family, family) {
+				delete(c.entries, key)
+				c.stats.Invalidations++
+				break
+			}
+		}
+	}
+}
+
+func (c *responseCache) getStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	return stats
+}
+
+// CacheStats returns the statistics of the response cache.
+// The statistics are zero if the response cache is not enabled (see WithResponseCache).
+func (c *client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.getStats()
+}
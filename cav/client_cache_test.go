@@ -0,0 +1,204 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"resty.dev/v3"
+)
+
+var cacheTestCalls = map[string]*atomic.Int32{
+	"GetTestCache":         {},
+	"UpdateTestCache":      {},
+	"GetTestCacheDisabled": {},
+	"GetTestCacheFamily":   {},
+	"UpdateTestUnrelated":  {},
+	"GetTestOther":         {},
+}
+
+func init() {
+	for name, calls := range cacheTestCalls {
+		ep := Endpoint{
+			DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-openapi/v38.1/",
+			Name:             name,
+			Description:      name,
+			Method:           MethodGET,
+			SubClient:        ClientVmware,
+			PathTemplate:     "/cloudapi/1.0.0/test/cache/" + name + "/{id}",
+			PathParams: []PathParam{
+				{
+					Name:        "id",
+					Description: "The identifier of the resource.",
+				},
+			},
+			MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{}`)) //nolint:errcheck
+			}),
+		}
+
+		switch name {
+		case "UpdateTestCache":
+			ep.Method = MethodPUT
+		case "UpdateTestUnrelated":
+			ep.Method = MethodPUT
+			ep.InvalidatedFamilies = []string{"TestOther"}
+		case "GetTestCacheDisabled":
+			ep.DisableCache = true
+		}
+
+		ep.Register()
+	}
+}
+
+func TestClient_ResponseCache(t *testing.T) {
+	c, err := newMockClient(WithResponseCache(time.Minute))
+	require.NoError(t, err)
+
+	do := func(name, id string) {
+		t.Helper()
+		ep := MustGetEndpoint(name)
+		_, err := c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], id))
+		require.NoError(t, err)
+	}
+	calls := func(name string) int32 {
+		return cacheTestCalls[name].Swap(0)
+	}
+	for name := range cacheTestCalls {
+		calls(name)
+	}
+
+	// The responses are cached by URL.
+	do("GetTestCache", "a")
+	do("GetTestCache", "a")
+	do("GetTestCache", "b")
+	assert.Equal(t, int32(2), calls("GetTestCache"))
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 2, Entries: 2}, c.CacheStats())
+
+	// The endpoints with DisableCache are not cached.
+	do("GetTestCacheDisabled", "a")
+	do("GetTestCacheDisabled", "a")
+	assert.Equal(t, int32(2), calls("GetTestCacheDisabled"))
+
+	// A mutation removes the responses of its family only.
+	do("GetTestCacheFamily", "a")
+	do("GetTestOther", "a")
+	do("UpdateTestCache", "a")
+	do("GetTestCache", "a")
+	do("GetTestOther", "a")
+	assert.Equal(t, int32(1), calls("GetTestCache"))
+	assert.Equal(t, int32(1), calls("GetTestOther"))
+
+	// GetTestCacheFamily is in the TestCacheFamily family, related to the TestCache family.
+	do("GetTestCacheFamily", "a")
+	assert.Equal(t, int32(2), calls("GetTestCacheFamily"))
+
+	// A mutation removes the responses of its InvalidatedFamilies.
+	do("UpdateTestUnrelated", "a")
+	do("GetTestOther", "a")
+	assert.Equal(t, int32(1), calls("GetTestOther"))
+	assert.NotZero(t, c.CacheStats().Invalidations)
+}
+
+func TestClient_ResponseCacheExpired(t *testing.T) {
+	c, err := newMockClient(WithResponseCache(20 * time.Millisecond))
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("GetTestCache")
+	cacheTestCalls["GetTestCache"].Store(0)
+
+	for range 2 {
+		_, err = c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], "expired"))
+		require.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+	}
+	assert.Equal(t, int32(2), cacheTestCalls["GetTestCache"].Load())
+}
+
+func TestClient_ResponseCacheRequestOptions(t *testing.T) {
+	c, err := newMockClient(WithResponseCache(time.Minute))
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("GetTestCache")
+	do := func(ctx context.Context) {
+		t.Helper()
+		_, err := c.Do(ctx, ep, WithPathParam(ep.PathParams[0], "options"))
+		require.NoError(t, err)
+	}
+	cacheTestCalls["GetTestCache"].Store(0)
+
+	// The options not changing the response do not prevent the caching.
+	do(ContextWithRequestOptions(t.Context(), WithoutRetry(), WithRequestTimeout(time.Minute)))
+	do(ContextWithRequestOptions(t.Context(), WithJobProgress(func(*Job) {})))
+	assert.Equal(t, int32(1), cacheTestCalls["GetTestCache"].Load())
+
+	// The requests with headers are always sent.
+	do(ContextWithRequestOptions(t.Context(), WithRequestHeader("X-Test", "a")))
+	assert.Equal(t, int32(2), cacheTestCalls["GetTestCache"].Load())
+}
+
+func TestResponseCache_SetAfterInvalidate(t *testing.T) {
+	c := newResponseCache(time.Minute)
+	get := MustGetEndpoint("GetTestCacheFamily")
+	update := MustGetEndpoint("UpdateTestCache")
+	resp := &resty.Response{}
+
+	// The response of a request sent before the invalidation of its family is not cached.
+	generation := c.generation(get)
+	c.invalidate(update)
+	c.set("stale", get, resp, generation)
+	_, ok := c.get("stale")
+	assert.False(t, ok)
+
+	// The response of a request sent after the invalidation is cached.
+	c.set("fresh", get, resp, c.generation(get))
+	_, ok = c.get("fresh")
+	assert.True(t, ok)
+
+	// The invalidation of an unrelated family does not prevent the caching.
+	generation = c.generation(get)
+	c.invalidate(MustGetEndpoint("UpdateTestUnrelated"))
+	c.set("unrelated", get, resp, generation)
+	_, ok = c.get("unrelated")
+	assert.True(t, ok)
+}
+
+func TestClient_WithResponseCache(t *testing.T) {
+	_, err := newMockClient(WithResponseCache(0))
+	assert.Error(t, err)
+
+	// The cache is not enabled by default.
+	c, err := newMockClient()
+	require.NoError(t, err)
+	assert.Equal(t, CacheStats{}, c.CacheStats())
+}
+
+func TestEndpoint_resourceFamily(t *testing.T) {
+	tests := map[string]string{
+		"ListEdgeGateway":            "EdgeGateway",
+		"UpdateEdgeGatewayBandwidth": "EdgeGatewayBandwidth",
+		"ListT0":                     "T0",
+		"Fake":                       "Fake",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, (&Endpoint{Name: name}).resourceFamily(), name)
+	}
+
+	assert.True(t, sameFamily("EdgeGateway", "EdgeGatewayBandwidth"))
+	assert.False(t, sameFamily("EdgeGateway", "T0"))
+}
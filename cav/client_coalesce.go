@@ -0,0 +1,181 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"resty.dev/v3"
+)
+
+// coalescedRequest is a request in flight shared by the identical requests.
+// Its context is not cancelled by the context of the caller which sent it,
+// it is cancelled when all the callers stopped waiting for the response.
+type coalescedRequest struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// requestKey identifies the identical requests: same sub-client, endpoint and URL,
+// the URL including the path and query parameters.
+func requestKey(endpoint *Endpoint, url string) string {
+	return string(endpoint.SubClient) + " " + endpoint.Name + " " + endpoint.Method.String() + " " + url
+}
+
+// coalescable returns true if the request can be shared with the identical requests.
+// The options changing the request or how it is sent (headers, idempotency key, timeout
+// and retries) are not part of the request key, the requests made with them are sent.
+// The job options do not change the GET requests.
+func (ro *requestOption) coalescable() bool {
+	return len(ro.headers) == 0 && ro.idempotencyKey == nil && ro.timeout == 0 && !ro.disableRetry
+}
+
+// coalesce calls send once for the identical requests in flight. The callers sharing the
+// response receive their own copy of it.
+// A caller whose context is done stops waiting, the request goes on for the other callers
+// and is cancelled when no caller waits for it anymore.
+// If the request is cancelled while a caller joins it, the caller sends it again.
+func (c *client) coalesce(ctx context.Context, key string, send func(ctx context.Context) (*resty.Response, error)) (*resty.Response, error) {
+	req := c.joinRequest(ctx, key)
+	defer c.leaveRequest(key, req)
+
+	ch := c.inflight.DoChan(key, func() (any, error) {
+		return send(req.ctx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			if res.Shared && ctx.Err() == nil && (errors.Is(res.Err, context.Canceled) || errors.Is(res.Err, context.DeadlineExceeded)) {
+				return send(ctx)
+			}
+			return nil, res.Err
+		}
+
+		resp := res.Val.(*resty.Response)
+		if res.Shared {
+			return copyResponse(resp), nil
+		}
+		return resp, nil
+	}
+}
+
+// joinRequest registers the caller as a waiter of the request in flight for the key.
+// The context of the request keeps the values of the context of its first caller.
+func (c *client) joinRequest(ctx context.Context, key string) *coalescedRequest {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+
+	if c.inflightRequests == nil {
+		c.inflightRequests = make(map[string]*coalescedRequest)
+	}
+
+	req, ok := c.inflightRequests[key]
+	if !ok {
+		req = &coalescedRequest{}
+		req.ctx, req.cancel = context.WithCancel(context.WithoutCancel(ctx))
+		c.inflightRequests[key] = req
+	}
+	req.waiters++
+	return req
+}
+
+// leaveRequest unregisters the caller, the request is cancelled when it was the last waiter.
+func (c *client) leaveRequest(key string, req *coalescedRequest) {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+
+	req.waiters--
+	if req.waiters > 0 {
+		return
+	}
+	req.cancel()
+	if c.inflightRequests[key] == req {
+		delete(c.inflightRequests, key)
+	}
+}
+
+// copyResponse returns a copy of the response with a copy of its decoded result,
+// so the caller can modify it without changing the response of the other callers.
+func copyResponse(resp *resty.Response) *resty.Response {
+	req := *resp.Request
+	req.Result = deepCopy(resp.Request.Result)
+
+	cp := *resp
+	cp.Request = &req
+	return &cp
+}
+
+// deepCopy returns a copy of v. The pointers, slices, maps and interfaces are copied recursively,
+// the unexported fields of the structs are copied as is.
+func deepCopy(v any) any {
+	if v == nil {
+		return nil
+	}
+
+	src := reflect.ValueOf(v)
+	dst := reflect.New(src.Type()).Elem()
+	copyValue(dst, src)
+	return dst.Interface()
+}
+
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(src.Elem().Type()))
+		copyValue(dst.Elem(), src.Elem())
+	case reflect.Struct:
+		dst.Set(src)
+		for i := range src.NumField() {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := range src.Len() {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Array:
+		for i := range src.Len() {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		for it := src.MapRange(); it.Next(); {
+			value := reflect.New(it.Value().Type()).Elem()
+			copyValue(value, it.Value())
+			dst.SetMapIndex(it.Key(), value)
+		}
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		value := reflect.New(src.Elem().Type()).Elem()
+		copyValue(value, src.Elem())
+		dst.Set(value)
+	default:
+		dst.Set(src)
+	}
+}
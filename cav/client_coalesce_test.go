@@ -0,0 +1,225 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"resty.dev/v3"
+)
+
+var coalesceTestCalls atomic.Int32
+
+type coalesceTestResponse struct {
+	Names []string          `json:"names"`
+	Tags  map[string]string `json:"tags"`
+	Child *struct {
+		Name string `json:"name"`
+	} `json:"child"`
+}
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-openapi/v38.1/",
+		Name:             "GetTestCoalesce",
+		Description:      "Get test coalesce",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/cloudapi/1.0.0/test/coalesce/{id}",
+		PathParams: []PathParam{
+			{
+				Name:        "id",
+				Description: "The identifier of the resource.",
+			},
+		},
+		BodyResponseType: coalesceTestResponse{},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			coalesceTestCalls.Add(1)
+			// The requests sent meanwhile are coalesced with this one.
+			time.Sleep(100 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"names":["a","b"],"tags":{"env":"prod"},"child":{"name":"c"}}`)) //nolint:errcheck
+		}),
+	}.Register()
+}
+
+func TestClient_DoCoalesce(t *testing.T) {
+	c, err := newMockClient()
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("GetTestCoalesce")
+
+	// The client authenticates before the concurrent requests.
+	_, err = c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], "warmup"))
+	require.NoError(t, err)
+	coalesceTestCalls.Store(0)
+
+	const callers = 10
+	results := make([]*coalesceTestResponse, callers)
+
+	wg := sync.WaitGroup{}
+	for i := range callers {
+		id := "a"
+		if i == callers-1 {
+			// A request with other path params is not coalesced.
+			id = "b"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], id))
+			if assert.NoError(t, err) {
+				results[i] = resp.Result().(*coalesceTestResponse)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), coalesceTestCalls.Load())
+
+	// Each caller receives its own copy of the result.
+	results[0].Names[0] = "changed"
+	results[0].Tags["env"] = "changed"
+	results[0].Child.Name = "changed"
+	for _, r := range results[1:] {
+		require.NotNil(t, r)
+		assert.Equal(t, []string{"a", "b"}, r.Names)
+		assert.Equal(t, "prod", r.Tags["env"])
+		assert.Equal(t, "c", r.Child.Name)
+	}
+}
+
+func TestClient_DoCoalesceSequential(t *testing.T) {
+	c, err := newMockClient()
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("GetTestCoalesce")
+	coalesceTestCalls.Store(0)
+
+	// The requests not in flight at the same time are sent.
+	for range 2 {
+		_, err := c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], "a"))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), coalesceTestCalls.Load())
+}
+
+func TestClient_DoCoalesceCallerCancelled(t *testing.T) {
+	c, err := newMockClient()
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("GetTestCoalesce")
+	_, err = c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], "warmup"))
+	require.NoError(t, err)
+	coalesceTestCalls.Store(0)
+
+	// The first caller is cancelled while the request is in flight.
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Millisecond)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := c.Do(ctx, ep, WithPathParam(ep.PathParams[0], "cancelled"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	resp, err := c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], "cancelled"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, resp.Result().(*coalesceTestResponse).Names)
+	wg.Wait()
+
+	// The request is not cancelled with the caller which sent it, the other caller shares it.
+	assert.Equal(t, int32(1), coalesceTestCalls.Load())
+}
+
+func TestClient_DoCoalesceRequestOptions(t *testing.T) {
+	c, err := newMockClient()
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("GetTestCoalesce")
+	_, err = c.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], "warmup"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		opts  func(i int) []RequestOption
+		calls int32
+	}{
+		{
+			name:  "Headers",
+			opts:  func(i int) []RequestOption { return []RequestOption{WithRequestHeader("X-Test", strconv.Itoa(i))} },
+			calls: 2,
+		},
+		{
+			name:  "Timeout",
+			opts:  func(int) []RequestOption { return []RequestOption{WithRequestTimeout(time.Minute)} },
+			calls: 2,
+		},
+		{
+			name:  "Without retry",
+			opts:  func(int) []RequestOption { return []RequestOption{WithoutRetry()} },
+			calls: 2,
+		},
+		{
+			// The job options do not change the GET requests.
+			name: "Job options",
+			opts: func(int) []RequestOption {
+				return []RequestOption{WithAsyncJobs(nil), WithoutAsyncJobs(), WithJobProgress(func(*Job) {})}
+			},
+			calls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coalesceTestCalls.Store(0)
+
+			wg := sync.WaitGroup{}
+			for i := range 2 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ctx := ContextWithRequestOptions(t.Context(), tt.opts(i)...)
+					_, err := c.Do(ctx, ep, WithPathParam(ep.PathParams[0], "options"))
+					assert.NoError(t, err)
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, tt.calls, coalesceTestCalls.Load())
+		})
+	}
+}
+
+func Test_copyResponse(t *testing.T) {
+	result := &coalesceTestResponse{Names: []string{"a"}}
+	resp := &resty.Response{Request: &resty.Request{Result: result, URL: "https://example.com"}}
+
+	cp := copyResponse(resp)
+	assert.Equal(t, "https://example.com", cp.Request.URL)
+	assert.Equal(t, result, cp.Result())
+	assert.NotSame(t, result, cp.Result())
+
+	cp.Result().(*coalesceTestResponse).Names[0] = "b"
+	assert.Equal(t, "a", result.Names[0])
+
+	assert.Nil(t, deepCopy(nil))
+}
@@ -0,0 +1,105 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PlannedRequest describes a mutating request that has not been sent because the client is in dry-run mode.
+// It is returned as an error by Client.Do, use errors.As to retrieve it:
+//
+//	var planned *cav.PlannedRequest
+//	if errors.As(err, &planned) {
+//		fmt.Println(planned.Method, planned.URL)
+//	}
+type PlannedRequest struct {
+	// Endpoint is the name of the endpoint.
+	Endpoint string `json:"endpoint"`
+	// Operation is the description of the endpoint.
+	Operation string `json:"operation"`
+	// Method is the HTTP method of the request.
+	Method string `json:"method"`
+	// URL is the URL of the request with the path and query parameters resolved.
+	URL string `json:"url"`
+	// Body is the JSON encoding of the request body, it is nil if the request has no body.
+	Body json.RawMessage `json:"body,omitempty"`
+	// ExpectJob is true if the API is expected to respond with a job (task) to wait for.
+	ExpectJob bool `json:"expectJob"`
+}
+
+// Error implements the error interface.
+func (p *PlannedRequest) Error() string {
+	return fmt.Sprintf("dry run: request %s %s not sent (endpoint: %s)", p.Method, p.URL, p.Endpoint)
+}
+
+// isMutating returns true if the method modifies the resources.
+func (m Method) isMutating() bool {
+	switch m {
+	case MethodPOST, MethodPUT, MethodPATCH, MethodDELETE:
+		return true
+	default:
+		return false
+	}
+}
+
+// expectsJob returns true if the API responds with a job (task) to wait for.
+func (e *Endpoint) expectsJob() bool {
+	switch e.BodyResponseType.(type) {
+	case Job, *Job:
+		return true
+	default:
+		return e.JobOptions != nil
+	}
+}
+
+// planRequest builds the request of the endpoint without sending it and records it in the plan of the client.
+func (c *client) planRequest(ctx context.Context, endpoint *Endpoint, opts ...EndpointRequestOption) (*PlannedRequest, error) {
+	req, u, err := c.resolveRequest(ctx, endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	planned := &PlannedRequest{
+		Endpoint:  endpoint.Name,
+		Operation: endpoint.Description,
+		Method:    endpoint.Method.String(),
+		URL:       u,
+		ExpectJob: endpoint.expectsJob(),
+	}
+
+	if req.Body != nil {
+		body, err := json.Marshal(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the body of the planned request: %w", err)
+		}
+		planned.Body = body
+	}
+
+	c.planMu.Lock()
+	c.plan = append(c.plan, *planned)
+	c.planMu.Unlock()
+
+	c.logger.InfoContext(ctx, "Dry run: request not sent", "endpoint", planned.Endpoint, "method", planned.Method, "url", planned.URL)
+
+	return planned, nil
+}
+
+// PlannedRequests returns the requests not sent by the client in dry-run mode, in the order of the calls.
+func (c *client) PlannedRequests() []PlannedRequest {
+	c.planMu.Lock()
+	defer c.planMu.Unlock()
+
+	plan := make([]PlannedRequest, len(c.plan))
+	copy(plan, c.plan)
+	return plan
+}
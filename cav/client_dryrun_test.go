@@ -0,0 +1,137 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package cav
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var dryRunCalls atomic.Int32
+
+type dryRunBody struct {
+	Name string `json:"name"`
+}
+
+func init() {
+	dryRunHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		dryRunCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`)) //nolint:errcheck
+	})
+
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestDryRunGet",
+		Description:      "Test dry run get",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/dryrun/{id}",
+		PathParams:       []PathParam{{Name: "id", Description: "ID", Required: true}},
+		MockResponseFunc: dryRunHandler,
+	}.Register()
+
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestDryRunCreate",
+		Description:      "Test dry run create",
+		Method:           MethodPOST,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/dryrun/{id}",
+		PathParams:       []PathParam{{Name: "id", Description: "ID", Required: true}},
+		QueryParams:      []QueryParam{{Name: "force", Description: "Force"}},
+		BodyRequestType:  dryRunBody{},
+		BodyResponseType: Job{},
+		MockResponseFunc: dryRunHandler,
+	}.Register()
+
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestDryRunDelete",
+		Description:      "Test dry run delete",
+		Method:           MethodDELETE,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/dryrun/{id}",
+		PathParams:       []PathParam{{Name: "id", Description: "ID", Required: true}},
+		MockResponseFunc: dryRunHandler,
+	}.Register()
+}
+
+func Test_Do_DryRun(t *testing.T) {
+	client, err := newMockClient(WithDryRun())
+	assert.Nil(t, err, "Error creating mock client")
+
+	getEp, err := GetEndpoint("TestDryRunGet")
+	assert.Nil(t, err)
+	createEp, err := GetEndpoint("TestDryRunCreate")
+	assert.Nil(t, err)
+	deleteEp, err := GetEndpoint("TestDryRunDelete")
+	assert.Nil(t, err)
+
+	dryRunCalls.Store(0)
+
+	// The GET requests are sent.
+	_, err = client.Do(t.Context(), getEp, WithPathParam(getEp.PathParams[0], "abc"))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), dryRunCalls.Load())
+
+	// The mutating requests are not sent.
+	resp, err := client.Do(t.Context(), createEp,
+		WithPathParam(createEp.PathParams[0], "a b"),
+		WithQueryParam(createEp.QueryParams[0], "true"),
+		SetBody(dryRunBody{Name: "test"}),
+	)
+	assert.Nil(t, resp)
+
+	var planned *PlannedRequest
+	assert.True(t, errors.As(err, &planned), "The error must be a PlannedRequest")
+	assert.Equal(t, "TestDryRunCreate", planned.Endpoint)
+	assert.Equal(t, createEp.Description, planned.Operation)
+	assert.Equal(t, http.MethodPost, planned.Method)
+	assert.Equal(t, client.GetConsole().GetAPIVCDEndpoint()+"/api/test/dryrun/a%20b?force=true", planned.URL)
+	assert.JSONEq(t, `{"name":"test"}`, string(planned.Body))
+	assert.True(t, planned.ExpectJob)
+
+	_, err = client.Do(t.Context(), deleteEp, WithPathParam(deleteEp.PathParams[0], "abc"))
+	assert.True(t, errors.As(err, &planned), "The error must be a PlannedRequest")
+	assert.Nil(t, planned.Body)
+	assert.False(t, planned.ExpectJob)
+
+	assert.Equal(t, int32(1), dryRunCalls.Load(), "The mutating requests must not be sent")
+
+	plan := client.PlannedRequests()
+	assert.Len(t, plan, 2)
+	assert.Equal(t, "TestDryRunCreate", plan[0].Endpoint)
+	assert.Equal(t, "TestDryRunDelete", plan[1].Endpoint)
+
+	// The validation errors of the request are returned instead of a plan.
+	_, err = client.Do(t.Context(), createEp, WithPathParam(createEp.PathParams[0], ""))
+	assert.NotNil(t, err)
+	assert.False(t, errors.As(err, &planned))
+	assert.Len(t, client.PlannedRequests(), 2)
+}
+
+func Test_Do_WithoutDryRun(t *testing.T) {
+	client, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+
+	ep, err := GetEndpoint("TestDryRunDelete")
+	assert.Nil(t, err)
+
+	dryRunCalls.Store(0)
+
+	_, err = client.Do(t.Context(), ep, WithPathParam(ep.PathParams[0], "abc"))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), dryRunCalls.Load())
+	assert.Empty(t, client.PlannedRequests())
+}
@@ -24,7 +24,9 @@ const (
 	mockOrg = "cav01ev01ocb0001234"
 )
 
-func newMockClient() (Client, error) {
+// newMockClient creates a client using a mock server.
+// The options are applied after the default options of the mock client.
+func newMockClient(opts ...ClientOption) (Client, error) {
 	// Mock implementation for testing purposes
 
 	// Get All endpoints available in the endpoint package
@@ -74,8 +76,7 @@ func newMockClient() (Client, error) {
 
 	xlogger.Debug("Mock server started", slog.String("url", hts.URL))
 
-	nC, err := NewClient(
-		mockOrg,
+	defaultOpts := []ClientOption{
 		WithCustomEndpoints(consoles.Services{
 			IHM: consoles.Service{
 				Enabled:  true,
@@ -91,15 +92,18 @@ func newMockClient() (Client, error) {
 			},
 			S3: consoles.Service{
 				Enabled:  true,
-				Endpoint: hts.URL + "/s3",
+				Endpoint: hts.URL,
 			},
 			Netbackup: consoles.Service{
 				Enabled:  true,
-				Endpoint: hts.URL + "/netbackup",
+				Endpoint: hts.URL,
 			},
 		}),
 		WithCloudAvenueCredential("mockuser", "mockpassword"),
-	)
+		WithNetbackupCredential("mockuser", "mockpassword"),
+	}
+
+	nC, err := NewClient(mockOrg, append(defaultOpts, opts...)...)
 	if err != nil {
 		return nil, err
 	}
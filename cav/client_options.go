@@ -12,7 +12,9 @@ package cav
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/xlog"
@@ -27,10 +29,20 @@ type settings struct {
 	Console consoles.ConsoleName
 	// SubClients contains the sub-clients for the client.
 	SubClients map[subClientName]subClientInterface
-	// CachePassphrase is the passphrase used for caching.
-	CachePassphrase string
-	// CachePath is the path to the cache file.
-	CachePath string
+	// SessionStore stores the sessions of the sub-clients.
+	SessionStore SessionStore
+	// HTTPClient contains the settings of the HTTP clients of the sub-clients.
+	HTTPClient HTTPClientSettings
+	// RetryPolicy is the retry policy of the requests.
+	RetryPolicy *RetryPolicy
+	// DryRun blocks the mutating requests.
+	DryRun bool
+	// Telemetry receives the spans and the metrics of the calls.
+	Telemetry Telemetry
+	// Cassette records or replays the requests.
+	Cassette *Cassette
+	// ResponseCacheTTL is the time the responses of the GET requests are cached, 0 if they are not cached.
+	ResponseCacheTTL time.Duration
 }
 
 func newSettings(organization string) *settings {
@@ -82,19 +94,84 @@ func WithCloudAvenueCredential(username, password string) ClientOption {
 			return err
 		}
 
-		for _, client := range []subClientName{ClientCerberus, ClientVmware} {
-			if _, ok := s.SubClients[client]; !ok {
-				s.SubClients[client] = subClients[client]
-			}
+		return setSubClientsCredential(s, cred)
+	}
+}
+
+// WithCloudAvenueAPIToken sets an API token as credential for the client.
+// The API token (VMware API refresh token) is exchanged for an access token,
+// which is refreshed before its expiry. If the API rotates the refresh token, the new one
+// is kept in the session store (see WithSessionStore) for the next clients.
+func WithCloudAvenueAPIToken(token string) ClientOption {
+	return func(s *settings) error {
+		logger := xlogger.WithGroup("client").WithGroup("options").WithGroup("WithCloudAvenueAPIToken")
+
+		// auth cloudavenue is shared between sub-clients vmware and cerberus.
+		cred, err := newCloudavenueAPIToken(s.Console, s.Organization, token)
+		if err != nil {
+			logger.Error("Failed to create Cloudavenue API token credential", "error", err)
+			return err
+		}
+
+		return setSubClientsCredential(s, cred)
+	}
+}
+
+// WithNetbackupCredential sets the credential of the Netbackup sub-client.
+// Netbackup has its own users, the Cloudavenue credential is not used to access Netbackup.
+func WithNetbackupCredential(username, password string) ClientOption {
+	return func(s *settings) error {
+		logger := xlogger.WithGroup("client").WithGroup("options").WithGroup("WithNetbackupCredential")
+
+		cred, err := newNetbackupCredential(s.Console, s.Organization, username, password)
+		if err != nil {
+			logger.Error("Failed to create Netbackup credential", "error", err)
+			return err
+		}
 
-			s.SubClients[client].setConsole(s.Console)
-			s.SubClients[client].setCredential(cred)
+		if _, ok := s.SubClients[ClientNetbackup]; !ok {
+			s.SubClients[ClientNetbackup] = subClients[ClientNetbackup]()
 		}
 
+		s.SubClients[ClientNetbackup].setConsole(s.Console)
+		s.SubClients[ClientNetbackup].setCredential(cred)
+
 		return nil
 	}
 }
 
+// setSubClientsCredential sets the credential of the sub-clients using the Cloudavenue authentication.
+// The S3 sub-client is added if S3 is available on the console, its keys are retrieved
+// with the Cloudavenue credential on the first S3 request.
+func setSubClientsCredential(s *settings, cred auth) error {
+	for _, client := range []subClientName{ClientCerberus, ClientVmware} {
+		if _, ok := s.SubClients[client]; !ok {
+			s.SubClients[client] = subClients[client]()
+		}
+
+		s.SubClients[client].setConsole(s.Console)
+		s.SubClients[client].setCredential(cred)
+	}
+
+	if !s.Console.Services().S3.IsEnabled() {
+		return nil
+	}
+
+	s3Cred, err := newS3Credential(s.Console, s.Organization, cred)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := s.SubClients[ClientS3]; !ok {
+		s.SubClients[ClientS3] = subClients[ClientS3]()
+	}
+
+	s.SubClients[ClientS3].setConsole(s.Console)
+	s.SubClients[ClientS3].setCredential(s3Cred)
+
+	return nil
+}
+
 // WithLogger sets the logger for the client.
 func WithLogger(customLogger *slog.Logger) ClientOption {
 	return func(_ *settings) error {
@@ -107,11 +184,114 @@ func WithLogger(customLogger *slog.Logger) ClientOption {
 	}
 }
 
-// WithCache store the tokens in a cache
+// WithHTTPClientSettings sets the settings of the HTTP clients.
+// Each sub-client keeps one HTTP client for all its requests, the settings configure
+// its connection pool or replace its transport.
+func WithHTTPClientSettings(httpSettings HTTPClientSettings) ClientOption {
+	return func(s *settings) error {
+		s.HTTPClient = httpSettings
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the requests.
+// The zero value of a field is replaced by its default value, see RetryPolicy.
+// The policy can be overridden per endpoint with Endpoint.RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(s *settings) error {
+		p, err := newRetryPolicy(policy)
+		if err != nil {
+			return fmt.Errorf("invalid retry policy: %w", err)
+		}
+		s.RetryPolicy = &p
+		return nil
+	}
+}
+
+// WithDryRun blocks the mutating requests (POST, PUT, PATCH and DELETE).
+// Instead of being sent, a mutating request is recorded and Client.Do returns it as a *PlannedRequest error.
+// The GET requests are still sent, so the lookups done by the commands keep working.
+// The recorded requests are returned by Client.PlannedRequests.
+//
+// As the mutation is returned as an error, a command stops at its first mutating request:
+// only this request is recorded, the next mutations of the command (and the jobs to wait for) are not planned.
+func WithDryRun() ClientOption {
+	return func(s *settings) error {
+		s.DryRun = true
+		return nil
+	}
+}
+
+// WithResponseCache caches the responses of the GET requests for the ttl.
+// The responses are indexed by endpoint and URL, so the same list with another filter or page is
+// requested again. A POST, PUT, PATCH or DELETE request removes from the cache the responses of its
+// resource family, e.g. UpdateVdc removes the responses of ListVdc and GetVdc (see Endpoint.InvalidatedFamilies).
+// The endpoints with DisableCache are never cached. The statistics are returned by Client.CacheStats.
+// Each call receives its own copy of the cached response.
+func WithResponseCache(ttl time.Duration) ClientOption {
+	return func(s *settings) error {
+		if ttl <= 0 {
+			return errors.New("response cache ttl must be greater than 0")
+		}
+		s.ResponseCacheTTL = ttl
+		return nil
+	}
+}
+
+// WithTelemetry instruments the client with the telemetry.
+// A span named after the endpoint is started for each call of Client.Do as a child of the
+// span of the context, with child spans for the retries and the job polls. The durations
+// of the calls and of the jobs, the retries and the waits on busy entities are recorded
+// per endpoint and sub-client (see the Metric* constants).
+func WithTelemetry(t Telemetry) ClientOption {
+	return func(s *settings) error {
+		if t == nil {
+			return errors.New("telemetry cannot be nil")
+		}
+		s.Telemetry = t
+		return nil
+	}
+}
+
+// WithCassette sends all the requests of the client through the cassette, including the
+// authentication requests. In record mode, the interactions with the API are saved in the
+// cassette file. In replay mode, the interactions are served from the file and no request is sent,
+// so a fixture recorded in production can be replayed offline:
+//
+//	cassette, err := cav.NewCassette("testdata/incident.json", cav.CassetteReplay)
+//	client, err := cav.NewClient(organization, cav.WithCloudAvenueCredential(username, password), cav.WithCassette(cassette))
+func WithCassette(cassette *Cassette) ClientOption {
+	return func(s *settings) error {
+		if cassette == nil {
+			return errors.New("cassette cannot be nil")
+		}
+		s.Cassette = cassette
+		return nil
+	}
+}
+
+// WithSessionStore stores the sessions in the session store.
+// The session is restored when the client is created and saved each time it changes,
+// so another client or process using the same store can reuse it.
+func WithSessionStore(store SessionStore) ClientOption {
+	return func(s *settings) error {
+		if store == nil {
+			return errors.New("session store cannot be nil")
+		}
+		s.SessionStore = store
+		return nil
+	}
+}
+
+// WithCache stores the sessions in an encrypted file.
+// It is a shortcut for WithSessionStore with NewFileSessionStore.
 func WithCache(passphrase, path string) ClientOption {
 	return func(s *settings) error {
-		s.CachePassphrase = passphrase
-		s.CachePath = path
+		store, err := NewFileSessionStore(path, passphrase)
+		if err != nil {
+			return err
+		}
+		s.SessionStore = store
 		return nil
 	}
 }
@@ -12,13 +12,15 @@ package cav
 import (
 	"context"
 	"fmt"
-	"time"
+	"strings"
 
 	"resty.dev/v3"
 )
 
 // NewRawRequest creates a new raw request using the resty client.
 func (c *client) NewRawRequest(ctx context.Context, subclientName string) (req *resty.Request, err error) {
+	ctx = c.withMock(ctx)
+
 	// Retrieve the subclient based on the provided client name.
 	// This method identifies the subclient and returns it.
 
@@ -32,9 +34,9 @@ func (c *client) NewRawRequest(ctx context.Context, subclientName string) (req *
 	// Inject the client name into the context for retrieval in the other methods.
 	ctxv := context.WithValue(ctx, contextKeyClientName, subClientName(subclientName))
 
-	// Setup the HTTP client for the request.
+	// Retrieve the HTTP client shared by the requests of the subclient.
 	// This client is used to send the request and handle the response.
-	hC, err := sc.newHTTPClient(ctxv)
+	hC, err := sc.httpClient(ctxv)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +49,9 @@ func (c *client) NewRawRequest(ctx context.Context, subclientName string) (req *
 }
 
 // NewRequest creates a new request using the resty client.
-func (c *client) NewRequest(ctx context.Context, endpoint *Endpoint, _ ...RequestOption) (req *resty.Request, err error) {
+func (c *client) NewRequest(ctx context.Context, endpoint *Endpoint, opts ...RequestOption) (req *resty.Request, err error) {
+	ctx = c.withMock(ctx)
+
 	// Retrieve the subclient based on the provided client name.
 	// This method identifies the subclient and returns it.
 	sc, err := c.identifyClient(ctx, endpoint.SubClient)
@@ -59,54 +63,56 @@ func (c *client) NewRequest(ctx context.Context, endpoint *Endpoint, _ ...Reques
 	ctxv := context.WithValue(ctx, contextKeyClientName, endpoint.SubClient)
 
 	// Create and populate the request options.
-	// TODO: actually the request options are not used in the current implementation.
-	// They are intended to be used for setting custom options on the request.
-	// reqOpts, err := newRequestOptions(opts...)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// TODO(azrod) thinking about removing the error from the new HTTPClient because
-	// it is not really useful, we can just return the request with the context. No error
-	// should be returned here, because the client should always be able to create a new HTTP.
-
-	// Setup the HTTP client for the request.
+	// The options stored in the context are overridden by the options of the call.
+	reqOpts, err := newRequestOptions(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the HTTP client shared by the requests of the subclient.
 	// This client is used to send the request and handle the response.
-	hC, err := sc.newHTTPClient(ctxv)
+	hC, err := sc.httpClient(ctxv)
 	if err != nil {
 		return nil, err
 	}
 
 	// * Middlewares
+	// The HTTP client is shared between the requests, so the middlewares specific
+	// to this request are stored in the request context and run by the client.
+	mws := requestMiddlewares{}
 
 	// ? Request Middlewares
 	if endpoint.RequestMiddlewares != nil {
-		// If the endpoint has request middlewares, set them on the HTTP client.
+		// If the endpoint has request middlewares, set them on the request.
 		// This allows for custom processing of the request before it is sent.
-		for _, mw := range endpoint.RequestMiddlewares {
-			hC.AddRequestMiddleware(mw)
-		}
+		mws.request = append(mws.request, endpoint.RequestMiddlewares...)
 	}
 
-	if isMockClient {
+	if c.mock {
 		// If the client is a mock client, we need to override the request URL to point to special prefix.
 		// This is because the mock client uses a different URL structure for the mock endpoints.
 		// The mock client will handle the request and return a mock response.
 
-		hC.AddRequestMiddleware(resty.RequestMiddleware(func(_ *resty.Client, r *resty.Request) error {
+		mws.request = append(mws.request, resty.RequestMiddleware(func(_ *resty.Client, r *resty.Request) error {
 			// Set the base URL to the mock endpoint URL.
 			r.URL = fmt.Sprintf("%s%s", hC.BaseURL(), endpoint.MockPath())
 			return nil
 		}))
 	}
 
+	// ? Telemetry Middlewares
+	if call := getTelemetryCallFromContext(ctx); call != nil {
+		// A span is started for each retry and ended before the other response middlewares,
+		// so the span of the job polling is not nested in it.
+		mws.request = append(mws.request, call.retryMiddleware)
+		mws.response = append(mws.response, call.endSpanMiddleware)
+	}
+
 	// ? Response Middlewares
 	if endpoint.ResponseMiddlewares != nil {
-		// If the endpoint has response middlewares, set them on the HTTP client.
+		// If the endpoint has response middlewares, set them on the request.
 		// This allows for custom processing of the response after it is received.
-		for _, mw := range endpoint.ResponseMiddlewares {
-			hC.AddResponseMiddleware(mw)
-		}
+		mws.response = append(mws.response, endpoint.ResponseMiddlewares...)
 	}
 
 	// If JobOpts are provided, we need to create a request with job middleware.
@@ -122,59 +128,53 @@ func (c *client) NewRequest(ctx context.Context, endpoint *Endpoint, _ ...Reques
 			return nil, fmt.Errorf("client %s does not support job options", endpoint.SubClient)
 		}
 
-		// Create a new HTTP client specifically for job requests.
-		// This is necessary because the initial client (hc) have a specific middleware defined below.
-		// If the hc client has used in NewJobMiddleware, it will create an infinite loop.
-		// So we create a new client for job requests.
-		hCForJob, err := sc.newHTTPClient(ctxv)
-		if err != nil {
-			return nil, err
+		jobCall := jobCallOptions{
+			progress:     endpoint.JobOptions.progress(reqOpts.jobProgress),
+			cancelOnDone: endpoint.JobOptions.cancelOnDone || reqOpts.cancelJobOnDone,
+			group:        reqOpts.jobGroup,
 		}
 
-		// If the request is for a job, set the job middleware.
-		// hC.SetResponseMiddlewares(
-		// 	resty.AutoParseResponseMiddleware,
-		// 	newJobMiddleware(hCForJob, sCJob, endpoint.JobOptions),
-		// )
-		hC.AddResponseMiddleware(newJobMiddleware(hCForJob, sCJob, endpoint.JobOptions))
+		if reqOpts.asyncJobs {
+			// The job is returned to the caller which polls it when it wants.
+			// The responses cached are invalidated again when the job is terminated.
+			if c.cache != nil {
+				jobCall.onTerminated = func() { c.cache.invalidate(endpoint) }
+			}
+			mws.response = append(mws.response, newAsyncJobMiddleware(hC, sCJob, endpoint.JobOptions, jobCall))
+		} else {
+			// The job status is polled with the same HTTP client.
+			// The job middleware replaces the middlewares of the polling requests to avoid an infinite loop.
+			mws.response = append(mws.response, newJobMiddleware(hC, sCJob, endpoint.JobOptions, jobCall))
+		}
 	}
 
-	var (
-		retryCount           = 5
-		retryWaitTime        = 60 * time.Second
-		retryMaxWaitTime     = 5 * time.Second
-		retryConditionsFuncs = make([]resty.RetryConditionFunc, 0)
-		retryIdempotent      = false
-	)
+	ctxv = storeRequestMiddlewaresInContext(ctxv, mws)
 
-	if endpoint.RetryConditionsFuncs != nil {
-		retryConditionsFuncs = append(retryConditionsFuncs, endpoint.RetryConditionsFuncs...)
+	// * Retry
+	// The retry policy of the endpoint overrides the retry policy of the client.
+	retryPolicy := c.retryPolicy
+	if endpoint.RetryPolicy != nil {
+		retryPolicy, err = newRetryPolicy(*endpoint.RetryPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry policy for endpoint %s: %w", endpoint.Name, err)
+		}
 	}
 
-	if isMockClient {
-		// If the client is a mock client, set the retry value to shorter values.
-		retryCount = 1
-		retryWaitTime = 5 * time.Millisecond
-	}
+	var (
+		busyCondition   resty.RetryConditionFunc
+		retryIdempotent = false
+	)
 
 	switch endpoint.Method {
 	case MethodPOST, MethodPUT, MethodDELETE:
-		// For POST, PUT, or PATCH requests, add retry hooks to check if the error return BUSY_ENTITY.
-		var conflictRetry resty.RetryConditionFunc = func(resp *resty.Response, _ error) bool {
-			if sc.idempotentRetryCondition()(resp, nil) {
-				// ! Increment the retry count to allow for retries "unlimited" retry for the busy entity error.
-				// This is because the busy entity has undefined max time to resolve.
-				resp.Request.RetryCount++
-				return true
-			}
-
-			return false
-		}
-
-		retryConditionsFuncs = append(retryConditionsFuncs, conflictRetry)
+		// For POST, PUT, or DELETE requests, retry if the error returns BUSY_ENTITY.
+		busyCondition = sc.idempotentRetryCondition()
 		retryIdempotent = true
 	}
 
+	retry := newRetryState(retryPolicy, endpoint.Description)
+	ctxv = storeRetryStateInContext(ctxv, retry)
+
 	// ContextData store specific data in the context.
 	// This context is used to pass additional information between middleware and handlers.
 	contextData := ContextData{}
@@ -186,16 +186,21 @@ func (c *client) NewRequest(ctx context.Context, endpoint *Endpoint, _ ...Reques
 	ctxv = storeExtraDataInContext(ctxv, contextData)
 
 	// Create a new request with the context and options.
+	// The retry decision is taken by the retry condition of the retry state, it replaces the
+	// default conditions of resty. The minimum wait time is lowered because resty clamps the
+	// wait time returned by the retry strategy and the jitter can go down to half of WaitTime.
 	// To know more about retry see https://resty.dev/docs/retry-mechanism/
 	hR := hC.NewRequest().
 		SetContext(ctxv).
-		EnableRetryDefaultConditions().
-		SetRetryCount(retryCount).
-		SetRetryMaxWaitTime(retryMaxWaitTime).
-		SetRetryWaitTime(retryWaitTime).
-		AddRetryConditions(retryConditionsFuncs...).
+		SetRetryDefaultConditions(false).
+		SetRetryCount(retry.retryCount(busyCondition != nil)).
+		SetRetryWaitTime(retryPolicy.WaitTime / 2).
+		SetRetryMaxWaitTime(retryPolicy.MaxWaitTime).
+		SetRetryConditions(retry.retryCondition(busyCondition, endpoint.RetryConditionsFuncs...)).
 		SetAllowNonIdempotentRetry(retryIdempotent)
 
+	reqOpts.apply(ctx, endpoint, hR)
+
 	// Set the query parameters in the request.
 	// This is done to set the query parameters in the HTTP requests.
 	for _, q := range endpoint.QueryParams {
@@ -217,13 +222,85 @@ func (c *client) NewRequest(ctx context.Context, endpoint *Endpoint, _ ...Reques
 	return hR, nil
 }
 
+// resolveRequest builds the request of the endpoint with the options without sending it,
+// and returns it with its URL, the path and query parameters resolved.
+func (c *client) resolveRequest(ctx context.Context, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Request, string, error) {
+	sc, err := c.identifyClient(ctx, endpoint.SubClient)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := c.NewRequest(ctx, endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, opt := range opts {
+		if err := opt(endpoint, req); err != nil {
+			return nil, "", err
+		}
+	}
+
+	hC, err := sc.httpClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The path parameters are already escaped by the request.
+	path := endpoint.PathTemplate
+	for k, v := range req.PathParams {
+		path = strings.ReplaceAll(path, "{"+k+"}", v)
+	}
+
+	u := strings.TrimSuffix(hC.BaseURL(), "/") + path
+	if len(req.QueryParams) > 0 {
+		u += "?" + req.QueryParams.Encode()
+	}
+
+	return req, u, nil
+}
+
 // Do executes the request and returns the response.
-func (c *client) Do(ctx context.Context, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
-	resp, err := endpoint.RequestFunc(ctx, c, endpoint, opts...)
+//
+// If the API rejects the request because the session is expired, the credential
+// is renewed and the request is replayed once (including the job polling).
+// An errors.AuthError is returned if the renewal of the credential fails.
+//
+// The request options stored in the context (see ContextWithRequestOptions) apply to the call.
+//
+// The identical GET requests sent concurrently, with the same endpoint and URL, are coalesced:
+// a single request is sent and each caller receives its own copy of the response.
+//
+// If the response cache is enabled (see WithResponseCache), the response of a GET request is
+// returned from the cache while it has not expired, and a mutating request removes the responses
+// of its resource family from the cache.
+//
+// The GET requests made with request options changing the request (headers, idempotency key,
+// timeout or retries, see ContextWithRequestOptions) are not coalesced, and the GET requests
+// made with headers are not answered from the cache: they are sent with their options.
+func (c *client) Do(ctx context.Context, endpoint *Endpoint, opts ...EndpointRequestOption) (resp *resty.Response, err error) {
+	// The requests of the credentials and of the jobs are sent to the mock server too.
+	ctx = c.withMock(ctx)
+
+	// The span of the call is a child of the span of the caller context.
+	ctx, endCall := c.startCall(ctx, endpoint)
+	defer func() { endCall(resp, err) }()
+
+	reqOpts, err := newRequestOptions(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	// The requests sent by the call, including its replay, are identified as the same call.
+	ctx = storeCallInContext(ctx)
+
+	// The timeout covers the whole call: the retries, the replay and the job polling.
+	if reqOpts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, reqOpts.timeout)
+		defer cancel()
+	}
+
 	// Retrieve the subclient based on the provided client name.
 	// This method identifies the subclient and returns it.
 	sc, err := c.identifyClient(ctx, endpoint.SubClient)
@@ -231,6 +308,89 @@ func (c *client) Do(ctx context.Context, endpoint *Endpoint, opts ...EndpointReq
 		return nil, err
 	}
 
+	// In dry-run mode, the mutating requests are recorded instead of being sent.
+	if c.dryRun && endpoint.Method.isMutating() {
+		planned, err := c.planRequest(ctx, endpoint, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return nil, planned
+	}
+
+	if c.cache != nil && endpoint.Method.isMutating() {
+		// The resources may be modified even if the request fails.
+		defer c.cache.invalidate(endpoint)
+	}
+
+	if endpoint.Method != MethodGET {
+		return c.send(ctx, sc, endpoint, opts...)
+	}
+
+	// The identical GET requests are answered from the cache or coalesced with the request in flight.
+	cached := c.cache != nil && endpoint.cacheable() && reqOpts.cacheable()
+	coalesced := reqOpts.coalescable()
+	if !cached && !coalesced {
+		return c.send(ctx, sc, endpoint, opts...)
+	}
+
+	_, u, err := c.resolveRequest(ctx, endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+	key := requestKey(endpoint, u)
+
+	var generation uint64
+	if cached {
+		if resp, ok := c.cache.get(key); ok {
+			xlogger.DebugContext(ctx, "Response returned from the cache", "operation", endpoint.Description, "url", u)
+			return copyResponse(resp), nil
+		}
+		// A mutation of the family while the request is in flight makes its response stale.
+		generation = c.cache.generation(endpoint)
+	}
+
+	if coalesced {
+		resp, err = c.coalesce(ctx, key, func(ctx context.Context) (*resty.Response, error) {
+			return c.send(ctx, sc, endpoint, opts...)
+		})
+	} else {
+		resp, err = c.send(ctx, sc, endpoint, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cached {
+		c.cache.set(key, endpoint, copyResponse(resp), generation)
+	}
+
+	return resp, nil
+}
+
+// send sends the request of the endpoint and returns the response or the API error.
+// The request is replayed once if the session has expired.
+func (c *client) send(ctx context.Context, sc subClientInterface, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
+	resp, err := endpoint.RequestFunc(ctx, c, endpoint, opts...)
+	if sc.sessionExpired(resp) {
+		xlogger.WarnContext(ctx, "Session expired, renewing the credential and replaying the request", "operation", endpoint.Description)
+		if errAuth := sc.renewCredential(ctx, endpoint.Description, resp); errAuth != nil {
+			xlogger.ErrorContext(ctx, "Failed to renew the credential", "operation", endpoint.Description, "error", errAuth)
+			return nil, errAuth
+		}
+
+		resp, err = endpoint.RequestFunc(ctx, c, endpoint, opts...)
+	}
+
+	// Save the session if it has been refreshed during the request,
+	// so other clients sharing the session store can reuse it.
+	if errStore := c.storeSessions(ctx); errStore != nil {
+		xlogger.WarnContext(ctx, "Failed to store the session in the session store", "error", errStore)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
 	if errAPI := sc.parseAPIError(endpoint.Description, resp); errAPI != nil {
 		xlogger.Error("API error occurred", "operation", endpoint.Description, "error", errAPI)
 		// If the response is an error, parse the API error and return it.
@@ -9,41 +9,267 @@
 
 package cav
 
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"resty.dev/v3"
+)
+
 type (
-	// requestOption is a function that modifies the request.
-	requestOption struct{}
+	// requestOption holds the options of a single call.
+	requestOption struct {
+		// timeout is the maximum duration of the call, including the retries and the job polling.
+		timeout time.Duration
+		// headers are added to the request.
+		headers map[string]string
+		// idempotencyKey is sent to the API to deduplicate the replays of the request.
+		idempotencyKey *idempotencyKey
+		// disableRetry disables all the retries of the request.
+		disableRetry bool
+		// asyncJobs returns the job of the request without waiting for its completion.
+		asyncJobs bool
+		// jobGroup collects the jobs of the asynchronous requests.
+		jobGroup *JobGroup
+		// jobProgress is called after each poll of the job of the request.
+		jobProgress JobProgressFunc
+		// cancelJobOnDone cancels the job of the request when the context is done.
+		cancelJobOnDone bool
+	}
 
+	// RequestOption is a function that modifies the options of a single call.
 	RequestOption func(*requestOption) error
+
+	// idempotencyKey is the key set by WithIdempotencyKey. It is sent by the first
+	// mutating call made with the option only, the call claiming it.
+	idempotencyKey struct {
+		key string
+
+		mu   sync.Mutex
+		call *requestCall
+	}
+
+	// requestCall identifies a call of Client.Do, the requests sent to replay the call share it.
+	requestCall struct {
+		_ byte // A pointer to a zero-size value is not unique.
+	}
+)
+
+const (
+	// vmwareRequestIDHeader is the header used by VMware Cloud Director to identify a client request.
+	vmwareRequestIDHeader = "X-VMWARE-VCLOUD-CLIENT-REQUEST-ID"
+	// idempotencyKeyHeader is the header used by the other APIs to deduplicate the requests.
+	idempotencyKeyHeader = "Idempotency-Key"
 )
 
-// * Not used yet
-
-// Create a request option struct to hold the request options.
-// This struct will be populated with the options provided in reqOpt.
-// func newRequestOptions(opts ...RequestOption) (*requestOption, error) {
-// 	// Create a new request option struct to hold the options.
-// 	ro := &requestOption{}
-// 	for _, opt := range opts {
-// 		if err := opt(ro); err != nil {
-// 			return nil, err
-// 		}
-// 	}
-// 	return ro, nil
-// }
-
-// // * Job
-
-// // WithJob is a request option to parse the Job Response.
-// func WithJob(opts ...JobOption) RequestOption {
-// 	return func(ro *requestOption) error {
-// 		// This option is used to parse the job response.
-// 		// It can be used to set the job settings or any other job-related options.
-// 		jobOpts, err := NewJobOptions(opts...)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		ro.JobOpts = jobOpts
-
-// 		return nil
-// 	}
-// }
+// newRequestOptions creates the request options from the options stored in the context
+// followed by the given options. The given options override the options of the context.
+func newRequestOptions(ctx context.Context, opts ...RequestOption) (*requestOption, error) {
+	ro := &requestOption{
+		headers: map[string]string{},
+	}
+	for _, opt := range append(getRequestOptionsFromContext(ctx), opts...) {
+		if err := opt(ro); err != nil {
+			return nil, err
+		}
+	}
+	return ro, nil
+}
+
+// storeCallInContext marks the context of a call of Client.Do.
+func storeCallInContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextCall, &requestCall{})
+}
+
+// claim reports whether the key is sent by the call. The first call claims the key,
+// the other calls made with the same option do not send it.
+func (k *idempotencyKey) claim(call *requestCall) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.call == nil {
+		k.call = call
+	}
+	return k.call == call
+}
+
+// apply applies the options to the request of the endpoint.
+func (ro *requestOption) apply(ctx context.Context, endpoint *Endpoint, req *resty.Request) {
+	call, sentByDo := ctx.Value(contextCall).(*requestCall)
+
+	if ro.timeout > 0 && !sentByDo {
+		// Do applies the timeout to the whole call, a request sent by the caller
+		// applies it to each attempt.
+		req.SetTimeout(ro.timeout)
+	}
+
+	for k, v := range ro.headers {
+		req.SetHeader(k, v)
+	}
+
+	if ro.idempotencyKey != nil && endpoint.Method.isMutating() {
+		if !sentByDo {
+			// Each request sent by the caller is a call.
+			call = &requestCall{}
+		}
+		if ro.idempotencyKey.claim(call) {
+			switch endpoint.SubClient {
+			case ClientVmware:
+				req.SetHeader(vmwareRequestIDHeader, ro.idempotencyKey.key)
+			default:
+				req.SetHeader(idempotencyKeyHeader, ro.idempotencyKey.key)
+			}
+		}
+	}
+
+	if ro.disableRetry {
+		req.SetRetryCount(0)
+	}
+}
+
+// ContextWithRequestOptions returns a copy of the context holding the request options.
+// The options apply to all the calls made with the context, including the calls
+// made by the api/* clients and by the commands. The options already stored in
+// the context are kept, the new options override them.
+//
+//	ctx = cav.ContextWithRequestOptions(ctx, cav.WithRequestTimeout(10*time.Second), cav.WithoutRetry())
+//	vdc, err := vdcClient.GetVDC(ctx, params)
+func ContextWithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	stored := getRequestOptionsFromContext(ctx)
+	all := make([]RequestOption, 0, len(stored)+len(opts))
+	all = append(all, stored...)
+	all = append(all, opts...)
+
+	return context.WithValue(ctx, contextRequestOptions, all)
+}
+
+// getRequestOptionsFromContext retrieves the request options from the context.
+func getRequestOptionsFromContext(ctx context.Context) []RequestOption {
+	if opts, ok := ctx.Value(contextRequestOptions).([]RequestOption); ok {
+		return opts
+	}
+	return nil
+}
+
+// WithRequestTimeout sets the maximum duration of the call, including the retries
+// and the job polling. The deadline of the context still applies if it is earlier.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(ro *requestOption) error {
+		if timeout <= 0 {
+			return errors.New("the request timeout must be greater than 0")
+		}
+		ro.timeout = timeout
+		return nil
+	}
+}
+
+// WithRequestHeader adds a header to the request.
+// The header overrides the header of the same name set by the client.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(ro *requestOption) error {
+		if key == "" {
+			return errors.New("the header name cannot be empty")
+		}
+		ro.headers[http.CanonicalHeaderKey(key)] = value
+		return nil
+	}
+}
+
+// WithIdempotencyKey sets the key identifying the operation, sent by all the attempts of the call.
+// The key is sent in the Idempotency-Key header, so an API deduplicating the requests does not
+// execute twice a request replayed after a network error. VMware Cloud Director does not deduplicate
+// the requests: the key is sent in its X-VMWARE-VCLOUD-CLIENT-REQUEST-ID header to correlate the
+// request with its task and its logs.
+//
+// The key identifies a single operation, it is only sent by the first mutating call (POST, PUT,
+// PATCH or DELETE) made with the option. When the option is stored in the context of a command,
+// the lookups of the command and its next mutating calls are sent without the key.
+func WithIdempotencyKey(key string) RequestOption {
+	k := &idempotencyKey{key: key}
+	return func(ro *requestOption) error {
+		if key == "" {
+			return errors.New("the idempotency key cannot be empty")
+		}
+		ro.idempotencyKey = k
+		return nil
+	}
+}
+
+// WithoutRetry disables the retries of the request, including the retries on busy entities.
+func WithoutRetry() RequestOption {
+	return func(ro *requestOption) error {
+		ro.disableRetry = true
+		return nil
+	}
+}
+
+// WithAsyncJobs returns the job of the call without waiting for its completion.
+// The result of the response is a *Job handle to wait for (Job.Wait) or to refresh (Job.Refresh).
+// If group is not nil, the job is also added to it, so the jobs of several calls can be
+// waited together. The commands returning a model still wait for their jobs because
+// they read the model once the job is terminated.
+//
+//	group := cav.NewJobGroup()
+//	ctx = cav.ContextWithRequestOptions(ctx, cav.WithAsyncJobs(group))
+//	for _, id := range ids {
+//		if err := client.DeleteEdgeGateway(ctx, types.ParamsEdgeGateway{ID: id}); err != nil {
+//			return err
+//		}
+//	}
+//	err := group.Wait(ctx)
+func WithAsyncJobs(group *JobGroup) RequestOption {
+	return func(ro *requestOption) error {
+		ro.asyncJobs = true
+		ro.jobGroup = group
+		return nil
+	}
+}
+
+// WithoutAsyncJobs waits for the completion of the job of the call,
+// it overrides a WithAsyncJobs option stored in the context.
+func WithoutAsyncJobs() RequestOption {
+	return func(ro *requestOption) error {
+		ro.asyncJobs = false
+		ro.jobGroup = nil
+		return nil
+	}
+}
+
+// AsyncJobsFromContext returns true if the request options stored in the context
+// return the jobs without waiting for their completion (see WithAsyncJobs).
+func AsyncJobsFromContext(ctx context.Context) bool {
+	ro, err := newRequestOptions(ctx)
+	return err == nil && ro.asyncJobs
+}
+
+// WithJobProgress sets a function called with the job of the call after each poll of its status,
+// in addition to the progress function of the job options of the endpoint.
+func WithJobProgress(progressFunc JobProgressFunc) RequestOption {
+	return func(ro *requestOption) error {
+		if progressFunc == nil {
+			return errors.New("the job progress function cannot be nil")
+		}
+		ro.jobProgress = progressFunc
+		return nil
+	}
+}
+
+// WithJobCancelOnContextDone cancels the job of the call on the platform when the context
+// is done (cancelled or deadline exceeded) or the timeout of the job is reached, instead of
+// only stopping polling it. The job is then polled until it is terminated and the error of
+// the call holds the JobTimeoutError and the error of the aborted job (see JobFailedError).
+// With WithAsyncJobs, the job is cancelled when the context of Job.Wait is done.
+// Only the VMware tasks can be cancelled, the other jobs keep running.
+func WithJobCancelOnContextDone() RequestOption {
+	return func(ro *requestOption) error {
+		ro.cancelJobOnDone = true
+		return nil
+	}
+}
@@ -0,0 +1,224 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package cav
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"resty.dev/v3"
+)
+
+var (
+	requestOptionsCalls   atomic.Int32
+	requestOptionsHeaders http.Header
+	requestOptionsMu      sync.Mutex
+)
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestRequestOptions",
+		Description:      "Test request options",
+		Method:           MethodPOST,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/requestoptions",
+		RetryPolicy:      &RetryPolicy{MaxAttempts: 3, WaitTime: time.Millisecond, MaxWaitTime: 2 * time.Millisecond},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestOptionsCalls.Add(1)
+			requestOptionsMu.Lock()
+			requestOptionsHeaders = r.Header.Clone()
+			requestOptionsMu.Unlock()
+
+			switch r.URL.Query().Get("behavior") {
+			case "slow":
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			case "unavailable":
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`)) //nolint:errcheck
+		}),
+	}.Register()
+}
+
+func Test_newRequestOptions(t *testing.T) {
+	ctx := ContextWithRequestOptions(t.Context(), WithRequestTimeout(time.Minute), WithRequestHeader("x-test", "context"))
+
+	ro, err := newRequestOptions(ctx, WithRequestHeader("X-Test", "call"), WithoutRetry())
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, ro.timeout)
+	assert.Equal(t, "call", ro.headers["X-Test"], "The options of the call override the options of the context")
+	assert.True(t, ro.disableRetry)
+
+	// The options of a parent context are kept.
+	child := ContextWithRequestOptions(ctx, WithIdempotencyKey("key"))
+	ro, err = newRequestOptions(child)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, ro.timeout)
+	assert.Equal(t, "key", ro.idempotencyKey.key)
+
+	assert.Equal(t, ctx, ContextWithRequestOptions(ctx), "No option must return the same context")
+
+	for name, opt := range map[string]RequestOption{
+		"zero timeout":          WithRequestTimeout(0),
+		"empty header":          WithRequestHeader("", "value"),
+		"empty idempotency key": WithIdempotencyKey(""),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newRequestOptions(context.Background(), opt)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestAsyncJobsFromContext(t *testing.T) {
+	assert.False(t, AsyncJobsFromContext(t.Context()))
+	assert.False(t, AsyncJobsFromContext(ContextWithRequestOptions(t.Context(), WithoutRetry())))
+
+	ctx := ContextWithRequestOptions(t.Context(), WithAsyncJobs(nil))
+	assert.True(t, AsyncJobsFromContext(ctx))
+	assert.False(t, AsyncJobsFromContext(ContextWithRequestOptions(ctx, WithoutAsyncJobs())))
+}
+
+func Test_NewRequest_RequestOptions(t *testing.T) {
+	client, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+
+	req, err := client.NewRequest(t.Context(), &Endpoint{SubClient: ClientVmware, Method: MethodPOST},
+		WithRequestHeader("X-Test", "value"),
+		WithIdempotencyKey("key-vmware"),
+		WithoutRetry(),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "value", req.Header.Get("X-Test"))
+	assert.Equal(t, "key-vmware", req.Header.Get(vmwareRequestIDHeader))
+	assert.Equal(t, 0, req.RetryCount)
+
+	req, err = client.NewRequest(t.Context(), &Endpoint{SubClient: ClientCerberus, Method: MethodPOST}, WithIdempotencyKey("key-cerberus"))
+	assert.Nil(t, err)
+	assert.Equal(t, "key-cerberus", req.Header.Get(idempotencyKeyHeader))
+
+	// The idempotency key is only sent by the first mutating request.
+	key := WithIdempotencyKey("key-once")
+	req, err = client.NewRequest(t.Context(), &Endpoint{SubClient: ClientCerberus, Method: MethodGET}, key)
+	assert.Nil(t, err)
+	assert.Empty(t, req.Header.Get(idempotencyKeyHeader))
+	req, err = client.NewRequest(t.Context(), &Endpoint{SubClient: ClientCerberus, Method: MethodPOST}, key)
+	assert.Nil(t, err)
+	assert.Equal(t, "key-once", req.Header.Get(idempotencyKeyHeader))
+	req, err = client.NewRequest(t.Context(), &Endpoint{SubClient: ClientCerberus, Method: MethodPOST}, key)
+	assert.Nil(t, err)
+	assert.Empty(t, req.Header.Get(idempotencyKeyHeader))
+
+	// The timeout of a request sent by the caller applies to each attempt.
+	req, err = client.NewRequest(t.Context(), &Endpoint{SubClient: ClientVmware}, WithRequestTimeout(time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, req.Timeout)
+
+	_, err = client.NewRequest(t.Context(), &Endpoint{SubClient: ClientVmware}, WithRequestTimeout(-time.Second))
+	assert.NotNil(t, err)
+}
+
+func Test_Do_RequestOptions(t *testing.T) {
+	client, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+
+	ep, err := GetEndpoint("TestRequestOptions")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name          string
+		behavior      string
+		opts          []RequestOption
+		expectedErr   bool
+		expectedCalls int32
+		check         func(t *testing.T, headers http.Header)
+	}{
+		{
+			name:          "headers and idempotency key",
+			opts:          []RequestOption{WithRequestHeader("X-Test", "value"), WithIdempotencyKey("key")},
+			expectedCalls: 1,
+			check: func(t *testing.T, headers http.Header) {
+				assert.Equal(t, "value", headers.Get("X-Test"))
+				assert.Equal(t, "key", headers.Get(vmwareRequestIDHeader))
+			},
+		},
+		{
+			name:          "the idempotency key is sent by all the attempts",
+			behavior:      "unavailable",
+			opts:          []RequestOption{WithIdempotencyKey("key")},
+			expectedErr:   true,
+			expectedCalls: 3,
+			check: func(t *testing.T, headers http.Header) {
+				assert.Equal(t, "key", headers.Get(vmwareRequestIDHeader))
+			},
+		},
+		{
+			name:          "without retry",
+			behavior:      "unavailable",
+			opts:          []RequestOption{WithoutRetry()},
+			expectedErr:   true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "timeout",
+			behavior:      "slow",
+			opts:          []RequestOption{WithRequestTimeout(20 * time.Millisecond), WithoutRetry()},
+			expectedErr:   true,
+			expectedCalls: 1,
+		},
+	}
+
+	t.Run("the idempotency key is sent by the first call", func(t *testing.T) {
+		ctx := ContextWithRequestOptions(t.Context(), WithIdempotencyKey("key-once"))
+		for i, expected := range []string{"key-once", ""} {
+			_, err := client.Do(ctx, ep)
+			assert.Nil(t, err)
+
+			requestOptionsMu.Lock()
+			assert.Equal(t, expected, requestOptionsHeaders.Get(vmwareRequestIDHeader), "call %d", i+1)
+			requestOptionsMu.Unlock()
+		}
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestOptionsCalls.Store(0)
+
+			ctx := ContextWithRequestOptions(t.Context(), tt.opts...)
+			start := time.Now()
+			_, err := client.Do(ctx, ep, SetCustomRestyOption(func(r *resty.Request) {
+				r.SetQueryParam("behavior", tt.behavior)
+			}))
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Less(t, time.Since(start), time.Second, "The call must not wait for the slow response")
+			assert.Equal(t, tt.expectedCalls, requestOptionsCalls.Load())
+
+			if tt.check != nil {
+				requestOptionsMu.Lock()
+				defer requestOptionsMu.Unlock()
+				tt.check(t, requestOptionsHeaders)
+			}
+		})
+	}
+}
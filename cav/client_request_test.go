@@ -10,6 +10,8 @@
 package cav
 
 import (
+	"net/http"
+	"sync/atomic"
 	"testing"
 )
 
@@ -65,3 +67,53 @@ func Test_NewRequest(t *testing.T) {
 // 	// Check if the request is created successfully
 // 	assert.NotNil(t, req)
 // }
+
+// sessionExpiredEndpointCalls counts the calls to the TestSessionExpired mock endpoint.
+var sessionExpiredEndpointCalls atomic.Int32
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestSessionExpired",
+		Description:      "Test session expired",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/session",
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			// The first call is rejected as if the session was expired.
+			if sessionExpiredEndpointCalls.Add(1) == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`)) //nolint:errcheck
+		}),
+	}.Register()
+}
+
+func Test_Do_RenewSessionWhenExpired(t *testing.T) {
+	client, err := newMockClient()
+	if err != nil {
+		t.Fatalf("Error creating client with mock: %v", err)
+	}
+
+	ep, err := GetEndpoint("TestSessionExpired")
+	if err != nil {
+		t.Fatalf("Error getting endpoint: %v", err)
+	}
+
+	sessionExpiredEndpointCalls.Store(0)
+
+	resp, err := client.Do(t.Context(), ep)
+	if err != nil {
+		t.Fatalf("Expected the request to be replayed after the session renewal, got error: %v", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", resp.StatusCode())
+	}
+
+	if calls := sessionExpiredEndpointCalls.Load(); calls != 2 {
+		t.Errorf("Expected 2 calls to the endpoint, got %d", calls)
+	}
+}
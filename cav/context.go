@@ -18,8 +18,14 @@ import (
 type contextKey string
 
 const (
-	contextKeyClientName contextKey = "subclient.clientName" // Context key for the client name
-	contextExtraData     contextKey = "subclient.extraData"  // Context key for extra data
+	contextKeyClientName  contextKey = "subclient.clientName"  // Context key for the client name
+	contextExtraData      contextKey = "subclient.extraData"   // Context key for extra data
+	contextMiddlewares    contextKey = "subclient.middlewares" // Context key for the middlewares of the request
+	contextRetryState     contextKey = "subclient.retryState"  // Context key for the retry state of the request
+	contextRequestOptions contextKey = "client.requestOptions" // Context key for the options of the calls
+	contextTelemetryCall  contextKey = "client.telemetryCall"  // Context key for the telemetry of the call
+	contextMock           contextKey = "client.mock"           // Context key for the requests of a mock client
+	contextCall           contextKey = "client.call"           // Context key for the call of Client.Do sending the request
 )
 
 type ContextData struct {
@@ -39,3 +45,54 @@ func GetExtraDataFromContext(ctx context.Context) ContextData {
 	}
 	return ContextData{}
 }
+
+// storeRequestMiddlewaresInContext stores the middlewares specific to a request in the context.
+func storeRequestMiddlewaresInContext(ctx context.Context, mws requestMiddlewares) context.Context {
+	return context.WithValue(ctx, contextMiddlewares, mws)
+}
+
+// getRequestMiddlewaresFromContext retrieves the middlewares specific to a request from the context.
+func getRequestMiddlewaresFromContext(ctx context.Context) requestMiddlewares {
+	if mws, ok := ctx.Value(contextMiddlewares).(requestMiddlewares); ok {
+		return mws
+	}
+	return requestMiddlewares{}
+}
+
+// storeRetryStateInContext stores the retry state of a request in the context.
+func storeRetryStateInContext(ctx context.Context, state *retryState) context.Context {
+	return context.WithValue(ctx, contextRetryState, state)
+}
+
+// getRetryStateFromContext retrieves the retry state of a request from the context.
+func getRetryStateFromContext(ctx context.Context) *retryState {
+	if state, ok := ctx.Value(contextRetryState).(*retryState); ok {
+		return state
+	}
+	return nil
+}
+
+// storeTelemetryCallInContext stores the telemetry of a call in the context.
+func storeTelemetryCallInContext(ctx context.Context, call *telemetryCall) context.Context {
+	return context.WithValue(ctx, contextTelemetryCall, call)
+}
+
+// getTelemetryCallFromContext retrieves the telemetry of a call from the context.
+func getTelemetryCallFromContext(ctx context.Context) *telemetryCall {
+	if call, ok := ctx.Value(contextTelemetryCall).(*telemetryCall); ok {
+		return call
+	}
+	return nil
+}
+
+// storeMockInContext marks the requests of the context as sent by a mock client.
+func storeMockInContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextMock, true)
+}
+
+// isMockFromContext reports whether the requests of the context are sent by a mock client.
+// The requests of a mock client are sent to the mock path of the endpoints.
+func isMockFromContext(ctx context.Context) bool {
+	mock, _ := ctx.Value(contextMock).(bool)
+	return mock
+}
@@ -29,6 +29,8 @@ CloudAvenue SDK Client Initialization Flow
 	When an API call is made:
 	    - The subClient uses credential.Headers() to set authentication headers
 	    - The subClient may call credential.Refresh() to refresh the token if needed
+	    - If the API rejects the session (expired token), the credential is renewed
+	      once and the request is replayed
 
 Summary:
   - NewClient initializes the main client and injects authentication and configuration.
@@ -82,6 +82,10 @@ type (
 		// To know more about retry see https://resty.dev/docs/retry-mechanism/
 		RetryConditionsFuncs []resty.RetryConditionFunc
 
+		// RetryPolicy overrides the retry policy of the client for this endpoint.
+		// If nil, the retry policy of the client is used.
+		RetryPolicy *RetryPolicy `validate:"omitempty"`
+
 		// RequestMiddleware is a function that takes a resty.Request and returns a resty.Request.
 		// This function is used to modify the request before it is sent.
 		// It allows for adding headers, query parameters, and other modifications to the request.
@@ -100,6 +104,7 @@ type (
 		//
 		// If your set `cav.Job{}` as BodyResponseType, the system will automatically
 		// handle the job response and retrieve the job status until it is completed (success or error).
+		// With WithAsyncJobs, the result of the response is a *Job handle returned without waiting.
 		BodyResponseType any `validate:"-"`
 
 		// ResponseMiddleware is a function that takes a resty.Response and returns a resty.Response.
@@ -108,6 +113,18 @@ type (
 		// and handling errors.
 		ResponseMiddlewares []resty.ResponseMiddleware
 
+		// * Cache
+
+		// DisableCache excludes the responses of the endpoint from the response cache of the client
+		// (see WithResponseCache), e.g. for a status that changes without a request of the client.
+		DisableCache bool
+
+		// InvalidatedFamilies are the resource families, besides its own, whose cached responses are
+		// removed by a request of this mutating endpoint. The family of an endpoint is its name without
+		// the verb, e.g. UpdateVdc invalidates the StorageProfile family because it modifies the
+		// storage profiles of the VDC.
+		InvalidatedFamilies []string
+
 		// * Mock
 
 		// mockResponse is the mock response that can be used for testing purposes.
@@ -129,6 +146,13 @@ type (
 		// mockResponseStatusCode is the HTTP status code to return for the mock response.
 		mockResponseStatusCode *int `validate:"omitempty"`
 
+		// mockResponseOverridden is true if the mock response has been overridden since the last restore.
+		// The endpoint is only written when it is overridden, so the mock servers can read it concurrently.
+		mockResponseOverridden bool
+		// mockResponseReplayed is true once the 401 status code set with SetMockResponse has been
+		// answered, the replay of the request after the renewal of the credential receives it again.
+		mockResponseReplayed bool
+
 		// * Job
 
 		// jobOptions is the options for the job.
@@ -157,6 +181,11 @@ type (
 		// TransformFunc is called after the ValidatorFunc(if provided) and before the value is set in the request.
 		TransformFunc func(value string) (string, error)
 
+		// FilterFields are the fields allowed in the FIQL filter set in the query parameter.
+		// If provided, the value is parsed as a FIQL filter and its fields, operators and values
+		// are checked before the ValidatorFunc and the TransformFunc (see ParseFilter and WithQuery).
+		FilterFields []FilterField `validate:"dive"`
+
 		// Ability to provides a value for the query parameter.
 		// This is useful when the query parameter value is known at the time of registration.
 		// If the value is provided Required, ValidatorFunc and TransformFunc are ignored.
@@ -26,6 +26,10 @@ type (
 
 func WithPathParam(pp PathParam, value string) EndpointRequestOption {
 	return func(endpoint *Endpoint, req *resty.Request) error {
+		// The option is applied again when the request is replayed or paginated,
+		// so the value transformed is not stored in the closure.
+		value := value
+
 		if endpoint.PathParams == nil {
 			return errors.Newf("endpoint %s has no path params", endpoint.Name)
 		}
@@ -33,17 +37,17 @@ func WithPathParam(pp PathParam, value string) EndpointRequestOption {
 		for _, p := range endpoint.PathParams {
 			if p.Name == pp.Name && p.Value == "" {
 				if p.Required && value == "" {
-					return errors.Newf("path param %s is required for endpoint %s", pp.Name, endpoint.Name)
+					return errors.WithKind(errors.Newf("path param %s is required for endpoint %s", pp.Name, endpoint.Name), errors.ErrValidation)
 				}
 				if p.ValidatorFunc != nil && value != "" {
 					if err := p.ValidatorFunc(value); err != nil {
-						return errors.Newf("path param %s validation failed for endpoint %s: %v", pp.Name, endpoint.Name, err)
+						return errors.WithKind(errors.Newf("path param %s validation failed for endpoint %s: %w", pp.Name, endpoint.Name, err), errors.ErrValidation)
 					}
 				}
 				if p.TransformFunc != nil && value != "" {
 					newValue, err := p.TransformFunc(value)
 					if err != nil {
-						return errors.Newf("path param %s transformation failed for endpoint %s: %v", pp.Name, endpoint.Name, err)
+						return errors.WithKind(errors.Newf("path param %s transformation failed for endpoint %s: %w", pp.Name, endpoint.Name, err), errors.ErrValidation)
 					}
 					value = newValue
 				}
@@ -57,6 +61,10 @@ func WithPathParam(pp PathParam, value string) EndpointRequestOption {
 
 func WithQueryParam(qp QueryParam, value string) EndpointRequestOption {
 	return func(endpoint *Endpoint, req *resty.Request) error {
+		// The option is applied again when the request is replayed or paginated,
+		// so the value transformed is not stored in the closure.
+		value := value
+
 		if endpoint.QueryParams == nil {
 			return errors.Newf("endpoint %s has no query params", endpoint.Name)
 		}
@@ -64,17 +72,27 @@ func WithQueryParam(qp QueryParam, value string) EndpointRequestOption {
 		for _, p := range endpoint.QueryParams {
 			if p.Name == qp.Name && p.Value == "" {
 				if p.Required && value == "" {
-					return errors.Newf("query param %s is required for endpoint %s", qp.Name, endpoint.Name)
+					return errors.WithKind(errors.Newf("query param %s is required for endpoint %s", qp.Name, endpoint.Name), errors.ErrValidation)
+				}
+				if len(p.FilterFields) > 0 && value != "" {
+					f, err := ParseFilter(value)
+					if err != nil {
+						return errors.WithKind(errors.Newf("query param %s validation failed for endpoint %s: %w", qp.Name, endpoint.Name, err), errors.ErrValidation)
+					}
+					if f, err = checkFilter(f, p.FilterFields); err != nil {
+						return errors.WithKind(errors.Newf("query param %s validation failed for endpoint %s: %w", qp.Name, endpoint.Name, err), errors.ErrValidation)
+					}
+					value = f.String()
 				}
 				if p.ValidatorFunc != nil && value != "" {
 					if err := p.ValidatorFunc(value); err != nil {
-						return errors.Newf("query param %s validation failed for endpoint %s: %v", qp.Name, endpoint.Name, err)
+						return errors.WithKind(errors.Newf("query param %s validation failed for endpoint %s: %w", qp.Name, endpoint.Name, err), errors.ErrValidation)
 					}
 				}
 				if p.TransformFunc != nil && value != "" {
 					newValue, err := p.TransformFunc(value)
 					if err != nil {
-						return errors.Newf("query param %s transformation failed for endpoint %s: %v", qp.Name, endpoint.Name, err)
+						return errors.WithKind(errors.Newf("query param %s transformation failed for endpoint %s: %w", qp.Name, endpoint.Name, err), errors.ErrValidation)
 					}
 					value = newValue
 				}
@@ -122,7 +140,7 @@ func SetBody(body any) EndpointRequestOption {
 
 		// Validate the body
 		if err := validators.New().Validate.Struct(body); err != nil {
-			return errors.Newf("body validation failed for endpoint %s %s: %v", endpoint.Name, endpoint.Method, err)
+			return errors.WithKind(errors.Newf("body validation failed for endpoint %s %s: %w", endpoint.Name, endpoint.Method, err), errors.ErrValidation)
 		}
 
 		req.SetBody(body)
@@ -107,6 +107,9 @@ func TestWithQueryParam_RequiredMissing(t *testing.T) {
 	if err == nil || err.Error() == "" {
 		t.Error("expected error for missing required query param, got nil")
 	}
+	if !errors.Is(err, errors.ErrValidation) {
+		t.Errorf("expected error matching ErrValidation, got %v", err)
+	}
 }
 
 func TestWithQueryParam_ValidatorFails(t *testing.T) {
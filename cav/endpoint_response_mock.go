@@ -11,9 +11,12 @@ package cav
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/orange-cloudavenue/common-go/generator"
 )
@@ -22,7 +25,12 @@ import (
 // Is used to generate response bodies for mock endpoints.
 var defaultMockResponseFunc = func(ep *Endpoint) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		defer ep.restoreMockResponse() // Restore the original mock response after handling the request
+		defer func() {
+			// Restore the original mock response after handling the request.
+			if !ep.keepMockResponseForReplay() {
+				ep.restoreMockResponse()
+			}
+		}()
 
 		if ep.MockResponseFuncIsDefined() {
 			xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("Using custom mock response function for endpoint")
@@ -30,122 +38,190 @@ var defaultMockResponseFunc = func(ep *Endpoint) http.HandlerFunc {
 			return
 		}
 
-		// Here catch status code defined in mockResponseStatusCode if >= 300
-		if ep.mockResponseStatusCode != nil {
-			if *ep.mockResponseStatusCode >= 300 {
-				xlogger.WithGroup("mock").With("endpoint", ep.Name).With("statusCode", *ep.mockResponseStatusCode).Debug("Mock response error with status code >= 300")
-				var apiError any
-				switch ep.SubClient {
-				case ClientCerberus:
-					apiError = &cerberusError{}
-				case ClientVmware:
-					apiError = &vmwareError{}
-				}
-				if err := generator.Struct(apiError); err != nil {
-					xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Error generating mock data for endpoint:", slog.Any("error", err))
-					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-					return
-				}
+		writeMockResponse(w, ep, ep.MockResponseData, ep.mockResponseStatusCode)
+	}
+}
 
-				// TODO apiError not used
-				xlogger.WithGroup("mock").With("statusCode", *ep.mockResponseStatusCode).Debug("Mock response error")
-				http.Error(w, http.StatusText(*ep.mockResponseStatusCode), *ep.mockResponseStatusCode)
-				return
-			}
+// NewMockResponseFunc returns a mock response function answering the data with the status code,
+// as SetMockResponse does, without changing the endpoint.
+// If data is nil, the body is generated from the BodyResponseType of the endpoint.
+// If statusCode is nil, the status code is 200.
+func NewMockResponseFunc(ep *Endpoint, data any, statusCode *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeMockResponse(w, ep, data, statusCode)
+	}
+}
+
+// writeMockResponse writes the mock response of the endpoint with the data and the status code.
+func writeMockResponse(w http.ResponseWriter, ep *Endpoint, data any, statusCode *int) {
+	// The errors are answered with the error body of the API of the endpoint,
+	// so they are decoded by the SDK as the errors of the real API.
+	// If data is defined, it is used as the error body.
+	if statusCode != nil && *statusCode >= 300 && data == nil {
+		xlogger.WithGroup("mock").With("endpoint", ep.Name).With("statusCode", *statusCode).Debug("Mock response error with status code >= 300")
+		data = defaultMockErrorBody(ep, *statusCode)
+	}
+
+	// Construct the mock response body
+	var newBody any
+
+	if data != nil {
+		xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("Using mock response data for endpoint")
+		// If mock response data is defined, use it directly
+		newBody = data
+	} else if ep.BodyResponseType != nil {
+		xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("No mock response data defined, generating mock data")
+
+		// Reflect on the BodyResponseType to determine the type of the response body
+		bodyType := reflect.TypeOf(ep.BodyResponseType)
+		// If bodyType is a pointer, we need to dereference it to get the underlying type
+		if bodyType.Kind() == reflect.Ptr {
+			// Dereference the pointer
+			bodyType = bodyType.Elem()
 		}
 
-		// Construct the mock response body
-		var newBody any
-
-		if ep.MockResponseData != nil {
-			xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("Using mock response data for endpoint")
-			// If mock response data is defined, use it directly
-			newBody = ep.MockResponseData
-		} else if ep.BodyResponseType != nil {
-			xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("No mock response data defined, generating mock data")
-
-			// Reflect on the BodyResponseType to determine the type of the response body
-			bodyType := reflect.TypeOf(ep.BodyResponseType)
-			// If bodyType is a pointer, we need to dereference it to get the underlying type
-			if bodyType.Kind() == reflect.Ptr {
-				// Dereference the pointer
-				bodyType = bodyType.Elem()
-			}
+		// Parse special case for bodyType is a Job type
+		switch {
+		case bodyType == reflect.TypeOf(Job{}):
+			switch ep.SubClient {
+			case ClientCerberus:
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"jobId":"87ab1934-0146-4fb0-80bc-815fea03214d","message":"Job created successfully"}`)) //nolint:errcheck
+				return
 
-			// Parse special case for bodyType is a Job type
-			switch {
-			case bodyType == reflect.TypeOf(Job{}):
-				switch ep.SubClient {
-				case ClientCerberus:
-					w.WriteHeader(http.StatusCreated)
-					w.Write([]byte(`{"jobId":"87ab1934-0146-4fb0-80bc-815fea03214d","message":"Job created successfully"}`)) //nolint:errcheck
-					return
+			case ClientVmware:
+				w.Header().Add("Location", "/mock/cav/v1/jobvmware/api/task/87ab1934-0146-4fb0-80bc-815fea03214d")
+				w.WriteHeader(http.StatusAccepted)
+				return
 
-				case ClientVmware:
-					w.Header().Add("Location", "/mock/cav/v1/jobvmware/api/task/87ab1934-0146-4fb0-80bc-815fea03214d")
-					w.WriteHeader(http.StatusAccepted)
-					return
-				}
+			case ClientNetbackup:
+				w.Header().Add("Location", netbackupAPIPath+"/jobs/1234")
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
 
-			default:
-				// Set bodyType to a pointer to the struct type
-				newBodyType := reflect.PointerTo(bodyType)
-				// set new var body with the type of bodyType
-				newBody = reflect.New(newBodyType).Interface()
-				switch bodyType.Kind() {
-				case reflect.Slice:
-					xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("BodyResponseType is a slice, generating mock data for slice of structs", slog.String("type", newBodyType.String()))
-					// If bodyType is a slice, we need to generate a slice of structs
-					// We use the generator to generate a slice of structs
-
-					// Add recovery to handle any panic during generation
-					defer func() {
-						if r := recover(); r != nil {
-							xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Panic during mock data generation:", slog.Any("error", r))
-							http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-						}
-					}()
-					generator.Slice(newBody)
-
-				default:
-					xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("BodyResponseType is a struct, generating mock data for struct", slog.String("type", newBodyType.String()))
-					if err := generator.Struct(newBody); err != nil {
-						xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Error generating mock data for endpoint:", slog.Any("error", err))
+		default:
+			// Set bodyType to a pointer to the struct type
+			newBodyType := reflect.PointerTo(bodyType)
+			// set new var body with the type of bodyType
+			newBody = reflect.New(newBodyType).Interface()
+			switch bodyType.Kind() {
+			case reflect.Slice:
+				xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("BodyResponseType is a slice, generating mock data for slice of structs", slog.String("type", newBodyType.String()))
+				// If bodyType is a slice, we need to generate a slice of structs
+				// We use the generator to generate a slice of structs
+
+				// Add recovery to handle any panic during generation
+				defer func() {
+					if r := recover(); r != nil {
+						xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Panic during mock data generation:", slog.Any("error", r))
 						http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-						return
 					}
+				}()
+				generator.Slice(newBody)
+
+			default:
+				xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("BodyResponseType is a struct, generating mock data for struct", slog.String("type", newBodyType.String()))
+				if err := generator.Struct(newBody); err != nil {
+					xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Error generating mock data for endpoint:", slog.Any("error", err))
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					return
 				}
 			}
 		}
+	}
 
-		xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("Mock response body", slog.Any("body", newBody))
+	xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("Mock response body", slog.Any("body", newBody))
 
-		bodyEncoded, err := json.Marshal(newBody)
-		if err != nil {
-			xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Error encoding body for endpoint:", slog.Any("error", err))
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
+	var (
+		bodyEncoded []byte
+		err         error
+	)
 
-		// Return a mock response
+	// S3 responds with XML documents, the other APIs with JSON documents.
+	switch ep.SubClient {
+	case ClientS3:
+		bodyEncoded, err = xml.Marshal(newBody)
+		w.Header().Set("Content-Type", "application/xml")
+	default:
+		bodyEncoded, err = json.Marshal(newBody)
 		w.Header().Set("Content-Type", "application/json")
+	}
+	if err != nil {
+		xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Error encoding body for endpoint:", slog.Any("error", err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
 
-		// Case used to set custom status code beetween 200 and 299
-		// If mockResponseStatusCode is defined, use it, otherwise default to 200
-		if ep.mockResponseStatusCode != nil {
-			xlogger.WithGroup("mock").With("endpoint", ep.Name).With("statusCode", *ep.mockResponseStatusCode).Debug("Setting mock response status code")
-			w.WriteHeader(*ep.mockResponseStatusCode)
-		} else {
-			xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("No mock response status code defined, using 200 OK")
-		}
+	// Case used to set custom status code beetween 200 and 299
+	// If statusCode is defined, use it, otherwise default to 200
+	if statusCode != nil {
+		xlogger.WithGroup("mock").With("endpoint", ep.Name).With("statusCode", *statusCode).Debug("Setting mock response status code")
+		w.WriteHeader(*statusCode)
+	} else {
+		xlogger.WithGroup("mock").With("endpoint", ep.Name).Debug("No mock response status code defined, using 200 OK")
+	}
 
-		w.Header().Set("X-Cloud-Avenue-Mock", "true") // Indicate that this is a mock response. For what ? Because !
-		_, err = w.Write(bodyEncoded)
-		if err != nil {
-			xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Error writing response body for endpoint:", slog.Any("error", err))
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
+	w.Header().Set("X-Cloud-Avenue-Mock", "true") // Indicate that this is a mock response. For what ? Because !
+	_, err = w.Write(bodyEncoded)
+	if err != nil {
+		xlogger.WithGroup("mock").With("endpoint", ep.Name).Error("Error writing response body for endpoint:", slog.Any("error", err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// defaultMockErrorBody returns the error body sent by the API of the endpoint with the status code.
+func defaultMockErrorBody(ep *Endpoint, statusCode int) any {
+	switch ep.SubClient {
+	case ClientCerberus:
+		return &cerberusError{
+			Code:    fmt.Sprintf("cf-%04d", statusCode),
+			Reason:  http.StatusText(statusCode),
+			Message: fmt.Sprintf("The request %s failed", ep.Name),
+		}
+	case ClientNetbackup:
+		return &netbackupError{
+			Message:       http.StatusText(statusCode),
+			MessageDetail: fmt.Sprintf("The request %s failed", ep.Name),
 		}
+	case ClientS3:
+		return &s3Error{
+			Code:      s3MockErrorCode(statusCode),
+			Message:   http.StatusText(statusCode),
+			RequestID: generator.MustGenerate("{uuid}"),
+		}
+	default:
+		// The messages of VMware Cloud Director are prefixed with the ID of the request.
+		return &vmwareError{
+			Message:       fmt.Sprintf("[ %s ] %s", generator.MustGenerate("{uuid}"), http.StatusText(statusCode)),
+			StatusCode:    statusCode,
+			StatusMessage: vmwareMockMinorErrorCode(statusCode),
+		}
+	}
+}
+
+// vmwareMockMinorErrorCode returns the minorErrorCode sent by VMware Cloud Director with the status code.
+func vmwareMockMinorErrorCode(statusCode int) string {
+	if statusCode == http.StatusForbidden {
+		return "ACCESS_TO_RESOURCE_IS_FORBIDDEN"
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(statusCode), " ", "_"))
+}
+
+// s3MockErrorCode returns the error code sent by the S3 API with the status code.
+func s3MockErrorCode(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return "InvalidRequest"
+	case http.StatusForbidden:
+		return "AccessDenied"
+	case http.StatusNotFound:
+		return "NoSuchBucket"
+	case http.StatusInternalServerError:
+		return "InternalError"
+	default:
+		return strings.ReplaceAll(http.StatusText(statusCode), " ", "")
 	}
 }
 
@@ -179,6 +255,7 @@ func (e *Endpoint) SetMockResponseFunc(mockResponse http.HandlerFunc) {
 		return
 	}
 	e.MockResponseFunc = mockResponse
+	e.mockResponseOverridden = true
 }
 
 // GetMockResponseData retrieves the mock response data for the endpoint.
@@ -187,12 +264,16 @@ func (e Endpoint) GetMockResponse() (data any, statusCode *int) {
 }
 
 // SetMockResponse sets the mock response data and status code for the endpoint.
+// The response answers the next request only. A 401 status code also answers the replay
+// of the request after the renewal of the credential, so the call fails as with the API.
 func (e *Endpoint) SetMockResponse(mockResponseData any, mockResponseStatusCode *int) {
 	// Remove MockResponseFunc
 	e.MockResponseFunc = nil // Clear the mock response function to use the default one
 	// Set the mock response data and status code
 	e.MockResponseData = mockResponseData
 	e.mockResponseStatusCode = mockResponseStatusCode
+	e.mockResponseOverridden = true
+	e.mockResponseReplayed = false
 }
 
 // CleanMockResponse cleans the mock response for the endpoint.
@@ -201,6 +282,8 @@ func (e *Endpoint) CleanMockResponse() {
 	e.MockResponseFunc = nil
 	e.MockResponseData = nil
 	e.mockResponseStatusCode = nil
+	e.mockResponseOverridden = true
+	e.mockResponseReplayed = false
 }
 
 func (e *Endpoint) RestoreMockResponse() {
@@ -208,8 +291,26 @@ func (e *Endpoint) RestoreMockResponse() {
 	e.restoreMockResponse()
 }
 
+// keepMockResponseForReplay returns true the first time a 401 status code set with SetMockResponse
+// is answered: the request is replayed after the renewal of the credential and the API rejects it again.
+func (e *Endpoint) keepMockResponseForReplay() bool {
+	if !e.mockResponseOverridden || e.mockResponseReplayed || e.MockResponseFunc != nil ||
+		e.mockResponseStatusCode == nil || *e.mockResponseStatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	e.mockResponseReplayed = true
+	return true
+}
+
 // restoreMockResponse restores the original mock response function and data.
 func (e *Endpoint) restoreMockResponse() {
+	if !e.mockResponseOverridden {
+		return
+	}
+
+	e.mockResponseOverridden = false
+	e.mockResponseReplayed = false
 	e.MockResponseFunc = e.mockResponseFunc
 	e.MockResponseData = e.mockResponseData
 	e.mockResponseStatusCode = nil
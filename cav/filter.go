@@ -0,0 +1,399 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"slices"
+	"strings"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+)
+
+// The comparison operators of the FIQL filters.
+const (
+	FilterEqual          FilterOperator = "=="
+	FilterNotEqual       FilterOperator = "!="
+	FilterGreaterThan    FilterOperator = "=gt="
+	FilterGreaterOrEqual FilterOperator = "=ge="
+	FilterLessThan       FilterOperator = "=lt="
+	FilterLessOrEqual    FilterOperator = "=le="
+)
+
+// filterReservedChars are the characters of the FIQL syntax, not allowed in the values.
+const filterReservedChars = ";,()"
+
+type (
+	// FilterOperator is a comparison operator of a FIQL filter, e.g. == or =gt=.
+	FilterOperator string
+
+	// Filter is a FIQL filter of a list endpoint of VMware Cloud Director,
+	// built with Eq, Ne, Gt, Ge, Lt, Le, And and Or or parsed with ParseFilter.
+	// String returns the filter in the FIQL syntax, e.g. name==vdc-*;numberOfVms=gt=2.
+	Filter interface {
+		String() string
+	}
+
+	// FilterCondition compares a field with a value.
+	// With FilterEqual and FilterNotEqual, the value can contain the wildcard *.
+	FilterCondition struct {
+		Field    string
+		Operator FilterOperator
+		Value    string
+	}
+
+	// FilterGroup combines filters with the AND (;) or the OR (,) operator.
+	FilterGroup struct {
+		Or      bool
+		Filters []Filter
+	}
+
+	// FilterField is a field allowed in the FIQL filter of a query parameter.
+	FilterField struct {
+		Name string `validate:"required,disallow_space"`
+
+		// Operators are the operators allowed on the field. All the operators are allowed if empty.
+		Operators []FilterOperator
+
+		// Wildcard indicates whether the value can contain the wildcard *.
+		// The ValidatorFunc and the TransformFunc are not called on a value with a wildcard.
+		Wildcard bool
+
+		// ValidatorFunc validates the value compared with the field.
+		ValidatorFunc func(value string) error
+
+		// TransformFunc transforms the value compared with the field, after the ValidatorFunc.
+		TransformFunc func(value string) (string, error)
+	}
+
+	// Query is a filter and a sort order of a list endpoint, set with WithQuery.
+	//
+	// Example:
+	//
+	//	q := cav.NewQuery(cav.Eq("name", "vdc-*"), cav.Gt("numberOfVms", "2")).SortAsc("name")
+	//	resp, err := client.Do(ctx, ep, cav.WithQuery(q))
+	Query struct {
+		filters  []Filter
+		sortAsc  string
+		sortDesc string
+	}
+)
+
+// Eq returns a filter matching the items whose field is equal to the value.
+// The value can contain the wildcard *, e.g. Eq("name", "vdc-*").
+func Eq(field, value string) Filter {
+	return FilterCondition{Field: field, Operator: FilterEqual, Value: value}
+}
+
+// Ne returns a filter matching the items whose field is not equal to the value.
+func Ne(field, value string) Filter {
+	return FilterCondition{Field: field, Operator: FilterNotEqual, Value: value}
+}
+
+// Gt returns a filter matching the items whose field is greater than the value.
+func Gt(field, value string) Filter {
+	return FilterCondition{Field: field, Operator: FilterGreaterThan, Value: value}
+}
+
+// Ge returns a filter matching the items whose field is greater than or equal to the value.
+func Ge(field, value string) Filter {
+	return FilterCondition{Field: field, Operator: FilterGreaterOrEqual, Value: value}
+}
+
+// Lt returns a filter matching the items whose field is less than the value.
+func Lt(field, value string) Filter {
+	return FilterCondition{Field: field, Operator: FilterLessThan, Value: value}
+}
+
+// Le returns a filter matching the items whose field is less than or equal to the value.
+func Le(field, value string) Filter {
+	return FilterCondition{Field: field, Operator: FilterLessOrEqual, Value: value}
+}
+
+// And returns a filter matching the items matched by all the filters.
+func And(filters ...Filter) Filter {
+	return FilterGroup{Filters: filters}
+}
+
+// Or returns a filter matching the items matched by at least one of the filters.
+func Or(filters ...Filter) Filter {
+	return FilterGroup{Or: true, Filters: filters}
+}
+
+func (c FilterCondition) String() string {
+	return c.Field + string(c.Operator) + c.Value
+}
+
+// String returns the filters joined by ; or by , and the groups in the group between parentheses.
+func (g FilterGroup) String() string {
+	sep := ";"
+	if g.Or {
+		sep = ","
+	}
+
+	parts := make([]string, 0, len(g.Filters))
+	for _, f := range g.Filters {
+		s := f.String()
+		if s == "" {
+			continue
+		}
+		if sub, ok := f.(FilterGroup); ok && len(sub.Filters) > 1 {
+			s = "(" + s + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, sep)
+}
+
+// ParseFilter parses a filter in the FIQL syntax, e.g. name==vdc-*;(numberOfVms=gt=2,isEnabled==false).
+// The AND operator ; has precedence over the OR operator ,.
+func ParseFilter(s string) (Filter, error) {
+	p := &filterParser{input: s}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.input) {
+		return nil, errors.Newf("invalid filter %q: unexpected %q at position %d", s, p.input[p.pos], p.pos)
+	}
+	return f, nil
+}
+
+// filterParser is a recursive descent parser of the FIQL syntax.
+type filterParser struct {
+	input string
+	pos   int
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	return p.parseGroup(true, ',', p.parseAnd)
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	return p.parseGroup(false, ';', p.parseConstraint)
+}
+
+// parseGroup parses the filters separated by sep. A single filter is not wrapped in a group.
+func (p *filterParser) parseGroup(or bool, sep byte, next func() (Filter, error)) (Filter, error) {
+	group := FilterGroup{Or: or}
+	for {
+		f, err := next()
+		if err != nil {
+			return nil, err
+		}
+		group.Filters = append(group.Filters, f)
+
+		if p.pos >= len(p.input) || p.input[p.pos] != sep {
+			break
+		}
+		p.pos++
+	}
+
+	if len(group.Filters) == 1 {
+		return group.Filters[0], nil
+	}
+	return group, nil
+}
+
+func (p *filterParser) parseConstraint() (Filter, error) {
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, errors.Newf("invalid filter %q: missing closing parenthesis", p.input)
+		}
+		p.pos++
+		return f, nil
+	}
+
+	rest := p.input[p.pos:]
+	end := strings.IndexAny(rest, filterReservedChars)
+	if end == -1 {
+		end = len(rest)
+	}
+	comparison := rest[:end]
+	p.pos += end
+
+	c, err := parseFilterCondition(comparison)
+	if err != nil {
+		return nil, errors.Newf("invalid filter %q: %w", p.input, err)
+	}
+	return c, nil
+}
+
+// parseFilterCondition parses a comparison, e.g. name==vdc-a or numberOfVms=gt=2.
+func parseFilterCondition(s string) (FilterCondition, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '=' && s[i] != '!' {
+			continue
+		}
+
+		c := FilterCondition{Field: s[:i]}
+		switch {
+		case strings.HasPrefix(s[i:], string(FilterEqual)):
+			c.Operator = FilterEqual
+		case strings.HasPrefix(s[i:], string(FilterNotEqual)):
+			c.Operator = FilterNotEqual
+		default:
+			// =xx= operators
+			end := strings.IndexByte(s[i+1:], '=')
+			if s[i] != '=' || end == -1 {
+				return c, errors.Newf("invalid operator in %q", s)
+			}
+			c.Operator = FilterOperator(s[i : i+end+2])
+			if !slices.Contains([]FilterOperator{FilterGreaterThan, FilterGreaterOrEqual, FilterLessThan, FilterLessOrEqual}, c.Operator) {
+				return c, errors.Newf("unknown operator %q in %q", c.Operator, s)
+			}
+		}
+		c.Value = s[i+len(c.Operator):]
+
+		if c.Field == "" || c.Value == "" {
+			return c, errors.Newf("%q must be in the format 'field<operator>value'", s)
+		}
+		return c, nil
+	}
+
+	return FilterCondition{}, errors.Newf("%q must be in the format 'field<operator>value'", s)
+}
+
+// checkFilter checks the fields, the operators and the values of the filter against the fields allowed,
+// and returns the filter with the values transformed by the TransformFunc of the fields.
+func checkFilter(f Filter, fields []FilterField) (Filter, error) {
+	switch f := f.(type) {
+	case FilterGroup:
+		checked := FilterGroup{Or: f.Or, Filters: make([]Filter, 0, len(f.Filters))}
+		for _, sub := range f.Filters {
+			c, err := checkFilter(sub, fields)
+			if err != nil {
+				return nil, err
+			}
+			checked.Filters = append(checked.Filters, c)
+		}
+		return checked, nil
+	case FilterCondition:
+		return checkFilterCondition(f, fields)
+	default:
+		return nil, errors.Newf("unsupported filter type %T", f)
+	}
+}
+
+func checkFilterCondition(c FilterCondition, fields []FilterField) (Filter, error) {
+	idx := slices.IndexFunc(fields, func(field FilterField) bool { return field.Name == c.Field })
+	if idx == -1 {
+		return nil, errors.Newf("filter field '%s' is not allowed, allowed fields: %s", c.Field, filterFieldNames(fields))
+	}
+	field := fields[idx]
+
+	if len(field.Operators) > 0 && !slices.Contains(field.Operators, c.Operator) {
+		return nil, errors.Newf("filter operator '%s' is not allowed on the field '%s'", c.Operator, c.Field)
+	}
+	if c.Value == "" {
+		return nil, errors.Newf("filter value of the field '%s' is empty", c.Field)
+	}
+	if strings.ContainsAny(c.Value, filterReservedChars) {
+		return nil, errors.Newf("filter value of the field '%s' contains a reserved character (%s)", c.Field, filterReservedChars)
+	}
+
+	if strings.Contains(c.Value, "*") {
+		if !field.Wildcard || (c.Operator != FilterEqual && c.Operator != FilterNotEqual) {
+			return nil, errors.Newf("wildcard is not allowed in the filter value of the field '%s'", c.Field)
+		}
+		return c, nil
+	}
+
+	if field.ValidatorFunc != nil {
+		if err := field.ValidatorFunc(c.Value); err != nil {
+			return nil, errors.Newf("filter value of the field '%s' is invalid: %w", c.Field, err)
+		}
+	}
+	if field.TransformFunc != nil {
+		value, err := field.TransformFunc(c.Value)
+		if err != nil {
+			return nil, errors.Newf("filter value of the field '%s' transformation failed: %w", c.Field, err)
+		}
+		c.Value = value
+	}
+	return c, nil
+}
+
+func filterFieldNames(fields []FilterField) string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// NewQuery returns a query matching the items matched by all the filters.
+func NewQuery(filters ...Filter) *Query {
+	return &Query{filters: filters}
+}
+
+// Where adds filters to the query, the items must match all the filters of the query.
+func (q *Query) Where(filters ...Filter) *Query {
+	q.filters = append(q.filters, filters...)
+	return q
+}
+
+// SortAsc sorts the items by the field in ascending order.
+func (q *Query) SortAsc(field string) *Query {
+	q.sortAsc, q.sortDesc = field, ""
+	return q
+}
+
+// SortDesc sorts the items by the field in descending order.
+func (q *Query) SortDesc(field string) *Query {
+	q.sortAsc, q.sortDesc = "", field
+	return q
+}
+
+// Filter returns the filter of the query.
+func (q *Query) Filter() Filter {
+	if len(q.filters) == 1 {
+		return q.filters[0]
+	}
+	return And(q.filters...)
+}
+
+// WithQuery sets the filter and the sort order of the query on the request.
+// The filter is set in the query parameter of the endpoint declaring FilterFields,
+// its fields, operators and values are checked before the request.
+// The sort field must be one of the FilterFields.
+func WithQuery(q *Query) EndpointRequestOption {
+	return func(endpoint *Endpoint, req *resty.Request) error {
+		idx := slices.IndexFunc(endpoint.QueryParams, func(p QueryParam) bool { return len(p.FilterFields) > 0 })
+		if idx == -1 {
+			return errors.Newf("endpoint %s has no query param accepting a filter", endpoint.Name)
+		}
+		qp := endpoint.QueryParams[idx]
+
+		if err := WithQueryParam(qp, q.Filter().String())(endpoint, req); err != nil {
+			return err
+		}
+
+		for param, field := range map[string]string{"sortAsc": q.sortAsc, "sortDesc": q.sortDesc} {
+			if field == "" {
+				continue
+			}
+			if !slices.ContainsFunc(qp.FilterFields, func(f FilterField) bool { return f.Name == field }) {
+				return errors.Newf("sort field '%s' is not allowed for endpoint %s, allowed fields: %s", field, endpoint.Name, filterFieldNames(qp.FilterFields))
+			}
+			// The sort order of the endpoint is replaced.
+			req.QueryParams.Del("sortAsc")
+			req.QueryParams.Del("sortDesc")
+			req.SetQueryParam(param, field)
+		}
+		return nil
+	}
+}
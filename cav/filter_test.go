@@ -0,0 +1,197 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"resty.dev/v3"
+)
+
+func testFilterFields() []FilterField {
+	return []FilterField{
+		{Name: "name", Operators: []FilterOperator{FilterEqual, FilterNotEqual}, Wildcard: true},
+		{Name: "numberOfVms"},
+		{
+			Name: "id",
+			ValidatorFunc: func(value string) error {
+				if !strings.HasPrefix(value, "urn:vcloud:vdc:") {
+					return errors.New("invalid URN")
+				}
+				return nil
+			},
+			TransformFunc: func(value string) (string, error) {
+				return strings.TrimPrefix(value, "urn:vcloud:vdc:"), nil
+			},
+		},
+	}
+}
+
+func Test_ParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    Filter
+		wantErr bool
+	}{
+		{
+			name:   "equality",
+			filter: "name==vdc-*",
+			want:   Eq("name", "vdc-*"),
+		},
+		{
+			name:   "comparison operators",
+			filter: "numberOfVms=gt=2;numberOfVms=le=10",
+			want:   And(Gt("numberOfVms", "2"), Le("numberOfVms", "10")),
+		},
+		{
+			name:   "and has precedence over or",
+			filter: "name==a;numberOfVms=lt=1,name!=b",
+			want:   Or(And(Eq("name", "a"), Lt("numberOfVms", "1")), Ne("name", "b")),
+		},
+		{
+			name:   "parentheses",
+			filter: "name==a;(numberOfVms=ge=1,name==b)",
+			want:   And(Eq("name", "a"), Or(Ge("numberOfVms", "1"), Eq("name", "b"))),
+		},
+		{
+			name:    "missing value",
+			filter:  "name==",
+			wantErr: true,
+		},
+		{
+			name:    "unknown operator",
+			filter:  "name=like=a",
+			wantErr: true,
+		},
+		{
+			name:    "missing closing parenthesis",
+			filter:  "(name==a,name==b",
+			wantErr: true,
+		},
+		{
+			name:    "unexpected character",
+			filter:  "name==a)",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.filter)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			// The filter written back is parsed as the same filter.
+			again, err := ParseFilter(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+}
+
+func Test_checkFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "allowed fields",
+			filter: And(Eq("name", "vdc-*"), Gt("numberOfVms", "2")),
+			want:   "name==vdc-*;numberOfVms=gt=2",
+		},
+		{
+			name:   "value transformed",
+			filter: Or(Eq("id", "urn:vcloud:vdc:1234"), Ne("name", "a")),
+			want:   "id==1234,name!=a",
+		},
+		{
+			name:    "field not allowed",
+			filter:  Eq("description", "a"),
+			wantErr: true,
+		},
+		{
+			name:    "operator not allowed",
+			filter:  Gt("name", "a"),
+			wantErr: true,
+		},
+		{
+			name:    "wildcard not allowed",
+			filter:  Eq("id", "urn:vcloud:vdc:*"),
+			wantErr: true,
+		},
+		{
+			name:    "reserved character",
+			filter:  Eq("name", "a;b"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			filter:  And(Eq("name", "a"), Eq("id", "1234")),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkFilter(tt.filter, testFilterFields())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestWithQuery(t *testing.T) {
+	endpoint := &Endpoint{
+		Name: "name", Method: "GET",
+		QueryParams: []QueryParam{
+			{Name: "filter", FilterFields: testFilterFields()},
+			{Name: "sortAsc", Value: "name"},
+		},
+	}
+
+	req := resty.New().R()
+	q := NewQuery(Eq("name", "vdc-*")).Where(Eq("id", "urn:vcloud:vdc:1234")).SortDesc("numberOfVms")
+	require.NoError(t, WithQuery(q)(endpoint, req))
+	assert.Equal(t, "name==vdc-*;id==1234", req.QueryParams.Get("filter"))
+	assert.Equal(t, "numberOfVms", req.QueryParams.Get("sortDesc"))
+	assert.False(t, req.QueryParams.Has("sortAsc"))
+
+	// An invalid filter or sort field fails before the request.
+	require.Error(t, WithQuery(NewQuery(Eq("description", "a")))(endpoint, resty.New().R()))
+	require.Error(t, WithQuery(NewQuery().SortAsc("description"))(endpoint, resty.New().R()))
+
+	// The endpoint does not accept a filter.
+	require.Error(t, WithQuery(NewQuery(Eq("name", "a")))(&Endpoint{Name: "name"}, resty.New().R()))
+}
+
+func TestWithQueryParam_Filter(t *testing.T) {
+	qp := QueryParam{Name: "filter", FilterFields: testFilterFields()}
+	endpoint := &Endpoint{Name: "name", QueryParams: []QueryParam{qp}}
+
+	req := resty.New().R()
+	require.NoError(t, WithQueryParam(qp, "(id==urn:vcloud:vdc:1234,name==a)")(endpoint, req))
+	assert.Equal(t, "id==1234,name==a", req.QueryParams.Get("filter"))
+
+	require.Error(t, WithQueryParam(qp, "numberOfVms==*")(endpoint, resty.New().R()))
+}
@@ -10,18 +10,19 @@
 package cav
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"resty.dev/v3"
-
-	"github.com/orange-cloudavenue/common-go/validators"
 )
 
 type (
 	jobsInterface interface {
 		// JobRefresh refreshes the job status.
-		// Error return a custom error if the job fails. errors.JobError
+		// Error return a *JobFailedError if the job fails.
 		JobRefresh(httpC *resty.Client, resp *resty.Response, reqOpts []EndpointRequestOption) (job *Job, err error)
 
 		// JobParser parses the job response.
@@ -34,67 +35,114 @@ type (
 
 		idempotentRetryCondition() resty.RetryConditionFunc
 	}
+
+	// jobCanceler is implemented by the subclients whose jobs can be cancelled.
+	jobCanceler interface {
+		// JobCancel requests the cancellation of the job of the response.
+		JobCancel(httpC *resty.Client, resp *resty.Response, reqOpts []EndpointRequestOption) error
+	}
+
+	// jobCallOptions are the options of a call applying to its job.
+	jobCallOptions struct {
+		// progress is called with the job after each poll.
+		progress JobProgressFunc
+		// cancelOnDone cancels the job when the context of the caller is done.
+		cancelOnDone bool
+		// group collects the job of an asynchronous call.
+		group *JobGroup
+		// onTerminated is called once when the job of an asynchronous call is terminated.
+		onTerminated func()
+	}
+)
+
+const (
+	// jobCancelTimeout is the maximum duration of the cancellation of a job,
+	// including the polling until the job is aborted.
+	jobCancelTimeout = time.Minute
+	// jobCancelPollInterval is the interval between the polls of a job being cancelled.
+	jobCancelPollInterval = time.Second
 )
 
 // NewJobMiddleware creates a new job middleware for handling job responses.
-func newJobMiddleware(httpC *resty.Client, c jobsInterface, jobOpts *JobOptions) resty.ResponseMiddleware {
+// The job is polled until it is terminated, see jobPoller.
+func newJobMiddleware(httpC *resty.Client, c jobsInterface, jobOpts *JobOptions, call jobCallOptions) resty.ResponseMiddleware {
 	return func(_ *resty.Client, resp *resty.Response) error {
 		if jobOpts == nil {
 			return fmt.Errorf("job options cannot be nil, use NewJobOptions() to create a valid job options")
 		}
 
-		if err := validators.New().Struct(jobOpts); err != nil {
-			// If the job options are not valid, return an error.
-			// This ensures that the job options are properly configured before proceeding.
-			return fmt.Errorf("invalid job options: %w", err)
+		// If the job options are not valid, return an error.
+		// This ensures that the job options are properly configured before proceeding.
+		if err := jobOpts.withDefaults().validate(); err != nil {
+			return err
 		}
 
+		if ok := c.idempotentRetryCondition()(resp, nil); ok {
+			// If the response match with the retry Condition (BUSY) bypass jobMiddleware
+			return nil
+		}
+
+		// The polling requests only run the extractor middleware.
+		// The middlewares of the original request (including this one) must not be run again.
+		pollMws := requestMiddlewares{}
 		if jobOpts.extractorFunc != nil {
 			// If an extractor function is provided, use it to extract extra data from the response.
 			// This allows for custom handling of the response data.
-			// Add custom middleware to the request to handle job responses.
-			httpC.AddResponseMiddleware(extractorFuncMiddleware(jobOpts.extractorFunc))
+			pollMws.response = append(pollMws.response, extractorFuncMiddleware(jobOpts.extractorFunc))
 		}
 
-		// Create a new request for job status checking.
-		// This request will be used to poll the job status until it is terminated.
-		// The request will be configured with retry conditions and timeout settings.
-		reqOpts := []EndpointRequestOption{}
-		reqOpts = append(reqOpts,
-			// Set the context from the original response request.
-			SetCustomRestyOption(func(r *resty.Request) { r.SetContext(resp.Request.Context()) }),
-			// Set retry conditions for the job status check.
-			// This will retry the request based on the job status and error conditions.
-			// TODO setstrategyRetry to use poolInterval and not jitter
-			SetCustomRestyOption(
-				func(r *resty.Request) {
-					r.SetRetryConditions(
-						// First, check if the request is busy.
-						c.idempotentRetryCondition(),
-						// Then, check if the job is still running.
-						jobRetryCondition(c),
-					)
-				}),
-			SetCustomRestyOption(func(r *resty.Request) { r.SetRetryWaitTime(jobOpts.PollInterval) }),
-			SetCustomRestyOption(func(r *resty.Request) { r.SetRetryMaxWaitTime(jobOpts.Timeout) }),
-			// Set the maximum number of retries based on the timeout and poll interval. E.g. if timeout is 5 minutes and poll interval is 15 seconds,
-			// the maximum number of retries will be 20 (5 minutes / 15 seconds = 20).
-			// This ensures that the job status is checked periodically until it is completed.
-			SetCustomRestyOption(func(r *resty.Request) { r.SetRetryCount(int(jobOpts.Timeout / jobOpts.PollInterval)) }),
-			SetCustomRestyOption(func(r *resty.Request) { r.SetTimeout(jobOpts.Timeout) }),
-		)
+		// Each poll of the job is traced in a child span of the span of the job.
+		telemetryCall := getTelemetryCallFromContext(resp.Request.Context())
+		var endJob func(job *Job, err error)
+		if telemetryCall != nil {
+			var jobCtx context.Context
+			jobCtx, endJob = telemetryCall.startJob()
+			pollMws.request = append(pollMws.request, telemetryCall.pollMiddleware(jobCtx))
+			pollMws.response = append([]resty.ResponseMiddleware{telemetryCall.endSpanMiddleware}, pollMws.response...)
+		}
 
-		if ok := c.idempotentRetryCondition()(resp, nil); ok {
-			// If the response match with the retry Condition (BUSY) bypass jobMiddleware
-			return nil
+		pollCtx := storeRequestMiddlewaresInContext(resp.Request.Context(), pollMws)
+		// The polling requests have their own retry settings, the retry state of the original request is removed.
+		pollCtx = storeRetryStateInContext(pollCtx, nil)
+
+		// Each refresh sends a single request, the poller waits between the requests.
+		refresh := func(ctx context.Context) (*Job, error) {
+			reqOpts := []EndpointRequestOption{
+				// Set the context of the poller with the polling middlewares.
+				SetCustomRestyOption(func(r *resty.Request) { r.SetContext(ctx) }),
+				SetCustomRestyOption(func(r *resty.Request) { r.SetRetryCount(0) }),
+			}
+
+			// Use the subclient's JobRefresh method to refresh the job status.
+			// This method will handle the job response and return the updated job status.
+			job, err := c.JobRefresh(httpC, resp, reqOpts)
+			if job != nil && call.progress != nil {
+				// The job failed is reported too.
+				call.progress(job)
+			}
+			return job, err
 		}
 
-		// Use the subclient's JobRefresh method to refresh the job status.
-		// This method will handle the job response and return the updated job status.
-		job, err := c.JobRefresh(httpC, resp, reqOpts)
+		job, err := newJobPoller(jobOpts).poll(pollCtx, refresh)
+
+		var timeoutErr *JobTimeoutError
+		if call.cancelOnDone && errors.As(err, &timeoutErr) {
+			// The caller has given up, the job is cancelled instead of being left running.
+			if canceler, ok := c.(jobCanceler); ok {
+				job, err = abortJob(resp.Request.Context(), err, httpC, c, canceler, jobOpts, resp, call.progress)
+			}
+		}
+		if endJob != nil {
+			endJob(job, err)
+		}
 
 		if job != nil {
 			xlogger.Debug("Job completed", slog.String("jobID", job.ID), slog.String("status", job.Status.String()))
+
+			// The result of the response is the job in its final state.
+			if _, ok := resp.Request.Result.(*Job); ok {
+				resp.Request.Result = job
+			}
 		}
 
 		// If an error occurs while refreshing the job status, return an err.
@@ -104,31 +152,6 @@ func newJobMiddleware(httpC *resty.Client, c jobsInterface, jobOpts *JobOptions)
 	}
 }
 
-var jobRetryCondition = func(c jobsInterface) resty.RetryConditionFunc {
-	return func(r *resty.Response, err error) bool {
-		xlogger.Debug("Checking job status", slog.Int("attempt", r.Request.Attempt), slog.Duration("retryWaitTime", r.Request.RetryWaitTime))
-
-		if err != nil {
-			xlogger.Error("Error occurred while waiting for job response", slog.String("error", err.Error()))
-			return false // Stop retrying if an error occurs
-		}
-
-		job, err := c.JobParser(r)
-		if err != nil {
-			xlogger.Error("Failed to parse job response", slog.String("error", err.Error()))
-			return false // Stop retrying if parsing fails
-		}
-
-		if job == nil {
-			xlogger.Warn("Job response is nil, stopping retries")
-			return false // Stop retrying if the job response is nil
-		}
-
-		xlogger.Debug("Job response status", slog.String("status", job.Status.String()))
-		return !job.Status.IsTerminated() // Continue retrying if the job is not terminated
-	}
-}
-
 var extractorFuncMiddleware = func(extractorFunc func(resp *resty.Response)) resty.ResponseMiddleware {
 	return func(_ *resty.Client, resp *resty.Response) error {
 		if extractorFunc != nil {
@@ -138,3 +161,31 @@ var extractorFuncMiddleware = func(extractorFunc func(resp *resty.Response)) res
 		return nil
 	}
 }
+
+// abortJob cancels the job of the response once the caller has given up, cause being the
+// error of the polling (e.g. a *JobTimeoutError), and polls it until it is terminated to return
+// its final state. The requests are not cancelled by the context of the caller, they are
+// limited to jobCancelTimeout. The error returned holds the cause and the error of the job.
+func abortJob(ctx context.Context, cause error, httpC *resty.Client, c jobsInterface, canceler jobCanceler, jobOpts *JobOptions, origin *resty.Response, progressFunc JobProgressFunc) (*Job, error) {
+	ctx = context.WithoutCancel(ctx)
+
+	cancelCtx, cancel := context.WithTimeout(ctx, jobCancelTimeout)
+	defer cancel()
+	if err := cancelJob(cancelCtx, httpC, canceler, jobOpts, origin); err != nil {
+		return nil, errors.Join(cause, err)
+	}
+
+	poller := jobPoller{timeout: jobCancelTimeout, interval: jobCancelPollInterval}
+	job, err := poller.poll(ctx, func(ctx context.Context) (*Job, error) {
+		job, err := refreshJob(ctx, httpC, c, jobOpts, origin)
+		if job != nil && progressFunc != nil {
+			progressFunc(job)
+		}
+		return job, err
+	})
+	if job != nil {
+		xlogger.Debug("Job cancelled", slog.String("jobID", job.ID), slog.String("status", job.Status.String()))
+	}
+
+	return job, errors.Join(cause, err)
+}
@@ -0,0 +1,332 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"resty.dev/v3"
+)
+
+type (
+	// jobHandle refreshes a job returned by an asynchronous call.
+	jobHandle struct {
+		// refresh returns the current state of the job.
+		refresh func(ctx context.Context) (*Job, error)
+		// cancel requests the cancellation of the job, nil if the job cannot be cancelled.
+		cancel func(ctx context.Context) error
+		// abort cancels the job once Wait has given up, cause being the error of the polling,
+		// and returns its final state. It is nil if the job is not cancelled on context done.
+		abort func(ctx context.Context, cause error) (*Job, error)
+		// progress is called with the job after each refresh.
+		progress JobProgressFunc
+		// onTerminated is called once when the job is terminated.
+		onTerminated func()
+
+		poller jobPoller
+
+		mu sync.Mutex
+		// err is the error of the job once it is terminated.
+		err error
+	}
+
+	// JobGroup collects the jobs returned by the asynchronous calls (see WithAsyncJobs),
+	// so they can be waited together.
+	JobGroup struct {
+		mu   sync.Mutex
+		jobs []*Job
+	}
+)
+
+var errJobNotAsync = errors.New("the job has not been returned by an asynchronous call, it cannot be refreshed")
+
+// NewJobGroup creates an empty group of jobs.
+func NewJobGroup() *JobGroup {
+	return &JobGroup{}
+}
+
+func (g *JobGroup) add(job *Job) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.jobs = append(g.jobs, job)
+}
+
+// Jobs returns the jobs of the group, in the order of the calls.
+func (g *JobGroup) Jobs() []*Job {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]*Job(nil), g.jobs...)
+}
+
+// Wait waits concurrently for all the jobs of the group (see WaitJobs).
+func (g *JobGroup) Wait(ctx context.Context) error {
+	return WaitJobs(ctx, g.Jobs()...)
+}
+
+// WaitJobs waits concurrently until all the jobs are terminated.
+// It returns the errors of the failed jobs joined, after all the jobs are terminated
+// or the context is done.
+func WaitJobs(ctx context.Context, jobs ...*Job) error {
+	errs := make([]error, len(jobs))
+
+	wg := sync.WaitGroup{}
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = job.Wait(ctx)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// CurrentStatus returns the status of the job when it was last refreshed.
+// It is safe to call while the job is refreshed by another goroutine.
+func (j *Job) CurrentStatus() JobStatus {
+	if j.handle == nil {
+		return j.Status
+	}
+
+	j.handle.mu.Lock()
+	defer j.handle.mu.Unlock()
+	return j.Status
+}
+
+// Refresh retrieves the current state of the job and updates it.
+// It returns the error of the job if the job has failed.
+func (j *Job) Refresh(ctx context.Context) error {
+	if j.handle == nil {
+		return errJobNotAsync
+	}
+
+	_, err := j.refresh(ctx)
+	return err
+}
+
+// refresh updates the job and returns a copy of it, nil if the state of the job is unknown.
+func (j *Job) refresh(ctx context.Context) (*Job, error) {
+	job, err := j.handle.refresh(ctx)
+	if job == nil {
+		// The job state is unknown, e.g. the request has failed.
+		if err == nil {
+			err = errors.New("no job returned by the refresh")
+		}
+		return nil, err
+	}
+
+	snapshot, err := j.update(job, err)
+	if j.handle.progress != nil {
+		j.handle.progress(snapshot)
+	}
+	return snapshot, err
+}
+
+// update replaces the state of the job with the refreshed job and returns a copy of it.
+// The error of the refresh is returned, or the error of the job if it was already terminated.
+func (j *Job) update(job *Job, err error) (*Job, error) {
+	j.handle.mu.Lock()
+	defer j.handle.mu.Unlock()
+
+	if j.Status.IsTerminated() {
+		// A terminated job is not updated.
+		snapshot := *j
+		return &snapshot, j.handle.err
+	}
+
+	id, handle := j.ID, j.handle
+	*j = *job
+	j.handle = handle
+	if j.ID == "" {
+		j.ID = id
+	}
+
+	if j.Status.IsTerminated() {
+		j.handle.err = err
+		xlogger.Debug("Job completed", slog.String("jobID", j.ID), slog.String("status", j.Status.String()))
+		if j.handle.onTerminated != nil {
+			j.handle.onTerminated()
+		}
+	}
+
+	snapshot := *j
+	return &snapshot, err
+}
+
+// Wait refreshes the job with the poll interval of the endpoint until it is terminated,
+// and returns its error if it has failed. The timeout of the job options of the endpoint
+// applies in addition to the deadline of the context, a *JobTimeoutError is returned
+// when one of them is reached.
+func (j *Job) Wait(ctx context.Context) error {
+	if j.handle == nil {
+		return errJobNotAsync
+	}
+
+	_, err := j.handle.poller.poll(ctx, j.refresh)
+
+	var timeoutErr *JobTimeoutError
+	if j.handle.abort == nil || !errors.As(err, &timeoutErr) {
+		return err
+	}
+
+	// The caller has given up, the job is cancelled instead of being left running.
+	job, err := j.handle.abort(ctx, err)
+	if job != nil {
+		j.update(job, err) //nolint:errcheck // the error of the cancellation is returned.
+	}
+	return err
+}
+
+// cancel requests the cancellation of the job and refreshes it.
+func (j *Job) cancel(ctx context.Context) error {
+	if j.handle == nil {
+		return errJobNotAsync
+	}
+	if j.handle.cancel == nil {
+		return errors.New("the job cannot be cancelled, only the VMware tasks can be cancelled")
+	}
+	if j.CurrentStatus().IsTerminated() {
+		return nil
+	}
+
+	if err := j.handle.cancel(ctx); err != nil {
+		return err
+	}
+
+	// The error of the job aborted is expected.
+	if err := j.Refresh(ctx); err != nil && !j.CurrentStatus().IsTerminated() {
+		return err
+	}
+	return nil
+}
+
+// CancelJob cancels a job returned by an asynchronous call (see WithAsyncJobs) and refreshes it.
+// The API aborts the job asynchronously, use Job.Wait to wait for its final state.
+// Only the VMware tasks can be cancelled.
+func (c *client) CancelJob(ctx context.Context, job *Job) error {
+	return job.cancel(ctx)
+}
+
+// newAsyncJobMiddleware creates a middleware returning a handle on the job of the response
+// instead of polling it until it is terminated. The handle is the result of the response
+// and is added to the group if any.
+func newAsyncJobMiddleware(httpC *resty.Client, c jobsInterface, jobOpts *JobOptions, call jobCallOptions) resty.ResponseMiddleware {
+	return func(_ *resty.Client, resp *resty.Response) error {
+		if err := jobOpts.withDefaults().validate(); err != nil {
+			return err
+		}
+
+		if ok := c.idempotentRetryCondition()(resp, nil); ok {
+			// If the response match with the retry Condition (BUSY) bypass the middleware
+			return nil
+		}
+
+		job, err := c.JobParser(resp)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return errors.New("no job returned by the API")
+		}
+		if job.Status == "" {
+			// The response creating the job does not always return its status.
+			job.Status = JobQueued
+		}
+
+		// The job is refreshed from a copy of the response, the result of the response
+		// is replaced by the handle below.
+		req := *resp.Request
+		origin := *resp
+		origin.Request = &req
+
+		job.handle = &jobHandle{
+			refresh: func(ctx context.Context) (*Job, error) {
+				return refreshJob(ctx, httpC, c, jobOpts, &origin)
+			},
+			progress:     call.progress,
+			onTerminated: call.onTerminated,
+			poller:       newJobPoller(jobOpts),
+		}
+
+		if canceler, ok := c.(jobCanceler); ok {
+			job.handle.cancel = func(ctx context.Context) error {
+				return cancelJob(ctx, httpC, canceler, jobOpts, &origin)
+			}
+			if call.cancelOnDone {
+				job.handle.abort = func(ctx context.Context, cause error) (*Job, error) {
+					return abortJob(ctx, cause, httpC, c, canceler, jobOpts, &origin, call.progress)
+				}
+			}
+		}
+
+		if call.group != nil {
+			call.group.add(job)
+		}
+		if _, ok := resp.Request.Result.(*Job); ok {
+			resp.Request.Result = job
+		}
+
+		return nil
+	}
+}
+
+// detachedJobResponse returns a copy of the response of the request creating the job,
+// to refresh or cancel the job once the request is over. The copy keeps the values of the
+// context of the request, e.g. the mock client, but is cancelled by ctx.
+// The requests sent with it only run the extractor middleware and are not retried.
+// The returned function releases the context.
+func detachedJobResponse(ctx context.Context, jobOpts *JobOptions, origin *resty.Response) (*resty.Response, []EndpointRequestOption, func()) {
+	pollMws := requestMiddlewares{}
+	if jobOpts.extractorFunc != nil {
+		pollMws.response = append(pollMws.response, extractorFuncMiddleware(jobOpts.extractorFunc))
+	}
+
+	pollCtx := storeRequestMiddlewaresInContext(context.WithoutCancel(origin.Request.Context()), pollMws)
+	pollCtx = storeRetryStateInContext(pollCtx, nil)
+	pollCtx, cancel := context.WithCancelCause(pollCtx)
+	stop := context.AfterFunc(ctx, func() { cancel(context.Cause(ctx)) })
+
+	req := *origin.Request
+	req.SetContext(pollCtx)
+	resp := *origin
+	resp.Request = &req
+
+	reqOpts := []EndpointRequestOption{
+		SetCustomRestyOption(func(r *resty.Request) { r.SetContext(pollCtx) }),
+		// The request is sent once, the caller decides when to send it again.
+		SetCustomRestyOption(func(r *resty.Request) { r.SetRetryCount(0) }),
+	}
+
+	return &resp, reqOpts, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// refreshJob refreshes the job of the response once.
+func refreshJob(ctx context.Context, httpC *resty.Client, c jobsInterface, jobOpts *JobOptions, origin *resty.Response) (*Job, error) {
+	resp, reqOpts, release := detachedJobResponse(ctx, jobOpts, origin)
+	defer release()
+
+	return c.JobRefresh(httpC, resp, reqOpts)
+}
+
+// cancelJob requests the cancellation of the job of the response.
+func cancelJob(ctx context.Context, httpC *resty.Client, c jobCanceler, jobOpts *JobOptions, origin *resty.Response) error {
+	resp, reqOpts, release := detachedJobResponse(ctx, jobOpts, origin)
+	defer release()
+
+	xlogger.Debug("Cancelling job", slog.String("endpoint", origin.Request.URL))
+	return c.JobCancel(httpC, resp, reqOpts)
+}
@@ -0,0 +1,166 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-openapi/v38.1/",
+		Name:             "UpdateTestAsyncJob",
+		Description:      "Update test async job",
+		Method:           MethodPUT,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/cloudapi/1.0.0/test/async",
+		BodyResponseType: Job{},
+	}.Register()
+}
+
+func TestClient_DoAsyncJobs(t *testing.T) {
+	c, err := newMockClient()
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("UpdateTestAsyncJob")
+	group := NewJobGroup()
+
+	ctx := ContextWithRequestOptions(t.Context(), WithAsyncJobs(group))
+	resp, err := c.Do(ctx, ep)
+	require.NoError(t, err)
+
+	job, ok := resp.Result().(*Job)
+	require.True(t, ok)
+	assert.Equal(t, "87ab1934-0146-4fb0-80bc-815fea03214d", job.ID)
+	assert.Equal(t, JobQueued, job.CurrentStatus())
+	assert.Equal(t, []*Job{job}, group.Jobs())
+
+	require.NoError(t, job.Wait(t.Context()))
+	assert.Equal(t, JobSuccess, job.CurrentStatus())
+
+	// The job is waited again once the option is overridden.
+	resp, err = c.Do(ContextWithRequestOptions(ctx, WithoutAsyncJobs()), ep)
+	require.NoError(t, err)
+	assert.Nil(t, resp.Result().(*Job).handle)
+	assert.Len(t, group.Jobs(), 1)
+}
+
+// newTestAsyncJob returns a job reaching the final status after the running refreshes.
+func newTestAsyncJob(running int, final JobStatus, finalErr error, terminated *atomic.Int32) *Job {
+	refreshes := 0
+	return &Job{
+		ID:     "job",
+		Status: JobQueued,
+		handle: &jobHandle{
+			refresh: func(_ context.Context) (*Job, error) {
+				refreshes++
+				if refreshes <= running {
+					return &Job{Status: JobRunning}, nil
+				}
+				return &Job{Name: "done", Status: final}, finalErr
+			},
+			onTerminated: func() { terminated.Add(1) },
+			poller:       jobPoller{interval: time.Millisecond},
+		},
+	}
+}
+
+func TestJob_Wait(t *testing.T) {
+	terminated := atomic.Int32{}
+	job := newTestAsyncJob(2, JobSuccess, nil, &terminated)
+
+	require.NoError(t, job.Refresh(t.Context()))
+	assert.Equal(t, JobRunning, job.CurrentStatus())
+
+	require.NoError(t, job.Wait(t.Context()))
+	assert.Equal(t, JobSuccess, job.CurrentStatus())
+	assert.Equal(t, "done", job.Name)
+	assert.Equal(t, "job", job.ID)
+
+	// The job is terminated once.
+	require.NoError(t, job.Wait(t.Context()))
+	assert.Equal(t, int32(1), terminated.Load())
+}
+
+func TestJob_WaitFailed(t *testing.T) {
+	terminated := atomic.Int32{}
+	job := newTestAsyncJob(0, JobError, errors.New("job failed"), &terminated)
+
+	require.EqualError(t, job.Wait(t.Context()), "job failed")
+	assert.Equal(t, JobError, job.CurrentStatus())
+	require.EqualError(t, job.Refresh(t.Context()), "job failed")
+	assert.Equal(t, int32(1), terminated.Load())
+}
+
+func TestJob_WaitContextDone(t *testing.T) {
+	terminated := atomic.Int32{}
+	job := newTestAsyncJob(1000, JobSuccess, nil, &terminated)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, job.Wait(ctx), context.DeadlineExceeded)
+	assert.Equal(t, JobRunning, job.CurrentStatus())
+	assert.Zero(t, terminated.Load())
+}
+
+func TestJob_NotAsync(t *testing.T) {
+	job := &Job{Status: JobSuccess}
+	assert.Equal(t, JobSuccess, job.CurrentStatus())
+	require.ErrorIs(t, job.Refresh(t.Context()), errJobNotAsync)
+	require.ErrorIs(t, job.Wait(t.Context()), errJobNotAsync)
+}
+
+func TestJobGroup_Wait(t *testing.T) {
+	terminated := atomic.Int32{}
+	group := NewJobGroup()
+	group.add(newTestAsyncJob(1, JobSuccess, nil, &terminated))
+	group.add(newTestAsyncJob(0, JobError, errors.New("first failed"), &terminated))
+	group.add(newTestAsyncJob(2, JobAborted, errors.New("second failed"), &terminated))
+
+	err := group.Wait(t.Context())
+	require.ErrorContains(t, err, "first failed")
+	require.ErrorContains(t, err, "second failed")
+
+	for _, job := range group.Jobs() {
+		assert.True(t, job.CurrentStatus().IsTerminated())
+	}
+	assert.Equal(t, int32(3), terminated.Load())
+
+	require.NoError(t, NewJobGroup().Wait(t.Context()))
+}
+
+func TestJob_Cancel(t *testing.T) {
+	terminated := atomic.Int32{}
+	job := newTestAsyncJob(0, JobAborted, errors.New("job aborted"), &terminated)
+
+	// The job cannot be cancelled without the cancel action of its subclient.
+	require.ErrorContains(t, job.cancel(t.Context()), "cannot be cancelled")
+
+	cancelled := 0
+	job.handle.cancel = func(_ context.Context) error {
+		cancelled++
+		return nil
+	}
+	require.NoError(t, job.cancel(t.Context()))
+	assert.Equal(t, JobAborted, job.CurrentStatus())
+
+	// A terminated job is not cancelled again.
+	require.NoError(t, job.cancel(t.Context()))
+	assert.Equal(t, 1, cancelled)
+
+	require.ErrorIs(t, (&Job{}).cancel(t.Context()), errJobNotAsync)
+}
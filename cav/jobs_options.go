@@ -14,27 +14,43 @@ import (
 	"time"
 
 	"resty.dev/v3"
-
-	"github.com/orange-cloudavenue/common-go/validators"
 )
 
 type (
 	// JobOptions defines the options for job operations.
 	JobOptions struct {
 		// Timeout specifies the maximum duration to wait for a job to complete.
-		// If the job does not complete within this time, an error will be returned.
-		// Default is 5 minutes
-		// If you want to wait indefinitely, set this to -1.
-		Timeout time.Duration `default:"5m"`
+		// If the job does not complete within this time, a *JobTimeoutError will be returned.
+		// Default is 5 minutes.
+		// If you want to wait indefinitely, set this to JobTimeoutInfinite (-1),
+		// the wait is then only bounded by the context.
+		Timeout time.Duration
 
 		// PollInterval specifies the interval between job status checks.
 		// Default is 15 seconds.
 		// This value should be less than the timeout.
-		PollInterval time.Duration `default:"15s"`
+		PollInterval time.Duration
+
+		// PollStrategy specifies how the interval evolves between the job status checks.
+		// Default is JobPollFixed.
+		PollStrategy JobPollStrategy
+
+		// MaxPollInterval is the upper bound of the interval with JobPollExponential.
+		// Default is 1 minute.
+		MaxPollInterval time.Duration
 
 		extractorFunc ExtractorFunc
+		progressFunc  JobProgressFunc
+		cancelOnDone  bool
 	}
 
+	// JobPollStrategy defines how the interval between the job status checks evolves.
+	JobPollStrategy string
+
+	// JobProgressFunc is called with the job after each poll of its status,
+	// e.g. to display its progress. It must not modify the job.
+	JobProgressFunc func(job *Job)
+
 	// ExtractorFunc defines a function type for extracting data from a resty.Response.
 	//
 	// The function does not return an error for not interrupting the job flow.
@@ -55,17 +71,29 @@ type (
 	JobOption func(*JobOptions) error
 )
 
+const (
+	JobPollFixed       JobPollStrategy = "fixed"       // The job is polled every PollInterval.
+	JobPollExponential JobPollStrategy = "exponential" // The interval is doubled after each poll, up to MaxPollInterval.
+
+	// JobTimeoutInfinite waits for the job until the context is done.
+	JobTimeoutInfinite time.Duration = -1
+)
+
+const (
+	defaultJobTimeout         = 5 * time.Minute
+	defaultJobPollInterval    = 15 * time.Second
+	defaultJobMaxPollInterval = time.Minute
+)
+
 // NewJobOptions creates a new JobOptions instance with default values.
 // Default values can be overridden by passing options.
 // Default values:
 //   - Timeout: 5 minutes
 //   - PollInterval: 15 seconds
+//   - PollStrategy: JobPollFixed
+//   - MaxPollInterval: 1 minute
 func NewJobOptions(opts ...JobOption) (*JobOptions, error) {
-	jO := &JobOptions{}
-
-	if err := validators.New().Struct(jO); err != nil {
-		return nil, err
-	}
+	jO := (&JobOptions{}).withDefaults()
 
 	// Override default values with provided options.
 	for _, opt := range opts {
@@ -74,10 +102,15 @@ func NewJobOptions(opts ...JobOption) (*JobOptions, error) {
 		}
 	}
 
+	if err := jO.validate(); err != nil {
+		return nil, err
+	}
+
 	return jO, nil
 }
 
 // WithCustomTimeout sets the maximum duration to wait for a job to complete.
+// Use JobTimeoutInfinite to wait until the context is done.
 //
 // Example:
 //
@@ -88,6 +121,9 @@ func NewJobOptions(opts ...JobOption) (*JobOptions, error) {
 //	// opts.Timeout will be 10 minutes
 func WithCustomTimeout(timeout time.Duration) JobOption {
 	return func(opts *JobOptions) error {
+		if timeout == 0 || timeout < JobTimeoutInfinite {
+			return fmt.Errorf("timeout must be greater than 0 or JobTimeoutInfinite, got %s", timeout)
+		}
 		opts.Timeout = timeout
 		return nil
 	}
@@ -112,6 +148,30 @@ func WithCustomPollInterval(interval time.Duration) JobOption {
 	}
 }
 
+// WithExponentialPollInterval doubles the interval between the job status checks after each check,
+// starting from the poll interval, up to maxInterval.
+//
+// Example:
+//
+//	opts, err := NewJobOptions(
+//	    WithCustomPollInterval(time.Second),
+//	    WithExponentialPollInterval(30 * time.Second),
+//	)
+//	if err != nil {
+//	    // handle error
+//	}
+//	// The job is checked after 1s, 2s, 4s, 8s, 16s, 30s, 30s...
+func WithExponentialPollInterval(maxInterval time.Duration) JobOption {
+	return func(opts *JobOptions) error {
+		if maxInterval <= 0 {
+			return fmt.Errorf("max poll interval must be greater than 0, got %s", maxInterval)
+		}
+		opts.PollStrategy = JobPollExponential
+		opts.MaxPollInterval = maxInterval
+		return nil
+	}
+}
+
 // SetExtractorFunc sets a custom extractor function for parsing job responses.
 // The extractor function should take a resty.Response and a target type to populate.
 //
@@ -130,3 +190,84 @@ func SetExtractorFunc(extractorFunc ExtractorFunc) JobOption {
 		return nil
 	}
 }
+
+// SetProgressFunc sets a function called with the job after each poll of its status.
+//
+// Example:
+//
+//	progress := func(job *cav.Job) {
+//	    fmt.Printf("%s: %d%%\n", job.Operation, job.Progress)
+//	}
+//	opts, err := NewJobOptions(SetProgressFunc(progress))
+//	if err != nil {
+//	    // handle error
+//	}
+func SetProgressFunc(progressFunc JobProgressFunc) JobOption {
+	return func(opts *JobOptions) error {
+		opts.progressFunc = progressFunc
+		return nil
+	}
+}
+
+// WithCancelOnContextDone cancels the job on the platform when the context of the caller is done
+// or the timeout is reached, see WithJobCancelOnContextDone to enable it for a single call.
+//
+// Example:
+//
+//	opts, err := NewJobOptions(WithCancelOnContextDone())
+//	if err != nil {
+//	    // handle error
+//	}
+func WithCancelOnContextDone() JobOption {
+	return func(opts *JobOptions) error {
+		opts.cancelOnDone = true
+		return nil
+	}
+}
+
+// progress calls the progress function of the options and the progress function
+// of the request, if any.
+func (o *JobOptions) progress(requestFunc JobProgressFunc) JobProgressFunc {
+	return func(job *Job) {
+		if o.progressFunc != nil {
+			o.progressFunc(job)
+		}
+		if requestFunc != nil {
+			requestFunc(job)
+		}
+	}
+}
+
+// withDefaults returns a copy of the options with the default values of the fields not set.
+// The options of an endpoint are shared by the requests and never modified.
+func (o *JobOptions) withDefaults() *JobOptions {
+	opts := *o
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultJobTimeout
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = defaultJobPollInterval
+	}
+	if opts.PollStrategy == "" {
+		opts.PollStrategy = JobPollFixed
+	}
+	if opts.MaxPollInterval == 0 {
+		opts.MaxPollInterval = defaultJobMaxPollInterval
+	}
+	return &opts
+}
+
+// validate checks the options once the default values are applied.
+func (o *JobOptions) validate() error {
+	switch {
+	case o.Timeout < JobTimeoutInfinite:
+		return fmt.Errorf("invalid job options: timeout must be greater than 0 or JobTimeoutInfinite, got %s", o.Timeout)
+	case o.PollInterval < 0:
+		return fmt.Errorf("invalid job options: poll interval must be greater than 0, got %s", o.PollInterval)
+	case o.MaxPollInterval < 0:
+		return fmt.Errorf("invalid job options: max poll interval must be greater than 0, got %s", o.MaxPollInterval)
+	case o.PollStrategy != JobPollFixed && o.PollStrategy != JobPollExponential:
+		return fmt.Errorf("invalid job options: unknown poll strategy %q", o.PollStrategy)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// jobPoller refreshes a job at regular intervals until it is terminated.
+type jobPoller struct {
+	// timeout is the maximum duration of the polling, no timeout if it is not positive.
+	// The polling is always stopped when the context is done.
+	timeout time.Duration
+	// interval is the wait between two refreshes, it is doubled after each refresh
+	// up to maxInterval if exponential is set.
+	interval    time.Duration
+	maxInterval time.Duration
+	exponential bool
+}
+
+// newJobPoller creates the poller of the job options, the default values are applied
+// to the options not set.
+func newJobPoller(jobOpts *JobOptions) jobPoller {
+	opts := jobOpts.withDefaults()
+	return jobPoller{
+		timeout:     opts.Timeout,
+		interval:    opts.PollInterval,
+		maxInterval: opts.MaxPollInterval,
+		exponential: opts.PollStrategy == JobPollExponential,
+	}
+}
+
+// wait returns the wait before the refresh following the given number of refreshes.
+func (p jobPoller) wait(refreshes int) time.Duration {
+	if !p.exponential {
+		return p.interval
+	}
+
+	wait := p.interval
+	for range refreshes - 1 {
+		wait *= 2
+		if wait >= p.maxInterval {
+			return max(p.maxInterval, p.interval)
+		}
+	}
+	return wait
+}
+
+// poll calls refresh until the job is terminated and returns the job in its final state
+// with the error of refresh. If the timeout is reached or the context is done before,
+// a *JobTimeoutError holding the last job refreshed is returned.
+// A failed refresh which does not return a terminated job stops the polling.
+func (p jobPoller) poll(ctx context.Context, refresh func(ctx context.Context) (*Job, error)) (*Job, error) {
+	start := time.Now()
+
+	pollCtx := ctx
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	var last *Job
+	for refreshes := 1; ; refreshes++ {
+		job, err := refresh(pollCtx)
+		if job != nil {
+			last = job
+			if job.Status.IsTerminated() {
+				return job, err
+			}
+		}
+		if err != nil && pollCtx.Err() == nil {
+			return last, err
+		}
+
+		if pollCtx.Err() == nil {
+			wait := p.wait(refreshes)
+			xlogger.Debug("Job not terminated, waiting before the next poll", slog.Int("poll", refreshes), slog.Duration("wait", wait))
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-pollCtx.Done():
+				timer.Stop()
+			case <-timer.C:
+				continue
+			}
+		}
+
+		// The caller has given up or the timeout of the job is reached.
+		timeoutErr := &JobTimeoutError{Job: last, Elapsed: time.Since(start), Err: ctx.Err()}
+		if timeoutErr.Err == nil {
+			timeoutErr.Err = context.DeadlineExceeded
+			timeoutErr.Timeout = p.timeout
+		}
+		return last, timeoutErr
+	}
+}
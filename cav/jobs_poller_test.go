@@ -0,0 +1,140 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRefresh returns a refresh reaching the final status after the running refreshes.
+func newTestRefresh(running int, final JobStatus, finalErr error, refreshes *int) func(context.Context) (*Job, error) {
+	return func(_ context.Context) (*Job, error) {
+		*refreshes++
+		if *refreshes <= running {
+			return &Job{ID: "job", Status: JobRunning, Progress: *refreshes * 10}, nil
+		}
+		return &Job{ID: "job", Status: final}, finalErr
+	}
+}
+
+func TestJobPoller_Poll(t *testing.T) {
+	refreshes := 0
+	poller := jobPoller{interval: time.Millisecond}
+
+	job, err := poller.poll(t.Context(), newTestRefresh(3, JobSuccess, nil, &refreshes))
+	require.NoError(t, err)
+	assert.Equal(t, JobSuccess, job.Status)
+	assert.Equal(t, 4, refreshes)
+
+	// The error of the job terminated is returned.
+	refreshes = 0
+	job, err = poller.poll(t.Context(), newTestRefresh(1, JobError, errors.New("job failed"), &refreshes))
+	require.EqualError(t, err, "job failed")
+	assert.Equal(t, JobError, job.Status)
+
+	// A failed refresh stops the polling.
+	refreshes = 0
+	job, err = poller.poll(t.Context(), func(_ context.Context) (*Job, error) {
+		refreshes++
+		return nil, errors.New("refresh failed")
+	})
+	require.EqualError(t, err, "refresh failed")
+	assert.Nil(t, job)
+	assert.Equal(t, 1, refreshes)
+}
+
+func TestJobPoller_Timeout(t *testing.T) {
+	refreshes := 0
+	poller := jobPoller{interval: time.Millisecond, timeout: 20 * time.Millisecond}
+
+	job, err := poller.poll(t.Context(), newTestRefresh(1000, JobSuccess, nil, &refreshes))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The error holds the last known state of the job.
+	var timeoutErr *JobTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, job, timeoutErr.Job)
+	assert.Equal(t, JobRunning, timeoutErr.Job.Status)
+	assert.Equal(t, 20*time.Millisecond, timeoutErr.Timeout)
+	assert.GreaterOrEqual(t, timeoutErr.Elapsed, 20*time.Millisecond)
+	assert.Contains(t, err.Error(), "job job is still running")
+
+	// The context of the caller is done.
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = poller.poll(ctx, newTestRefresh(1000, JobSuccess, nil, &refreshes))
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Zero(t, timeoutErr.Timeout)
+}
+
+func TestJobPoller_Infinite(t *testing.T) {
+	refreshes := 0
+	poller := newJobPoller(&JobOptions{Timeout: JobTimeoutInfinite, PollInterval: time.Millisecond})
+
+	// The job is polled until it is terminated.
+	job, err := poller.poll(t.Context(), newTestRefresh(50, JobSuccess, nil, &refreshes))
+	require.NoError(t, err)
+	assert.Equal(t, JobSuccess, job.Status)
+
+	// The wait is only bounded by the context.
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	_, err = poller.poll(ctx, newTestRefresh(1000, JobSuccess, nil, &refreshes))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestJobPoller_Wait(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []JobOption
+		expected []time.Duration
+	}{
+		{
+			name:     "Default",
+			expected: []time.Duration{15 * time.Second, 15 * time.Second, 15 * time.Second},
+		},
+		{
+			name:     "Fixed",
+			opts:     []JobOption{WithCustomPollInterval(2 * time.Second)},
+			expected: []time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second},
+		},
+		{
+			name: "Exponential",
+			opts: []JobOption{WithCustomPollInterval(time.Second), WithExponentialPollInterval(5 * time.Second)},
+			expected: []time.Duration{
+				time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+			},
+		},
+		{
+			name:     "Exponential below the poll interval",
+			opts:     []JobOption{WithCustomPollInterval(time.Minute), WithExponentialPollInterval(time.Second)},
+			expected: []time.Duration{time.Minute, time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := NewJobOptions(tt.opts...)
+			require.NoError(t, err)
+
+			poller := newJobPoller(opts)
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, poller.wait(i+1), "wait after %d refreshes", i+1)
+			}
+		})
+	}
+}
@@ -9,6 +9,77 @@
 
 package cav
 
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DoJobProgress(t *testing.T) {
+	c, err := newMockClient()
+	require.NoError(t, err)
+
+	ep := MustGetEndpoint("UpdateTestAsyncJob")
+
+	mu := sync.Mutex{}
+	var polled []*Job
+	ctx := ContextWithRequestOptions(t.Context(), WithJobProgress(func(job *Job) {
+		mu.Lock()
+		defer mu.Unlock()
+		polled = append(polled, job)
+	}))
+
+	// The result of the response is the job in its final state.
+	resp, err := c.Do(ctx, ep)
+	require.NoError(t, err)
+	job := resp.Result().(*Job)
+	assert.Equal(t, JobSuccess, job.Status)
+	assert.NotEmpty(t, job.Operation)
+	require.Len(t, polled, 1)
+	assert.Equal(t, job, polled[0])
+
+	// The progress of an asynchronous job is reported by Refresh.
+	resp, err = c.Do(ContextWithRequestOptions(ctx, WithAsyncJobs(nil)), ep)
+	require.NoError(t, err)
+	require.Len(t, polled, 1)
+	require.NoError(t, resp.Result().(*Job).Wait(t.Context()))
+	require.Len(t, polled, 2)
+	assert.Equal(t, JobSuccess, polled[1].Status)
+
+	_, err = newRequestOptions(t.Context(), WithJobProgress(nil))
+	assert.Error(t, err)
+}
+
+func TestNewJobOptions(t *testing.T) {
+	opts, err := NewJobOptions()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, opts.Timeout)
+	assert.Equal(t, 15*time.Second, opts.PollInterval)
+	assert.Equal(t, JobPollFixed, opts.PollStrategy)
+	assert.Equal(t, time.Minute, opts.MaxPollInterval)
+
+	opts, err = NewJobOptions(WithCustomTimeout(JobTimeoutInfinite), WithExponentialPollInterval(30*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, JobTimeoutInfinite, opts.Timeout)
+	assert.Equal(t, JobPollExponential, opts.PollStrategy)
+	assert.Equal(t, 30*time.Second, opts.MaxPollInterval)
+
+	_, err = NewJobOptions(WithCustomTimeout(0))
+	require.Error(t, err)
+	_, err = NewJobOptions(WithExponentialPollInterval(0))
+	require.Error(t, err)
+
+	// The options of an endpoint are not modified by the default values.
+	epOpts := &JobOptions{PollInterval: time.Second}
+	assert.Equal(t, 5*time.Minute, epOpts.withDefaults().Timeout)
+	assert.Zero(t, epOpts.Timeout)
+	require.Error(t, (&JobOptions{Timeout: -2}).withDefaults().validate())
+	require.Error(t, (&JobOptions{PollStrategy: "linear"}).withDefaults().validate())
+}
+
 // // --- Mocks ---
 
 // type mockClient struct {
@@ -9,6 +9,13 @@
 
 package cav
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+)
+
 type (
 	// Job struct defines the job status.
 	Job struct {
@@ -25,7 +32,68 @@ type (
 		HREF string
 
 		// Status is the current status of the job.
+		// Use CurrentStatus() to read it while the job is refreshed by another goroutine.
 		Status JobStatus
+
+		// Operation is the operation tracked by the job.
+		Operation string
+
+		// Progress is an approximate percentage of completion between 0 and 100.
+		// For a Cerberus job, it is the percentage of the actions done.
+		Progress int
+
+		// Details is the detailed message of the job, if any.
+		Details string
+
+		// StartTime and EndTime are the start and the end of the job,
+		// they are zero if the API does not return them.
+		StartTime time.Time
+		EndTime   time.Time
+
+		// Owner is the entity the job operates on, nil if the API does not return it.
+		Owner *JobOwner
+
+		// Actions are the steps of a Cerberus job.
+		Actions []JobAction
+
+		// handle refreshes the job returned by an asynchronous call (see WithAsyncJobs).
+		handle *jobHandle
+	}
+
+	// JobOwner is the entity a job operates on.
+	JobOwner struct {
+		ID   string
+		Name string
+		// Type is the type of the entity, e.g. application/vnd.vmware.vcloud.vdc+xml.
+		Type string
+		HREF string
+	}
+
+	// JobAction is a step of a Cerberus job.
+	JobAction struct {
+		Name    string
+		Status  JobStatus
+		Details string
+	}
+
+	// JobFailedError is the error of a job terminated in error or aborted.
+	// Err is the error returned by the API, Job is the job in its final state.
+	JobFailedError struct {
+		Job *Job
+		Err error
+	}
+
+	// JobTimeoutError is the error of a job not terminated before the timeout of its job options
+	// or before the context of the caller is done. Job is the last known state of the job,
+	// nil if it has never been refreshed.
+	JobTimeoutError struct {
+		Job *Job
+		// Timeout is the timeout of the job options, zero if the context of the caller is done.
+		Timeout time.Duration
+		// Elapsed is the duration of the polling.
+		Elapsed time.Duration
+		// Err is context.DeadlineExceeded or the error of the context of the caller.
+		Err error
 	}
 
 	JobStatus string // JobStatus represents the job status, e.g., "queued", "running", "success", "error", "aborted" etc.
@@ -48,3 +116,36 @@ func (s JobStatus) IsTerminated() bool {
 func (s JobStatus) String() string {
 	return string(s)
 }
+
+// Error returns the error message of the API.
+func (e *JobFailedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error of the API, e.g. an *errors.APIError.
+func (e *JobFailedError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is errors.ErrJobFailed.
+func (e *JobFailedError) Is(target error) bool {
+	return target == errors.ErrJobFailed
+}
+
+// Error returns the last known state of the job and the cause of the timeout.
+func (e *JobTimeoutError) Error() string {
+	if e.Job == nil {
+		return fmt.Sprintf("job not terminated after %s: %v", e.Elapsed.Round(time.Millisecond), e.Err)
+	}
+	return fmt.Sprintf("job %s is still %s (%d%%) after %s: %v", e.Job.ID, e.Job.Status, e.Job.Progress, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+// Unwrap returns context.DeadlineExceeded or the error of the context of the caller.
+func (e *JobTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is errors.ErrTimeout.
+func (e *JobTimeoutError) Is(target error) bool {
+	return target == errors.ErrTimeout
+}
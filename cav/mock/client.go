@@ -12,7 +12,9 @@ package mock
 import (
 	"log"
 	"log/slog"
+	"net/http"
 	"net/http/httptest"
+	"testing"
 
 	"github.com/go-chi/chi/v5"
 
@@ -27,7 +29,46 @@ const (
 
 var logger = xlog.GetGlobalLogger()
 
+// Client is a mock client sending its requests to its own mock server.
+// The mock responses set on a Client do not change the shared endpoints,
+// so the tests using different responses can run in parallel.
+type Client struct {
+	cav.Client
+
+	server    *httptest.Server
+	responses *mockResponses
+	recorder  *recorder
+}
+
+// NewClient creates a mock client with its own mock server.
+// The server is closed with Close, see NewTestClient to close it at the end of a test.
 func NewClient(opts ...OptionFunc) (cav.Client, error) {
+	c, err := newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewTestClient creates a mock client closed at the end of the test.
+// The test fails if the client cannot be created.
+func NewTestClient(t testing.TB, opts ...OptionFunc) *Client {
+	t.Helper()
+
+	c, err := newClient(opts...)
+	if err != nil {
+		t.Fatalf("failed to create the mock client: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Errorf("failed to close the mock client: %v", err)
+		}
+	})
+
+	return c
+}
+
+func newClient(opts ...OptionFunc) (*Client, error) {
 	// Mock implementation for testing purposes
 
 	// Get All endpoints available in the endpoint package
@@ -49,11 +90,41 @@ func NewClient(opts ...OptionFunc) (cav.Client, error) {
 
 	endpoints := cav.GetEndpointsUncategorized()
 	mux := chi.NewRouter()
+	jobs := newFaultJobs()
+	responses := newMockResponses()
+	rec := &recorder{}
 
 	// Here, for each endpoint, we build a response handler for the mock HTTP server
 	for _, ep := range endpoints {
 		logger.Debug("Registering mock endpoint", slog.String("name", ep.Name), slog.String("method", ep.Method.String()), slog.String("path", ep.MockPath()), slog.String("ID", ep.ID))
-		mux.MethodFunc(ep.Method.String(), ep.MockPath(), cav.GetDefaultMockResponseFunc(ep))
+		handler := cav.GetDefaultMockResponseFunc(ep)
+		if Options.simulator != nil {
+			if h := Options.simulator.handler(ep); h != nil {
+				handler = h
+			}
+		}
+
+		handler = responses.handler(ep, handler)
+
+		switch ep.Name {
+		case "GetJobCerberus":
+			handler = jobs.handler(cerberusJob, handler)
+		case "GetJobVmware":
+			handler = jobs.handler(vmwareTask, handler)
+		}
+
+		if faults, ok := Options.faults[ep.Name]; ok {
+			ef, err := newEndpointFaults(ep, faults)
+			if err != nil {
+				return nil, err
+			}
+			handler = ef.handler(ep, jobs, handler)
+		}
+
+		// The requests are recorded before the faults are injected.
+		handler = rec.handler(ep, handler)
+
+		mux.MethodFunc(ep.Method.String(), ep.MockPath(), handler)
 	}
 
 	hts := httptest.NewServer(mux)
@@ -62,8 +133,7 @@ func NewClient(opts ...OptionFunc) (cav.Client, error) {
 
 	logger.Debug("Mock server created", slog.String("url", hts.URL))
 
-	nC, err := cav.NewClient(
-		mockOrg,
+	cavOpts := []cav.ClientOption{
 		cav.WithCustomEndpoints(consoles.Services{
 			IHM: consoles.Service{
 				Enabled:  true,
@@ -79,25 +149,79 @@ func NewClient(opts ...OptionFunc) (cav.Client, error) {
 			},
 			S3: consoles.Service{
 				Enabled:  true,
-				Endpoint: hts.URL + "/s3",
+				Endpoint: hts.URL,
 			},
 			Netbackup: consoles.Service{
 				Enabled:  true,
-				Endpoint: hts.URL + "/netbackup",
+				Endpoint: hts.URL,
 			},
 		}),
 		cav.WithCloudAvenueCredential("mockuser", "mockpassword"),
-		cav.WithLogger(logger),
-	)
+		cav.WithNetbackupCredential("mockuser", "mockpassword"),
+	}
+
+	// The logger of the SDK is global, it is only replaced by a custom logger
+	// so the mock clients can be created while other mock clients are running.
+	if Options.logger != nil {
+		cavOpts = append(cavOpts, cav.WithLogger(logger))
+	}
+
+	cavOpts = append(cavOpts, Options.clientOptions...)
+
+	nC, err := cav.NewClient(mockOrg, cavOpts...)
 	if err != nil {
+		hts.Close()
 		return nil, err
 	}
 
 	logger.Debug("Mock client created", slog.String("organization", mockOrg))
 
-	return nC, nil
+	return &Client{
+		Client:    nC,
+		server:    hts,
+		responses: responses,
+		recorder:  rec,
+	}, nil
+}
+
+// Close closes the client and its mock server.
+func (c *Client) Close() error {
+	err := c.Client.Close()
+	c.server.Close()
+	return err
+}
+
+// SetMockResponse sets the mock response of the next request of the endpoint, for this client only.
+// As with Endpoint.SetMockResponse, the default response is restored after the request,
+// so a retried request receives the default response.
+// If data is nil, the body is generated from the response type of the endpoint.
+// If statusCode is nil, the status code is 200.
+func (c *Client) SetMockResponse(ep *cav.Endpoint, data any, statusCode *int) {
+	c.responses.set(ep, cav.NewMockResponseFunc(ep, data, statusCode))
+	logger.Debug("Client mock response set for endpoint", slog.String("endpoint", ep.Name))
+}
+
+// SetMockResponseFunc sets the handler answering the next request of the endpoint, for this client only.
+func (c *Client) SetMockResponseFunc(ep *cav.Endpoint, fn http.HandlerFunc) {
+	c.responses.set(ep, fn)
+	logger.Debug("Client mock response function set for endpoint", slog.String("endpoint", ep.Name))
+}
+
+// CleanMockResponse restores the default mock response of the endpoint for this client,
+// if the mock response set has not been used.
+func (c *Client) CleanMockResponse(ep *cav.Endpoint) {
+	c.responses.clean(ep)
+}
+
+// CleanMockResponses restores the default mock responses of all the endpoints for this client.
+func (c *Client) CleanMockResponses() {
+	c.responses.cleanAll()
 }
 
+// SetMockResponse sets the mock response of the endpoint for all the mock clients.
+//
+// Deprecated: the endpoint is shared by all the mock clients, so the tests setting
+// different responses cannot run in parallel. Use Client.SetMockResponse instead.
 func SetMockResponse(ep *cav.Endpoint, mockResponseData any, mockResponseStatusCode *int) {
 	ep.SetMockResponse(mockResponseData, mockResponseStatusCode)
 	logger.Debug("Mock response set for endpoint", slog.String("endpoint", ep.Name), slog.Int("status_code", *mockResponseStatusCode))
@@ -10,13 +10,19 @@
 package mock
 
 import (
+	"errors"
 	"log/slog"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
 )
 
 type OptionFunc func(*Options) error
 
 type Options struct {
-	logger *slog.Logger
+	logger        *slog.Logger
+	simulator     *Simulator
+	faults        map[string][]Fault
+	clientOptions []cav.ClientOption
 }
 
 func WithLogger(logger *slog.Logger) OptionFunc {
@@ -28,3 +34,25 @@ func WithLogger(logger *slog.Logger) OptionFunc {
 		return nil
 	}
 }
+
+// WithSimulator serves the endpoints handled by the simulator from its in-memory state
+// instead of the default mock responses.
+func WithSimulator(sim *Simulator) OptionFunc {
+	return func(c *Options) error {
+		if sim == nil {
+			return errors.New("simulator cannot be nil")
+		}
+
+		c.simulator = sim
+		return nil
+	}
+}
+
+// WithClientOptions adds options to the client created for the mock server,
+// e.g. cav.WithResponseCache to test the cache of the commands.
+func WithClientOptions(opts ...cav.ClientOption) OptionFunc {
+	return func(c *Options) error {
+		c.clientOptions = append(c.clientOptions, opts...)
+		return nil
+	}
+}
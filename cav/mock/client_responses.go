@@ -0,0 +1,67 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+)
+
+// mockResponses holds the mock responses set on a client, by endpoint name.
+// They take precedence over the responses of the shared endpoints and of the simulator.
+// As with Endpoint.SetMockResponse, a mock response answers the next request only.
+type mockResponses struct {
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+}
+
+func newMockResponses() *mockResponses {
+	return &mockResponses{handlers: map[string]http.HandlerFunc{}}
+}
+
+func (m *mockResponses) set(ep *cav.Endpoint, fn http.HandlerFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.handlers[ep.Name] = fn
+}
+
+func (m *mockResponses) clean(ep *cav.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.handlers, ep.Name)
+}
+
+func (m *mockResponses) cleanAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.handlers)
+}
+
+// handler wraps the handler of the endpoint to answer the mock response set on the client, if any,
+// then restore the default response.
+func (m *mockResponses) handler(ep *cav.Endpoint, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		fn, ok := m.handlers[ep.Name]
+		delete(m.handlers, ep.Name)
+		m.mu.Unlock()
+
+		if ok {
+			fn(w, r)
+			return
+		}
+		next(w, r)
+	}
+}
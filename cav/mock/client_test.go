@@ -0,0 +1,187 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	edgegateway "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/edgegateway/v1"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav/mock"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	cerrors "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+func TestClient_MockResponsesIsolation(t *testing.T) {
+	ep := endpoints.GetOrganization()
+
+	for _, name := range []string{"org-a", "org-b", "org-c", "org-d"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mC := mock.NewTestClient(t)
+			mC.SetMockResponse(ep, &itypes.ApiResponseGetOrg{Name: name}, nil)
+
+			resp, err := mC.Do(t.Context(), ep)
+			require.NoError(t, err)
+			assert.Equal(t, name, resp.Result().(*itypes.ApiResponseGetOrg).Name)
+
+			// The default response is restored after the request.
+			resp, err = mC.Do(t.Context(), ep)
+			require.NoError(t, err)
+			assert.NotEqual(t, name, resp.Result().(*itypes.ApiResponseGetOrg).Name)
+
+			// A response not used is removed by CleanMockResponse.
+			mC.SetMockResponse(ep, &itypes.ApiResponseGetOrg{Name: name}, nil)
+			mC.CleanMockResponse(ep)
+			resp, err = mC.Do(t.Context(), ep)
+			require.NoError(t, err)
+			assert.NotEqual(t, name, resp.Result().(*itypes.ApiResponseGetOrg).Name)
+		})
+	}
+}
+
+func TestClient_SetMockResponseFunc(t *testing.T) {
+	t.Parallel()
+
+	ep := endpoints.GetOrganization()
+	mC := mock.NewTestClient(t)
+	mC.SetMockResponseFunc(ep, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, err := mC.Do(t.Context(), ep)
+	var apiErr *cerrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+
+	// The other clients are not affected.
+	other := mock.NewTestClient(t)
+	_, err = other.Do(t.Context(), ep)
+	require.NoError(t, err)
+
+	mC.SetMockResponseFunc(ep, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mC.CleanMockResponses()
+	_, err = mC.Do(t.Context(), ep)
+	require.NoError(t, err)
+}
+
+func TestClient_CoalesceCommands(t *testing.T) {
+	sim, err := mock.NewSimulator(mock.WithFixture(faultsFixture))
+	require.NoError(t, err)
+
+	// The requests are slow enough to be in flight at the same time.
+	mC := mock.NewTestClient(t,
+		mock.WithSimulator(sim),
+		mock.WithFaults("QueryEdgeGateway", mock.FaultFixedLatency(100*time.Millisecond)),
+		mock.WithFaults("GetEdgeGateway", mock.FaultFixedLatency(100*time.Millisecond)),
+	)
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	// The client authenticates before the concurrent commands.
+	_, err = eC.ListEdgeGateway(t.Context())
+	require.NoError(t, err)
+	mC.ResetCalls()
+
+	// The job options of the context do not change the GET requests of the commands.
+	ctx := cav.ContextWithRequestOptions(t.Context(), cav.WithJobProgress(func(*cav.Job) {}))
+
+	const callers = 5
+	wg := sync.WaitGroup{}
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			edge, err := eC.GetEdgeGateway(ctx, types.ParamsEdgeGateway{Name: faultsEdgeName})
+			if assert.NoError(t, err) {
+				assert.Equal(t, faultsEdgeName, edge.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, mC.CallCount("QueryEdgeGateway"))
+	assert.Equal(t, 1, mC.CallCount("GetEdgeGateway"))
+}
+
+func TestClient_ResponseCacheCommands(t *testing.T) {
+	sim, err := mock.NewSimulator(mock.WithFixture(faultsFixture))
+	require.NoError(t, err)
+
+	mC := mock.NewTestClient(t,
+		mock.WithSimulator(sim),
+		mock.WithClientOptions(cav.WithResponseCache(time.Minute)),
+	)
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	edge, err := eC.GetEdgeGateway(t.Context(), types.ParamsEdgeGateway{Name: faultsEdgeName})
+	require.NoError(t, err)
+
+	// The second command is answered from the cache, including with job options in the context.
+	ctx := cav.ContextWithRequestOptions(t.Context(), cav.WithJobProgress(func(*cav.Job) {}))
+	_, err = eC.GetEdgeGateway(ctx, types.ParamsEdgeGateway{ID: edge.ID})
+	require.NoError(t, err)
+	_, err = eC.GetEdgeGateway(ctx, types.ParamsEdgeGateway{ID: edge.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, mC.CallCount("GetEdgeGateway"))
+
+	// The update removes the edge gateway from the cache, the model returned is read again.
+	_, err = eC.UpdateEdgeGateway(t.Context(), types.ParamsUpdateEdgeGateway{ID: edge.ID, Bandwidth: 25})
+	require.NoError(t, err)
+	assert.Equal(t, 2, mC.CallCount("GetEdgeGateway"))
+
+	// The model read after the update is cached.
+	_, err = eC.GetEdgeGateway(t.Context(), types.ParamsEdgeGateway{ID: edge.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, mC.CallCount("GetEdgeGateway"))
+}
+
+func TestClient_DryRunCommands(t *testing.T) {
+	sim, err := mock.NewSimulator(mock.WithFixture(faultsFixture))
+	require.NoError(t, err)
+
+	mC := mock.NewTestClient(t,
+		mock.WithSimulator(sim),
+		mock.WithClientOptions(cav.WithDryRun()),
+	)
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	// With a bandwidth, the command creates the edge gateway then updates its bandwidth.
+	_, err = eC.CreateEdgeGateway(t.Context(), types.ParamsCreateEdgeGateway{OwnerName: "vdc-a", Bandwidth: 25})
+
+	var planned *cav.PlannedRequest
+	require.ErrorAs(t, err, &planned)
+	assert.Equal(t, "CreateEdgeGateway", planned.Endpoint)
+
+	// The command stops at the creation, the update of the bandwidth is not planned.
+	plan := mC.PlannedRequests()
+	require.Len(t, plan, 1)
+	assert.Equal(t, "CreateEdgeGateway", plan[0].Endpoint)
+	assert.True(t, plan[0].ExpectJob)
+
+	mC.AssertNotCalled(t, "CreateEdgeGateway")
+	mC.AssertNotCalled(t, "UpdateEdgeGatewayBandwidth")
+}
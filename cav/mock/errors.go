@@ -0,0 +1,148 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+// The error bodies of the APIs, to use as the data of a mock response with a status code >= 300.
+//
+// Example:
+//
+//	status := http.StatusNotFound
+//	mC.SetMockResponse(ep, &mock.VmwareError{
+//		MajorErrorCode: status,
+//		MinorErrorCode: "NOT_FOUND",
+//		Message:        "[ 0c8b1e3d ] The VDC group has not been found.",
+//	}, &status)
+type (
+	// VmwareError is the error body of the VMware Cloud Director API.
+	VmwareError struct {
+		MajorErrorCode int    `json:"majorErrorCode"`
+		MinorErrorCode string `json:"minorErrorCode"`
+		Message        string `json:"message"`
+	}
+
+	// CerberusError is the error body of the Cerberus API.
+	CerberusError struct {
+		Code    string `json:"code"`
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	}
+
+	// NetbackupError is the error body of the Netbackup API.
+	NetbackupError struct {
+		Message       string `json:"Message"`
+		MessageDetail string `json:"MessageDetail,omitempty"`
+	}
+
+	// S3Error is the error body of the S3 API, encoded in XML.
+	S3Error struct {
+		XMLName   xml.Name `xml:"Error"`
+		Code      string   `xml:"Code"`
+		Message   string   `xml:"Message"`
+		Resource  string   `xml:"Resource,omitempty"`
+		RequestID string   `xml:"RequestId,omitempty"`
+	}
+)
+
+// ErrorBody returns the error body sent by the API of the endpoint with the status code and the message.
+// The vendor error code is derived from the status code, e.g. NOT_FOUND for VMware or cf-0404 for Cerberus.
+func ErrorBody(ep *cav.Endpoint, status int, message string) any {
+	switch ep.SubClient {
+	case cav.ClientCerberus:
+		return &CerberusError{
+			Code:    fmt.Sprintf("cf-%04d", status),
+			Reason:  http.StatusText(status),
+			Message: message,
+		}
+	case cav.ClientNetbackup:
+		return &NetbackupError{
+			Message:       http.StatusText(status),
+			MessageDetail: message,
+		}
+	case cav.ClientS3:
+		return &S3Error{
+			Code:      strings.ReplaceAll(http.StatusText(status), " ", ""),
+			Message:   message,
+			RequestID: generator.MustGenerate("{uuid}"),
+		}
+	default:
+		return &VmwareError{
+			MajorErrorCode: status,
+			MinorErrorCode: vmwareErrorCode(status),
+			Message:        fmt.Sprintf("[ %s ] %s", generator.MustGenerate("{uuid}"), message),
+		}
+	}
+}
+
+// BusyEntityErrorBody returns the error body sent with a 409 by the API of the endpoint when
+// another operation is running on the entity. The SDK retries the mutating requests
+// receiving this error.
+func BusyEntityErrorBody(ep *cav.Endpoint) any {
+	switch ep.SubClient {
+	case cav.ClientCerberus:
+		return &CerberusError{
+			Code:    "cf-0002",
+			Reason:  "Job already exists",
+			Message: "another job present on org " + mockOrg,
+		}
+	case cav.ClientNetbackup:
+		return &NetbackupError{
+			Message: "Another operation is already in progress on this machine.",
+		}
+	case cav.ClientS3:
+		return &S3Error{
+			Code:      "OperationAborted",
+			Message:   "A conflicting conditional operation is currently in progress against this resource.",
+			RequestID: generator.MustGenerate("{uuid}"),
+		}
+	default:
+		return &VmwareError{
+			MajorErrorCode: http.StatusConflict,
+			MinorErrorCode: "BUSY_ENTITY",
+			Message:        fmt.Sprintf("[ %s ] The entity is busy completing an operation.", generator.MustGenerate("{uuid}")),
+		}
+	}
+}
+
+// vmwareErrorCode returns the minorErrorCode sent by VMware Cloud Director with the status code.
+func vmwareErrorCode(status int) string {
+	if status == http.StatusForbidden {
+		return "ACCESS_TO_RESOURCE_IS_FORBIDDEN"
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+// writeErrorBody writes the error body with the status code,
+// encoded in XML for the S3 API and in JSON for the other APIs.
+func writeErrorBody(w http.ResponseWriter, ep *cav.Endpoint, status int, body any) {
+	if ep.SubClient != cav.ClientS3 {
+		writeJSON(w, status, body)
+		return
+	}
+
+	bodyEncoded, err := xml.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write(bodyEncoded) //nolint:errcheck
+}
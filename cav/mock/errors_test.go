@@ -0,0 +1,91 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav/mock"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/endpoints"
+	cerrors "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+)
+
+func TestErrors_DefaultBody(t *testing.T) {
+	t.Parallel()
+
+	mC := mock.NewTestClient(t)
+	status := http.StatusNotFound
+	var apiErr *cerrors.APIError
+
+	// VMware
+	ep := endpoints.GetOrganizationDetails()
+	mC.SetMockResponse(ep, nil, &status)
+	_, err := mC.Do(t.Context(), ep)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Contains(t, apiErr.Message, "Not Found")
+	assert.NotEqual(t, "Unknown error occurred", apiErr.Message)
+
+	// Cerberus
+	ep = endpoints.GetOrganization()
+	mC.SetMockResponse(ep, nil, &status)
+	_, err = mC.Do(t.Context(), ep)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Not Found: The request GetOrganization failed", apiErr.Message)
+}
+
+func TestErrors_CustomBody(t *testing.T) {
+	t.Parallel()
+
+	mC := mock.NewTestClient(t)
+	status := http.StatusBadRequest
+	var apiErr *cerrors.APIError
+
+	ep := endpoints.GetOrganizationDetails()
+	mC.SetMockResponse(ep, &mock.VmwareError{
+		MajorErrorCode: status,
+		MinorErrorCode: "BAD_REQUEST",
+		Message:        "[ 0c8b1e3d ] The filter is invalid.",
+	}, &status)
+	_, err := mC.Do(t.Context(), ep)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "[ 0c8b1e3d ] The filter is invalid.", apiErr.Message)
+
+	ep = endpoints.GetOrganization()
+	mC.SetMockResponse(ep, mock.ErrorBody(ep, status, "The configuration is invalid"), &status)
+	_, err = mC.Do(t.Context(), ep)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Bad Request: The configuration is invalid", apiErr.Message)
+}
+
+func TestErrors_BusyEntityBody(t *testing.T) {
+	t.Parallel()
+
+	mC := mock.NewTestClient(t)
+	status := http.StatusConflict
+
+	// The request is retried when the entity is busy, then the default response is returned.
+	ep := endpoints.UpdateOrganization()
+	mC.SetMockResponse(ep, mock.BusyEntityErrorBody(ep), &status)
+	_, err := mC.Do(t.Context(), ep)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mC.CallCount(ep.Name))
+
+	// The other conflicts are not retried.
+	mC.ResetCalls()
+	mC.SetMockResponse(ep, mock.ErrorBody(ep, status, "The name is already used"), &status)
+	_, err = mC.Do(t.Context(), ep)
+	require.Error(t, err)
+	assert.Equal(t, 1, mC.CallCount(ep.Name))
+}
@@ -0,0 +1,407 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+type (
+	// Fault configures a failure injected by the mock server in the responses of an endpoint.
+	// See WithFaults.
+	Fault func(*endpointFaults) error
+
+	// endpointFaults holds the faults of an endpoint and the number of injections left.
+	endpointFaults struct {
+		mu sync.Mutex
+
+		latency                func() time.Duration
+		serverErrorRate        float64
+		busyAttempts           int
+		sessionExpiredAttempts int
+		job                    faultJobOutcome
+		jobMessage             string
+	}
+
+	// faultJobOutcome is the end of the jobs created by an endpoint with a job fault.
+	faultJobOutcome int
+
+	// faultJobs holds the jobs created by the endpoints with a job fault, by job ID.
+	// The polls of these jobs are answered instead of the handlers of the job endpoints.
+	faultJobs struct {
+		mu   sync.Mutex
+		jobs map[string]*faultJob
+	}
+
+	faultJob struct {
+		kind      simTaskKind
+		operation string
+		outcome   faultJobOutcome
+		message   string
+		polls     int
+	}
+)
+
+const (
+	faultJobNone faultJobOutcome = iota
+	faultJobError
+	faultJobAborted
+	faultJobNeverEnds
+)
+
+// serverErrorStatuses are the status codes of the server errors injected by FaultServerErrors.
+var serverErrorStatuses = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// WithFaults injects the faults in the responses of the endpoint, without changing the
+// shared endpoint definition. The faults are applied in this order: latency, session expiry,
+// busy entity, server errors, then the job faults if the request is accepted.
+//
+// Example:
+//
+//	mC, err := mock.NewClient(
+//		mock.WithFaults("UpdateVdcGroup", mock.FaultBusyEntity(2), mock.FaultJobError("disk full")),
+//		mock.WithFaults("GetVdc", mock.FaultLatency(10*time.Millisecond, 50*time.Millisecond)),
+//		mock.WithFaults("ListVdc", mock.FaultLogNormalLatency(20*time.Millisecond, 0.5, time.Second)),
+//	)
+func WithFaults(endpointName string, faults ...Fault) OptionFunc {
+	return func(c *Options) error {
+		if _, err := cav.GetEndpoint(endpointName); err != nil {
+			return fmt.Errorf("cannot inject faults: %w", err)
+		}
+
+		for _, f := range faults {
+			if f == nil {
+				return fmt.Errorf("cannot inject a nil fault in endpoint %s", endpointName)
+			}
+		}
+
+		if c.faults == nil {
+			c.faults = map[string][]Fault{}
+		}
+		c.faults[endpointName] = append(c.faults[endpointName], faults...)
+		return nil
+	}
+}
+
+// FaultLatency delays each response by a random duration between min and max (uniform distribution).
+func FaultLatency(minLatency, maxLatency time.Duration) Fault {
+	return func(f *endpointFaults) error {
+		if minLatency < 0 || maxLatency < minLatency {
+			return fmt.Errorf("invalid latency range [%s, %s]", minLatency, maxLatency)
+		}
+
+		f.latency = func() time.Duration {
+			return minLatency + rand.N(maxLatency-minLatency+1) //nolint:gosec // The latency does not need a secure random.
+		}
+		return nil
+	}
+}
+
+// FaultFixedLatency delays each response by the same duration.
+func FaultFixedLatency(latency time.Duration) Fault {
+	return func(f *endpointFaults) error {
+		if latency < 0 {
+			return fmt.Errorf("invalid latency %s", latency)
+		}
+
+		f.latency = func() time.Duration { return latency }
+		return nil
+	}
+}
+
+// FaultLogNormalLatency delays each response by a random duration following a log-normal
+// distribution, the usual shape of the response times of an API: most of the responses
+// are close to the median and a few of them are much slower (long tail).
+// sigma is the standard deviation of the logarithm of the latency, e.g. with 0.5 about
+// 1 response out of 12 is more than twice the median. The latency is capped at maxLatency.
+func FaultLogNormalLatency(median time.Duration, sigma float64, maxLatency time.Duration) Fault {
+	return func(f *endpointFaults) error {
+		if median <= 0 || sigma < 0 || maxLatency < median {
+			return fmt.Errorf("invalid log-normal latency (median %s, sigma %g, max %s)", median, sigma, maxLatency)
+		}
+
+		f.latency = func() time.Duration {
+			latency := float64(median) * math.Exp(sigma*rand.NormFloat64()) //nolint:gosec // The latency does not need a secure random.
+			return time.Duration(min(latency, float64(maxLatency)))
+		}
+		return nil
+	}
+}
+
+// FaultLatencyFunc delays each response by the duration returned by fn.
+// Use it to simulate another distribution than FaultLatency, FaultFixedLatency and FaultLogNormalLatency.
+func FaultLatencyFunc(fn func() time.Duration) Fault {
+	return func(f *endpointFaults) error {
+		if fn == nil {
+			return errors.New("the latency function cannot be nil")
+		}
+
+		f.latency = fn
+		return nil
+	}
+}
+
+// FaultServerErrors answers a random server error (500, 502, 503 or 504) with the
+// given probability, between 0 and 1.
+func FaultServerErrors(rate float64) Fault {
+	return func(f *endpointFaults) error {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid server error rate %v, must be between 0 and 1", rate)
+		}
+
+		f.serverErrorRate = rate
+		return nil
+	}
+}
+
+// FaultBusyEntity answers the first attempts with the 409 busy entity error of the API
+// (BUSY_ENTITY on VMware, "Job already exists" on Cerberus), see BusyEntityErrorBody.
+func FaultBusyEntity(attempts int) Fault {
+	return func(f *endpointFaults) error {
+		if attempts <= 0 {
+			return errors.New("the number of busy attempts must be positive")
+		}
+
+		f.busyAttempts = attempts
+		return nil
+	}
+}
+
+// FaultSessionExpired answers the first attempts with a 401 as if the session had expired.
+func FaultSessionExpired(attempts int) Fault {
+	return func(f *endpointFaults) error {
+		if attempts <= 0 {
+			return errors.New("the number of session expired attempts must be positive")
+		}
+
+		f.sessionExpiredAttempts = attempts
+		return nil
+	}
+}
+
+// FaultJobError makes the jobs created by the endpoint end in error with the message.
+// The request creating the job is not handled by the simulator, nothing is changed.
+func FaultJobError(message string) Fault {
+	return func(f *endpointFaults) error {
+		f.job = faultJobError
+		f.jobMessage = defaultString(message, "The job failed")
+		return nil
+	}
+}
+
+// FaultJobAborted makes the jobs created by the endpoint end aborted.
+// Cerberus has no aborted status, the job fails with an aborted message.
+func FaultJobAborted() Fault {
+	return func(f *endpointFaults) error {
+		f.job = faultJobAborted
+		f.jobMessage = "The job was aborted"
+		return nil
+	}
+}
+
+// FaultJobNeverEnds makes the jobs created by the endpoint run forever.
+// The call ends with the timeout of the job or of the request.
+func FaultJobNeverEnds() Fault {
+	return func(f *endpointFaults) error {
+		f.job = faultJobNeverEnds
+		return nil
+	}
+}
+
+// newEndpointFaults applies the faults of the endpoint.
+func newEndpointFaults(ep *cav.Endpoint, faults []Fault) (*endpointFaults, error) {
+	f := &endpointFaults{}
+	for _, fault := range faults {
+		if err := fault(f); err != nil {
+			return nil, fmt.Errorf("invalid fault for endpoint %s: %w", ep.Name, err)
+		}
+	}
+
+	if f.job != faultJobNone {
+		if _, ok := ep.BodyResponseType.(cav.Job); !ok {
+			return nil, fmt.Errorf("invalid fault for endpoint %s: the endpoint does not create a job", ep.Name)
+		}
+		if ep.SubClient != cav.ClientCerberus && ep.SubClient != cav.ClientVmware {
+			return nil, fmt.Errorf("invalid fault for endpoint %s: the jobs of %s are not supported", ep.Name, ep.SubClient)
+		}
+	}
+
+	return f, nil
+}
+
+// consume decrements the number of injections left and reports whether the fault is injected.
+func consume(attempts *int) bool {
+	if *attempts <= 0 {
+		return false
+	}
+	*attempts--
+	return true
+}
+
+// handler wraps the handler of the endpoint to inject the faults.
+func (f *endpointFaults) handler(ep *cav.Endpoint, jobs *faultJobs, next http.HandlerFunc) http.HandlerFunc {
+	jobKind := cerberusJob
+	if ep.SubClient == cav.ClientVmware {
+		jobKind = vmwareTask
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if f.latency != nil {
+			select {
+			case <-time.After(f.latency()):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		f.mu.Lock()
+		var (
+			status int
+			body   any
+		)
+		switch {
+		case consume(&f.sessionExpiredAttempts):
+			status = http.StatusUnauthorized
+			body = ErrorBody(ep, status, "The session has expired or is invalid")
+		case consume(&f.busyAttempts):
+			status = http.StatusConflict
+			body = BusyEntityErrorBody(ep)
+		case f.serverErrorRate > 0 && rand.Float64() < f.serverErrorRate: //nolint:gosec // The faults do not need a secure random.
+			status = serverErrorStatuses[rand.N(len(serverErrorStatuses))] //nolint:gosec // The faults do not need a secure random.
+			body = ErrorBody(ep, status, http.StatusText(status))
+		}
+		f.mu.Unlock()
+
+		if body != nil {
+			logger.Debug("Fault injected", slog.String("endpoint", ep.Name), slog.Int("status", status))
+			writeErrorBody(w, ep, status, body)
+			return
+		}
+
+		if f.job != faultJobNone {
+			id := jobs.add(jobKind, ep.Name, f.job, f.jobMessage)
+			logger.Debug("Faulty job created", slog.String("endpoint", ep.Name), slog.String("id", id))
+			writeJobCreated(w, r, jobKind, id, ep.Name)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func newFaultJobs() *faultJobs {
+	return &faultJobs{jobs: map[string]*faultJob{}}
+}
+
+// add registers a job ending with the outcome and returns its ID.
+func (j *faultJobs) add(kind simTaskKind, operation string, outcome faultJobOutcome, message string) string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	id := generator.MustGenerate("{uuid}")
+	j.jobs[id] = &faultJob{
+		kind:      kind,
+		operation: operation,
+		outcome:   outcome,
+		message:   message,
+	}
+	return id
+}
+
+// handler wraps the handler of a job endpoint to answer the polls of the faulty jobs.
+func (j *faultJobs) handler(kind simTaskKind, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		j.mu.Lock()
+		job, ok := j.jobs[chi.URLParam(r, "taskId")]
+		if ok {
+			job.polls++
+		}
+		j.mu.Unlock()
+
+		if !ok || job.kind != kind {
+			next(w, r)
+			return
+		}
+
+		if kind == vmwareTask {
+			writeJSON(w, http.StatusOK, job.vmwareResponse(r, chi.URLParam(r, "taskId")))
+			return
+		}
+		writeJSON(w, http.StatusOK, job.cerberusResponse())
+	}
+}
+
+func (job *faultJob) cerberusResponse() cav.CerberusJobAPIResponse {
+	status, description := "FAILED", job.message
+	if job.outcome == faultJobNeverEnds {
+		status, description = "IN_PROGRESS", "Job "+job.operation+" in progress"
+	}
+
+	return cav.CerberusJobAPIResponse{
+		{
+			Actions: []cav.CerberusJobAPIResponseAction{
+				{Name: job.operation, Status: status, Details: job.message},
+			},
+			Description: description,
+			Name:        job.operation,
+			Status:      status,
+		},
+	}
+}
+
+func (job *faultJob) vmwareResponse(r *http.Request, id string) vmwareTaskResponse {
+	resp := vmwareTaskResponse{
+		HREF:          baseURL(r) + "/api/task/" + id,
+		ID:            "urn:vcloud:task:" + id,
+		Name:          "task",
+		Operation:     job.operation,
+		OperationName: job.operation,
+	}
+
+	switch job.outcome {
+	case faultJobError:
+		resp.Status = "error"
+		resp.Error = &vmwareTaskFailure{
+			Message:        job.message,
+			MajorErrorCode: http.StatusInternalServerError,
+			MinorErrorCode: "INTERNAL_SERVER_ERROR",
+		}
+	case faultJobAborted:
+		resp.Status = "aborted"
+		// The cause of the abort is reported in the error of the task.
+		resp.Error = &vmwareTaskFailure{
+			Message:        job.message,
+			MajorErrorCode: http.StatusInternalServerError,
+			MinorErrorCode: "INTERNAL_SERVER_ERROR",
+		}
+	case faultJobNeverEnds:
+		resp.Status = "running"
+		resp.Progress = min(job.polls, 99)
+	}
+
+	return resp
+}
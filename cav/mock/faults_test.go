@@ -0,0 +1,266 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	edgegateway "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/edgegateway/v1"
+	vdcgroup "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/vdcgroup/v1"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav/mock"
+	cerrors "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+const faultsEdgeName = "tn01e02ocb0001234spt101"
+
+var faultsFixture = mock.Fixture{
+	Vdcs: []mock.FixtureVdc{{Name: "vdc-a"}, {Name: "vdc-b"}},
+	VdcGroups: []mock.FixtureVdcGroup{
+		{Name: "group-a", Vdcs: []string{"vdc-a"}},
+	},
+	EdgeGateways: []mock.FixtureEdgeGateway{
+		{Name: faultsEdgeName, Owner: "vdc-b"},
+	},
+}
+
+// newFaultsClient returns a mock client backed by a simulator seeded with faultsFixture.
+func newFaultsClient(t *testing.T, opts ...mock.OptionFunc) (*mock.Simulator, cav.Client) {
+	t.Helper()
+
+	sim, err := mock.NewSimulator(mock.WithFixture(faultsFixture))
+	require.NoError(t, err)
+
+	mC, err := mock.NewClient(append(opts, mock.WithSimulator(sim))...)
+	require.NoError(t, err)
+
+	return sim, mC
+}
+
+func TestFaults_BusyEntity(t *testing.T) {
+	sim, mC := newFaultsClient(t,
+		mock.WithFaults("UpdateEdgeGatewayBandwidth", mock.FaultBusyEntity(2)),
+		mock.WithFaults("UpdateVdcGroup", mock.FaultBusyEntity(2)),
+	)
+	ctx := context.Background()
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	// Cerberus
+	_, err = eC.UpdateEdgeGateway(ctx, types.ParamsUpdateEdgeGateway{Name: faultsEdgeName, Bandwidth: 25})
+	require.NoError(t, err)
+
+	// VMware
+	gC, err := vdcgroup.New(mC)
+	require.NoError(t, err)
+
+	_, err = gC.UpdateVdcGroup(ctx, types.ParamsUpdateVdcGroup{Name: "group-a"})
+	require.NoError(t, err)
+
+	// The busy attempts are rejected before reaching the simulator.
+	assert.Len(t, sim.Tasks(), 2)
+}
+
+func TestFaults_ErrorKinds(t *testing.T) {
+	_, mC := newFaultsClient(t,
+		mock.WithFaults("UpdateEdgeGatewayBandwidth", mock.FaultBusyEntity(1)),
+		mock.WithFaults("UpdateVdcGroup", mock.FaultBusyEntity(1)),
+	)
+	ctx := cav.ContextWithRequestOptions(context.Background(), cav.WithoutRetry())
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+	gC, err := vdcgroup.New(mC)
+	require.NoError(t, err)
+
+	// The busy entity is reported with the code of the API.
+	_, err = eC.UpdateEdgeGateway(ctx, types.ParamsUpdateEdgeGateway{Name: faultsEdgeName, Bandwidth: 25})
+	require.ErrorIs(t, err, cerrors.ErrBusyEntity)
+	var apiErr *cerrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "cf-0002", apiErr.Code)
+	assert.Equal(t, "Job already exists", apiErr.Reason)
+
+	_, err = gC.UpdateVdcGroup(ctx, types.ParamsUpdateVdcGroup{Name: "group-a"})
+	require.ErrorIs(t, err, cerrors.ErrBusyEntity)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "BUSY_ENTITY", apiErr.Code)
+
+	_, err = gC.GetVdcGroup(ctx, types.ParamsGetVdcGroup{Name: "group-unknown"})
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	_, err = gC.CreateVdcGroup(ctx, types.ParamsCreateVdcGroup{Name: "group-a", Vdcs: []types.ParamsCreateVdcGroupVdc{{Name: "vdc-b"}}})
+	require.ErrorIs(t, err, cerrors.ErrConflict)
+
+	_, err = gC.GetVdcGroup(ctx, types.ParamsGetVdcGroup{})
+	require.ErrorIs(t, err, cerrors.ErrValidation)
+}
+
+func TestFaults_SessionExpired(t *testing.T) {
+	_, mC := newFaultsClient(t, mock.WithFaults("QueryEdgeGateway", mock.FaultSessionExpired(1)))
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	// The credential is renewed and the request replayed.
+	edge, err := eC.GetEdgeGateway(context.Background(), types.ParamsEdgeGateway{Name: faultsEdgeName})
+	require.NoError(t, err)
+	assert.Equal(t, faultsEdgeName, edge.Name)
+}
+
+func TestFaults_ServerErrors(t *testing.T) {
+	_, mC := newFaultsClient(t, mock.WithFaults("ListEdgeGateway", mock.FaultServerErrors(1)))
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	_, err = eC.ListEdgeGateway(context.Background())
+	require.Error(t, err)
+
+	var apiErr *cerrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.GreaterOrEqual(t, apiErr.StatusCode, http.StatusInternalServerError)
+}
+
+func TestFaults_Latency(t *testing.T) {
+	tests := []struct {
+		name       string
+		fault      mock.Fault
+		minLatency time.Duration
+	}{
+		{
+			name:       "Uniform",
+			fault:      mock.FaultLatency(50*time.Millisecond, 60*time.Millisecond),
+			minLatency: 50 * time.Millisecond,
+		},
+		{
+			name:       "Fixed",
+			fault:      mock.FaultFixedLatency(50 * time.Millisecond),
+			minLatency: 50 * time.Millisecond,
+		},
+		{
+			// Without deviation, the latency is the median.
+			name:       "Log-normal",
+			fault:      mock.FaultLogNormalLatency(50*time.Millisecond, 0, time.Second),
+			minLatency: 50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, mC := newFaultsClient(t, mock.WithFaults("GetEdgeGateway", tt.fault))
+
+			eC, err := edgegateway.New(mC)
+			require.NoError(t, err)
+
+			start := time.Now()
+			_, err = eC.GetEdgeGateway(context.Background(), types.ParamsEdgeGateway{Name: faultsEdgeName})
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, time.Since(start), tt.minLatency)
+		})
+	}
+}
+
+func TestFaults_Jobs(t *testing.T) {
+	t.Run("Cerberus job in error", func(t *testing.T) {
+		sim, mC := newFaultsClient(t, mock.WithFaults("UpdateEdgeGatewayBandwidth", mock.FaultJobError("not enough bandwidth")))
+
+		eC, err := edgegateway.New(mC)
+		require.NoError(t, err)
+
+		_, err = eC.UpdateEdgeGateway(context.Background(), types.ParamsUpdateEdgeGateway{Name: faultsEdgeName, Bandwidth: 25})
+		assert.ErrorContains(t, err, "not enough bandwidth")
+
+		// The simulator has not handled the request.
+		assert.Empty(t, sim.Tasks())
+	})
+
+	t.Run("VMware task aborted", func(t *testing.T) {
+		_, mC := newFaultsClient(t, mock.WithFaults("DeleteVdcGroup", mock.FaultJobAborted()))
+
+		gC, err := vdcgroup.New(mC)
+		require.NoError(t, err)
+
+		err = gC.DeleteVdcGroup(context.Background(), types.ParamsDeleteVdcGroup{Name: "group-a"})
+		assert.ErrorContains(t, err, "aborted")
+
+		_, err = gC.GetVdcGroup(context.Background(), types.ParamsGetVdcGroup{Name: "group-a"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Job never ends", func(t *testing.T) {
+		_, mC := newFaultsClient(t, mock.WithFaults("UpdateVdcGroup", mock.FaultJobNeverEnds()))
+
+		gC, err := vdcgroup.New(mC)
+		require.NoError(t, err)
+
+		ctx := cav.ContextWithRequestOptions(context.Background(), cav.WithRequestTimeout(1500*time.Millisecond))
+		_, err = gC.UpdateVdcGroup(ctx, types.ParamsUpdateVdcGroup{Name: "group-a"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// The error holds the last known state of the job.
+		var timeoutErr *cav.JobTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.NotNil(t, timeoutErr.Job)
+		assert.Equal(t, cav.JobRunning, timeoutErr.Job.Status)
+		assert.ErrorIs(t, err, cerrors.ErrTimeout)
+	})
+}
+
+func TestFaults_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  mock.OptionFunc
+	}{
+		{
+			name: "Unknown endpoint",
+			opt:  mock.WithFaults("UnknownEndpoint", mock.FaultBusyEntity(1)),
+		},
+		{
+			name: "Nil fault",
+			opt:  mock.WithFaults("GetVdc", nil),
+		},
+		{
+			name: "Invalid server error rate",
+			opt:  mock.WithFaults("GetVdc", mock.FaultServerErrors(1.5)),
+		},
+		{
+			name: "Invalid latency range",
+			opt:  mock.WithFaults("GetVdc", mock.FaultLatency(time.Second, time.Millisecond)),
+		},
+		{
+			name: "Negative fixed latency",
+			opt:  mock.WithFaults("GetVdc", mock.FaultFixedLatency(-time.Second)),
+		},
+		{
+			name: "Invalid log-normal latency",
+			opt:  mock.WithFaults("GetVdc", mock.FaultLogNormalLatency(time.Second, 0.5, time.Millisecond)),
+		},
+		{
+			name: "Job fault on an endpoint without job",
+			opt:  mock.WithFaults("GetVdc", mock.FaultJobError("")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mock.NewClient(tt.opt)
+			assert.Error(t, err)
+		})
+	}
+}
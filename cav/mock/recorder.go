@@ -0,0 +1,188 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+)
+
+type (
+	// Call is a request received by the mock server of a client.
+	Call struct {
+		// Endpoint is the name of the endpoint called.
+		Endpoint string
+		Method   string
+		// PathParams are the values of the path parameters, by name.
+		PathParams map[string]string
+		Query      url.Values
+		Header     http.Header
+		// Body is the raw body of the request, use DecodeBody to decode it.
+		Body []byte
+	}
+
+	// recorder keeps the requests received by the mock server of a client, in order.
+	recorder struct {
+		mu    sync.Mutex
+		calls []Call
+	}
+)
+
+// DecodeBody decodes the body of the request into v.
+// The body is decoded from XML if the request has an XML content type, from JSON otherwise.
+func (c Call) DecodeBody(v any) error {
+	if len(c.Body) == 0 {
+		return fmt.Errorf("the request of %s has no body", c.Endpoint)
+	}
+
+	if strings.Contains(c.Header.Get("Content-Type"), "xml") {
+		return xml.Unmarshal(c.Body, v)
+	}
+	return json.Unmarshal(c.Body, v)
+}
+
+// handler wraps the handler of the endpoint to record the requests before answering them.
+func (rec *recorder) handler(ep *cav.Endpoint, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		call := Call{
+			Endpoint:   ep.Name,
+			Method:     r.Method,
+			PathParams: map[string]string{},
+			Query:      r.URL.Query(),
+			Header:     r.Header.Clone(),
+		}
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			for i, key := range rctx.URLParams.Keys {
+				call.PathParams[key] = rctx.URLParams.Values[i]
+			}
+		}
+
+		if r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "failed to read the request body", http.StatusBadRequest)
+				return
+			}
+			call.Body = body
+			// The handler of the endpoint reads the body again.
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		rec.mu.Lock()
+		rec.calls = append(rec.calls, call)
+		rec.mu.Unlock()
+
+		next(w, r)
+	}
+}
+
+// Calls returns the requests received by the mock server, in order.
+func (c *Client) Calls() []Call {
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+
+	calls := make([]Call, len(c.recorder.calls))
+	copy(calls, c.recorder.calls)
+	return calls
+}
+
+// CallsTo returns the requests of the endpoint received by the mock server, in order.
+func (c *Client) CallsTo(endpointName string) []Call {
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+
+	var calls []Call
+	for _, call := range c.recorder.calls {
+		if call.Endpoint == endpointName {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CallCount returns the number of requests of the endpoint received by the mock server.
+// The retries and the job polls are counted as distinct requests.
+func (c *Client) CallCount(endpointName string) int {
+	return len(c.CallsTo(endpointName))
+}
+
+// LastCall returns the last request of the endpoint received by the mock server.
+func (c *Client) LastCall(endpointName string) (Call, bool) {
+	calls := c.CallsTo(endpointName)
+	if len(calls) == 0 {
+		return Call{}, false
+	}
+	return calls[len(calls)-1], true
+}
+
+// LastBody decodes the body of the last request of the endpoint into v.
+func (c *Client) LastBody(endpointName string, v any) error {
+	call, ok := c.LastCall(endpointName)
+	if !ok {
+		return fmt.Errorf("the endpoint %s has not been called", endpointName)
+	}
+	return call.DecodeBody(v)
+}
+
+// ResetCalls forgets the requests received by the mock server.
+func (c *Client) ResetCalls() {
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+
+	c.recorder.calls = nil
+}
+
+// AssertCalled asserts that the endpoint has been called at least once.
+func (c *Client) AssertCalled(t testing.TB, endpointName string) bool {
+	t.Helper()
+
+	if c.CallCount(endpointName) == 0 {
+		t.Errorf("expected the endpoint %s to be called, called endpoints: %v", endpointName, c.calledEndpoints())
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled asserts that the endpoint has not been called.
+func (c *Client) AssertNotCalled(t testing.TB, endpointName string) bool {
+	t.Helper()
+
+	if n := c.CallCount(endpointName); n > 0 {
+		t.Errorf("expected the endpoint %s not to be called, called %d times", endpointName, n)
+		return false
+	}
+	return true
+}
+
+// calledEndpoints returns the names of the endpoints called, in the order of their first call.
+func (c *Client) calledEndpoints() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, call := range c.Calls() {
+		if !seen[call.Endpoint] {
+			seen[call.Endpoint] = true
+			names = append(names, call.Endpoint)
+		}
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	edgegateway "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/edgegateway/v1"
+	vdc "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/vdc/v1"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav/mock"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+func newRecordedClient(t *testing.T) *mock.Client {
+	t.Helper()
+
+	sim, err := mock.NewSimulator(mock.WithFixture(faultsFixture))
+	require.NoError(t, err)
+
+	return mock.NewTestClient(t, mock.WithSimulator(sim))
+}
+
+func TestRecorder_UpdateVDCBody(t *testing.T) {
+	t.Parallel()
+
+	mC := newRecordedClient(t)
+	vC, err := vdc.New(mC)
+	require.NoError(t, err)
+
+	mC.AssertNotCalled(t, "UpdateVdc")
+
+	description := "Updated by the test"
+	vcpu := 10
+	_, err = vC.UpdateVDC(t.Context(), types.ParamsUpdateVDC{Name: "vdc-a", Description: &description, Vcpu: &vcpu})
+	require.NoError(t, err)
+
+	mC.AssertCalled(t, "UpdateVdc")
+	assert.Equal(t, 1, mC.CallCount("UpdateVdc"))
+
+	call, ok := mC.LastCall("UpdateVdc")
+	require.True(t, ok)
+	assert.Equal(t, http.MethodPut, call.Method)
+	assert.Equal(t, "vdc-a", call.PathParams["vdc-name"])
+
+	var body itypes.ApiRequestUpdateVDC
+	require.NoError(t, mC.LastBody("UpdateVdc", &body))
+	assert.Equal(t, "vdc-a", body.VDC.Name)
+	assert.Equal(t, description, body.VDC.Description)
+	assert.NotZero(t, body.VDC.CPUAllocated)
+}
+
+func TestRecorder_CreateEdgeGatewayQueries(t *testing.T) {
+	t.Parallel()
+
+	mC := newRecordedClient(t)
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	_, err = eC.CreateEdgeGateway(t.Context(), types.ParamsCreateEdgeGateway{OwnerName: "group-a"})
+	require.NoError(t, err)
+
+	mC.AssertCalled(t, "ListVdcGroup")
+	call, ok := mC.LastCall("ListVdcGroup")
+	require.True(t, ok)
+	assert.Contains(t, call.Query.Get("filter"), "name==group-a")
+
+	// The job of the creation is polled until it is done.
+	mC.AssertCalled(t, "CreateEdgeGateway")
+	mC.AssertCalled(t, "GetJobCerberus")
+
+	mC.ResetCalls()
+	assert.Empty(t, mC.Calls())
+	assert.Error(t, mC.LastBody("CreateEdgeGateway", &struct{}{}))
+}
@@ -0,0 +1,248 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+type (
+	// Simulator is a stateful in-memory backend for the mock client.
+	// It stores the VDCs, the VDC groups, the edge gateways with their public IPs,
+	// the storage profiles and the organization settings, so a resource created by a
+	// command is returned by the next ones. As with the real API, the mutations are
+	// accepted synchronously and applied when their Cerberus job or VMware task completes.
+	//
+	// The endpoints not handled by the simulator keep the default mock responses.
+	// See WithSimulator.
+	Simulator struct {
+		mu sync.Mutex
+
+		// runningPolls is the number of polls of a job answered with a running status.
+		runningPolls int
+
+		org          *simOrg
+		t0s          []*simT0
+		vdcs         []*simVdc
+		vdcGroups    []*simVdcGroup
+		edgeGateways []*simEdgeGateway
+
+		tasks     map[string]*simTask
+		taskOrder []string
+	}
+
+	// SimulatorOptionFunc is a function to configure the simulator.
+	SimulatorOptionFunc func(*Simulator) error
+
+	// simHandlerFunc handles a request of an endpoint with the lock of the simulator held.
+	// It returns the body of the response, a *simTask for the asynchronous operations
+	// or a *simError rendered in the error format of the API.
+	simHandlerFunc func(r *http.Request) (any, error)
+
+	// simError is an error returned by the simulator with its HTTP status code.
+	// code is the error code of the vendor (e.g. BUSY_ENTITY), derived from the status if empty.
+	simError struct {
+		status  int
+		code    string
+		message string
+	}
+)
+
+// NewSimulator creates a simulator with an organization and a T0 router.
+// Use WithFixture or WithFixtureFile to seed it with other resources.
+func NewSimulator(opts ...SimulatorOptionFunc) (*Simulator, error) {
+	s := &Simulator{
+		org: &simOrg{
+			id:                  generator.MustGenerate("{urn:org}"),
+			name:                mockOrg,
+			fullName:            "Mock Organization",
+			description:         "Organization of the simulator",
+			customerMail:        "admin@example.com",
+			internetBillingMode: "PAYG",
+		},
+		t0s: []*simT0{
+			newSimT0("prvrf01eocb0001234allsp01", "SHARED_STANDARD"),
+		},
+		tasks: map[string]*simTask{},
+	}
+
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// WithRunningPolls sets the number of polls answered with a running status
+// before a job completes (0 by default: the first poll returns the result).
+// Each poll waits for the poll interval of the endpoint.
+func WithRunningPolls(n int) SimulatorOptionFunc {
+	return func(s *Simulator) error {
+		if n < 0 {
+			return errors.New("the number of running polls cannot be negative")
+		}
+		s.runningPolls = n
+		return nil
+	}
+}
+
+// handlers returns the handlers of the endpoints simulated, by endpoint name.
+func (s *Simulator) handlers() map[string]simHandlerFunc {
+	return map[string]simHandlerFunc{
+		// * Jobs
+		"GetJobCerberus":  s.getJobCerberus,
+		"GetJobVmware":    s.getJobVmware,
+		"CancelJobVmware": s.cancelJobVmware,
+
+		// * Organization
+		"GetOrganization":        s.getOrganization,
+		"GetOrganizationDetails": s.getOrganizationDetails,
+		"UpdateOrganization":     s.updateOrganization,
+
+		// * VDC
+		"ListVdc":            s.listVdc,
+		"GetVdc":             s.getVdc,
+		"GetVdcMetadata":     s.getVdcMetadata,
+		"CreateVdc":          s.createVdc,
+		"UpdateVdc":          s.updateVdc,
+		"DeleteVdc":          s.deleteVdc,
+		"ListStorageProfile": s.listStorageProfile,
+
+		// * VDC Group
+		"ListVdcGroup":   s.listVdcGroup,
+		"CreateVdcGroup": s.createVdcGroup,
+		"UpdateVdcGroup": s.updateVdcGroup,
+		"DeleteVdcGroup": s.deleteVdcGroup,
+
+		// * EdgeGateway
+		"ListT0":                     s.listNetwork,
+		"GetEdgeGatewayServices":     s.listNetwork,
+		"GetEdgeGateway":             s.getEdgeGateway,
+		"ListEdgeGateway":            s.listEdgeGateway,
+		"QueryEdgeGateway":           s.queryEdgeGateway,
+		"CreateEdgeGateway":          s.createEdgeGateway,
+		"DeleteEdgeGateway":          s.deleteEdgeGateway,
+		"UpdateEdgeGatewayBandwidth": s.updateEdgeGatewayBandwidth,
+		"CreatePublicIp":             s.createPublicIP,
+		"EnableCloudavenueServices":  s.enableCloudavenueServices,
+		"DisableCloudavenueServices": s.deleteService,
+	}
+}
+
+// handler returns the HTTP handler of the endpoint, or nil if the endpoint is not simulated.
+func (s *Simulator) handler(ep *cav.Endpoint) http.HandlerFunc {
+	h, ok := s.handlers()[ep.Name]
+	if !ok {
+		return nil
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		body, err := h(r)
+		s.mu.Unlock()
+
+		if err != nil {
+			logger.Debug("Simulated request rejected", slog.String("endpoint", ep.Name), slog.String("error", err.Error()))
+			writeSimError(w, ep, err)
+			return
+		}
+
+		if task, ok := body.(*simTask); ok {
+			writeJobCreated(w, r, task.kind, task.id, task.operation)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, body)
+	}
+}
+
+func (e *simError) Error() string {
+	return e.message
+}
+
+// errBadRequest returns a simError with the status 400.
+func errBadRequest(format string, args ...any) error {
+	return &simError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// errNotFound returns a simError with the status 404.
+func errNotFound(format string, args ...any) error {
+	return &simError{status: http.StatusNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+// errConflict returns a simError with the status 409.
+func errConflict(format string, args ...any) error {
+	return &simError{status: http.StatusConflict, message: fmt.Sprintf(format, args...)}
+}
+
+// writeSimError writes the error in the format of the API of the endpoint.
+func writeSimError(w http.ResponseWriter, ep *cav.Endpoint, err error) {
+	status := http.StatusInternalServerError
+	code := ""
+	var sErr *simError
+	if errors.As(err, &sErr) {
+		status = sErr.status
+		code = sErr.code
+	}
+	if code == "" {
+		code = vmwareErrorCode(status)
+	}
+
+	switch ep.SubClient {
+	case cav.ClientCerberus:
+		writeJSON(w, status, &CerberusError{
+			Code:    fmt.Sprintf("cf-%04d", status),
+			Reason:  http.StatusText(status),
+			Message: err.Error(),
+		})
+	default:
+		writeJSON(w, status, &VmwareError{
+			MajorErrorCode: status,
+			MinorErrorCode: code,
+			Message:        err.Error(),
+		})
+	}
+}
+
+// writeJSON writes the body encoded in JSON with the status code.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	bodyEncoded, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(bodyEncoded) //nolint:errcheck
+}
+
+// decodeBody decodes the JSON body of the request in v.
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errBadRequest("invalid request body: %s", err)
+	}
+	return nil
+}
+
+// baseURL returns the URL of the mock server, used to build the HREF of the resources.
+func baseURL(r *http.Request) string {
+	return "http://" + r.Host
+}
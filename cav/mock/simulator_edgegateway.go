@@ -0,0 +1,564 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/common-go/generator"
+	"github.com/orange-cloudavenue/common-go/urn"
+)
+
+type (
+	// simT0 is a T0 router the edge gateways are connected to.
+	simT0 struct {
+		name           string
+		classOfService string
+		uplinkID       string
+	}
+
+	// simEdgeGateway is an edge gateway owned by a VDC or a VDC group.
+	simEdgeGateway struct {
+		id          string
+		name        string
+		description string
+		rateLimit   int
+		t0          *simT0
+
+		// Only one of ownerVdc and ownerVdcGroup is set.
+		ownerVdc      *simVdc
+		ownerVdcGroup *simVdcGroup
+
+		publicIPs []*simPublicIP
+		services  *simServices
+	}
+
+	// simOwner is the VDC or the VDC group owning an edge gateway.
+	simOwner struct {
+		vdc      *simVdc
+		vdcGroup *simVdcGroup
+	}
+
+	// simPublicIP is a public IP of an edge gateway.
+	simPublicIP struct {
+		ip        string
+		announced bool
+	}
+
+	// simServices is the network connecting an edge gateway to the Cloud Avenue services.
+	simServices struct {
+		id      string
+		network string
+	}
+
+	// simNetworkT0 is a T0 router in the network hierarchy returned by Cerberus.
+	// It is the superset of the T0 and network services responses sharing the same route.
+	simNetworkT0 struct {
+		Type       string `json:"type"`
+		Name       string `json:"name"`
+		Properties struct {
+			ClassOfService string `json:"classOfService,omitempty"`
+		} `json:"properties"`
+		Children []simNetworkEdgeGateway `json:"children"`
+	}
+
+	simNetworkEdgeGateway struct {
+		Type       string `json:"type"`
+		Name       string `json:"name"`
+		Properties struct {
+			RateLimit int    `json:"rateLimit,omitempty"`
+			EdgeUUID  string `json:"edgeUuid,omitempty"`
+		} `json:"properties"`
+		Children []simNetworkService `json:"children,omitempty"`
+	}
+
+	simNetworkService struct {
+		Type        string `json:"type"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName,omitempty"`
+		ServiceID   string `json:"serviceId"`
+		Properties  struct {
+			IP        string   `json:"ip,omitempty"`
+			Announced bool     `json:"announced,omitempty"`
+			Ranges    []string `json:"ranges,omitempty"`
+		} `json:"properties"`
+	}
+
+	// simEdgeGatewayResponse is an edge gateway returned by the VMware cloudapi.
+	simEdgeGatewayResponse struct {
+		ID                 string                     `json:"id"`
+		Name               string                     `json:"name"`
+		Description        string                     `json:"description"`
+		EdgeGatewayUplinks []simEdgeGatewayUplink     `json:"edgeGatewayUplinks"`
+		OrgVDC             *itypes.ApiObjectReference `json:"orgVdc"`
+		OwnerRef           *itypes.ApiObjectReference `json:"ownerRef"`
+	}
+
+	simEdgeGatewayUplink struct {
+		Connected  bool   `json:"connected"`
+		Dedicated  bool   `json:"dedicated"`
+		UplinkID   string `json:"uplinkId"`
+		UplinkName string `json:"uplinkName"`
+	}
+)
+
+func newSimT0(name, classOfService string) *simT0 {
+	return &simT0{
+		name:           name,
+		classOfService: defaultString(classOfService, "SHARED_STANDARD"),
+		uplinkID:       generator.MustGenerate("{urn:network}"),
+	}
+}
+
+func (s *Simulator) findT0(name string) *simT0 {
+	for _, t0 := range s.t0s {
+		if t0.name == name {
+			return t0
+		}
+	}
+	return nil
+}
+
+// findOwner returns the VDC or, if there is no VDC with this name, the VDC group.
+func (s *Simulator) findOwner(name string) (simOwner, error) {
+	if vdc := s.findVdcByName(name); vdc != nil {
+		return simOwner{vdc: vdc}, nil
+	}
+	if group := s.findVdcGroupByName(name); group != nil {
+		return simOwner{vdcGroup: group}, nil
+	}
+	return simOwner{}, errNotFound("VDC or VDC group %s not found", name)
+}
+
+// ownerExists reports whether the owner is still stored in the simulator.
+func (s *Simulator) ownerExists(o simOwner) bool {
+	if o.vdc != nil {
+		return s.findVdcByID(o.vdc.id) == o.vdc
+	}
+	return s.findVdcGroupByID(o.vdcGroup.id) == o.vdcGroup
+}
+
+// newEdgeGateway returns an edge gateway with a name not used by another one.
+// The edge gateway is not stored.
+func (s *Simulator) newEdgeGateway(owner simOwner, t0 *simT0) *simEdgeGateway {
+	name := generator.MustGenerate("{resource_name:edgegateway}")
+	for s.findEdgeGatewayByName(name) != nil {
+		name = generator.MustGenerate("{resource_name:edgegateway}")
+	}
+
+	return &simEdgeGateway{
+		id:            generator.MustGenerate("{urn:edgegateway}"),
+		name:          name,
+		rateLimit:     5,
+		t0:            t0,
+		ownerVdc:      owner.vdc,
+		ownerVdcGroup: owner.vdcGroup,
+	}
+}
+
+func (s *Simulator) findEdgeGatewayByName(name string) *simEdgeGateway {
+	for _, e := range s.edgeGateways {
+		if e.name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// findEdgeGatewayByID returns the edge gateway by URN or UUID.
+func (s *Simulator) findEdgeGatewayByID(id string) *simEdgeGateway {
+	for _, e := range s.edgeGateways {
+		if e.id == id || urn.ExtractUUID(e.id) == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// findPublicIP returns the public IP from any edge gateway.
+func (s *Simulator) findPublicIP(ip string) *simPublicIP {
+	for _, e := range s.edgeGateways {
+		for _, p := range e.publicIPs {
+			if p.ip == ip {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// newPublicIP returns a public IP not used by an edge gateway.
+func (s *Simulator) newPublicIP() string {
+	ip := generator.MustGenerate("{ipv4address}")
+	for s.findPublicIP(ip) != nil {
+		ip = generator.MustGenerate("{ipv4address}")
+	}
+	return ip
+}
+
+func newSimServices(edge *simEdgeGateway, prefixLength int) *simServices {
+	return &simServices{
+		id:      edge.name + "-cav-services",
+		network: fmt.Sprintf("%s/%d", generator.MustGenerate("{ipv4address}"), prefixLength),
+	}
+}
+
+// ownerRef returns the reference of the VDC or the VDC group owning the edge gateway.
+func (e *simEdgeGateway) ownerRef() *itypes.ApiObjectReference {
+	if e.ownerVdc != nil {
+		return &itypes.ApiObjectReference{ID: e.ownerVdc.id, Name: e.ownerVdc.name}
+	}
+	return &itypes.ApiObjectReference{ID: e.ownerVdcGroup.id, Name: e.ownerVdcGroup.name}
+}
+
+func (e *simEdgeGateway) toResponse() simEdgeGatewayResponse {
+	resp := simEdgeGatewayResponse{
+		ID:          e.id,
+		Name:        e.name,
+		Description: e.description,
+		EdgeGatewayUplinks: []simEdgeGatewayUplink{
+			{
+				Connected:  true,
+				Dedicated:  strings.HasPrefix(e.t0.classOfService, "DEDICATED"),
+				UplinkID:   e.t0.uplinkID,
+				UplinkName: e.t0.name,
+			},
+		},
+		OwnerRef: e.ownerRef(),
+	}
+	if e.ownerVdc != nil {
+		resp.OrgVDC = resp.OwnerRef
+	}
+	return resp
+}
+
+// * Network hierarchy
+
+// listNetwork returns the network hierarchy of the organization: the T0 routers, their edge gateways
+// and the services of the edge gateways. As Cerberus does, the query parameters are ignored.
+func (s *Simulator) listNetwork(_ *http.Request) (any, error) {
+	resp := make([]simNetworkT0, 0, len(s.t0s))
+	for _, t0 := range s.t0s {
+		nt0 := simNetworkT0{
+			Type:     "tier-0-vrf",
+			Name:     t0.name,
+			Children: make([]simNetworkEdgeGateway, 0),
+		}
+		nt0.Properties.ClassOfService = t0.classOfService
+
+		for _, e := range s.edgeGateways {
+			if e.t0 != t0 {
+				continue
+			}
+
+			nEdge := simNetworkEdgeGateway{
+				Type: "edge-gateway",
+				Name: e.name,
+			}
+			nEdge.Properties.RateLimit = e.rateLimit
+			nEdge.Properties.EdgeUUID = urn.ExtractUUID(e.id)
+
+			for _, ip := range e.publicIPs {
+				svc := simNetworkService{
+					Type:        "service",
+					Name:        "internet",
+					DisplayName: "internet",
+					ServiceID:   publicIPServiceID(ip.ip),
+				}
+				svc.Properties.IP = ip.ip
+				svc.Properties.Announced = ip.announced
+				nEdge.Children = append(nEdge.Children, svc)
+			}
+
+			if e.services != nil {
+				svc := simNetworkService{
+					Type:        "service",
+					Name:        "cav-services",
+					DisplayName: "Cloud Avenue Services",
+					ServiceID:   e.services.id,
+				}
+				svc.Properties.Ranges = []string{e.services.network}
+				nEdge.Children = append(nEdge.Children, svc)
+			}
+
+			nt0.Children = append(nt0.Children, nEdge)
+		}
+
+		resp = append(resp, nt0)
+	}
+
+	return resp, nil
+}
+
+// publicIPServiceID returns the ID of the internet service of the public IP (ip-a-b-c-d).
+func publicIPServiceID(ip string) string {
+	return "ip-" + strings.ReplaceAll(ip, ".", "-")
+}
+
+// * EdgeGateway
+
+// getEdgeGateway returns the edge gateway of the path parameter edgeId.
+func (s *Simulator) getEdgeGateway(r *http.Request) (any, error) {
+	edge, err := s.lookupEdgeGateway(r)
+	if err != nil {
+		return nil, err
+	}
+	return edge.toResponse(), nil
+}
+
+// listEdgeGateway returns the edge gateways of the requested page.
+func (s *Simulator) listEdgeGateway(r *http.Request) (any, error) {
+	values := make([]simEdgeGatewayResponse, 0, len(s.edgeGateways))
+	for _, e := range s.edgeGateways {
+		values = append(values, e.toResponse())
+	}
+
+	values, page := cloudAPIPage(r, values)
+
+	return struct {
+		Values []simEdgeGatewayResponse `json:"values"`
+		simCloudAPIPage
+	}{values, page}, nil
+}
+
+// queryEdgeGateway returns the edge gateways matching the filter of the query.
+func (s *Simulator) queryEdgeGateway(r *http.Request) (any, error) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := itypes.ApiResponseQueryEdgeGateway{
+		Record: make([]itypes.ApiResponseQueryEdgeGatewayRecord, 0),
+	}
+	for _, e := range s.edgeGateways {
+		fields := map[string]string{
+			"name":                e.name,
+			"isBusy":              "false",
+			"gatewayStatus":       "READY",
+			"numberOfExtNetworks": "1",
+			"numberOfOrgNetworks": "0",
+		}
+		if e.ownerVdc != nil {
+			fields["orgVdcName"] = e.ownerVdc.name
+		}
+		if !filter.match(fields) {
+			continue
+		}
+
+		record := itypes.ApiResponseQueryEdgeGatewayRecord{
+			HREF:                baseURL(r) + "/api/admin/edgeGateway/" + urn.ExtractUUID(e.id),
+			Type:                "application/vnd.vmware.vcloud.query.edgeGateway+json",
+			Name:                e.name,
+			NumberOfExtNetworks: 1,
+			GatewayStatus:       "READY",
+		}
+		if e.ownerVdc != nil {
+			record.VDCID = e.ownerVdc.href(r)
+			record.VDCName = e.ownerVdc.name
+		}
+		resp.Record = append(resp.Record, record)
+	}
+
+	return resp, nil
+}
+
+// lookupEdgeGateway returns the edge gateway of the path parameter edgeId (URN or UUID).
+func (s *Simulator) lookupEdgeGateway(r *http.Request) (*simEdgeGateway, error) {
+	id := chi.URLParam(r, "edgeId")
+	edge := s.findEdgeGatewayByID(id)
+	if edge == nil {
+		return nil, errNotFound("edge gateway %s not found", id)
+	}
+	return edge, nil
+}
+
+// createEdgeGateway creates an edge gateway owned by the VDC or the VDC group of the path with a Cerberus job.
+// The name of the edge gateway is returned in the details of the job.
+func (s *Simulator) createEdgeGateway(r *http.Request) (any, error) {
+	var req itypes.ApiRequestEdgeGateway
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	ownerName := chi.URLParam(r, "vdc-name")
+	var owner simOwner
+	switch chi.URLParam(r, "vdc-type") {
+	case "vdcs":
+		owner.vdc = s.findVdcByName(ownerName)
+		if owner.vdc == nil {
+			return nil, errNotFound("VDC %s not found", ownerName)
+		}
+	case "vdc-groups":
+		owner.vdcGroup = s.findVdcGroupByName(ownerName)
+		if owner.vdcGroup == nil {
+			return nil, errNotFound("VDC group %s not found", ownerName)
+		}
+	default:
+		return nil, errBadRequest("invalid owner type %s", chi.URLParam(r, "vdc-type"))
+	}
+
+	t0 := s.findT0(req.T0Name)
+	if t0 == nil {
+		return nil, errNotFound("T0 %s not found", req.T0Name)
+	}
+
+	edge := s.newEdgeGateway(owner, t0)
+
+	return s.newTask(cerberusJob, "createEdgeGateway", "Create edge gateway "+edge.name, edge.name,
+		func() error {
+			if !s.ownerExists(owner) {
+				return errNotFound("owner %s of the edge gateway not found", ownerName)
+			}
+			return nil
+		},
+		func() {
+			s.edgeGateways = append(s.edgeGateways, edge)
+		})
+}
+
+// deleteEdgeGateway deletes the edge gateway and its services with a Cerberus job.
+func (s *Simulator) deleteEdgeGateway(r *http.Request) (any, error) {
+	edge, err := s.lookupEdgeGateway(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newTask(cerberusJob, "deleteEdgeGateway", "Delete edge gateway "+edge.name, edge.name,
+		s.edgeGatewayExists(edge),
+		func() {
+			s.edgeGateways = slices.DeleteFunc(s.edgeGateways, func(e *simEdgeGateway) bool { return e == edge })
+		})
+}
+
+// edgeGatewayExists returns a check failing if the edge gateway has been deleted.
+func (s *Simulator) edgeGatewayExists(edge *simEdgeGateway) func() error {
+	return func() error {
+		if s.findEdgeGatewayByID(edge.id) != edge {
+			return errNotFound("edge gateway %s not found", edge.name)
+		}
+		return nil
+	}
+}
+
+// updateEdgeGatewayBandwidth updates the rate limit of the edge gateway with a Cerberus job.
+func (s *Simulator) updateEdgeGatewayBandwidth(r *http.Request) (any, error) {
+	edge, err := s.lookupEdgeGateway(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req itypes.ApiRequestBandwidth
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Bandwidth <= 0 {
+		return nil, errBadRequest("invalid rate limit %d", req.Bandwidth)
+	}
+
+	return s.newTask(cerberusJob, "updateEdgeGateway", "Update edge gateway "+edge.name, edge.name,
+		s.edgeGatewayExists(edge),
+		func() {
+			edge.rateLimit = req.Bandwidth
+		})
+}
+
+// * Services
+
+// createPublicIP reserves a public IP for the edge gateway with a Cerberus job.
+// The IP is returned in the details of the job.
+func (s *Simulator) createPublicIP(r *http.Request) (any, error) {
+	var req itypes.ApiRequestEdgegatewayPublicIP
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	if req.NetworkType != "internet" {
+		return nil, errBadRequest("invalid network type %s", req.NetworkType)
+	}
+
+	edge := s.findEdgeGatewayByID(req.EdgeGatewayID)
+	if edge == nil {
+		return nil, errNotFound("edge gateway %s not found", req.EdgeGatewayID)
+	}
+
+	ip := &simPublicIP{ip: s.newPublicIP(), announced: req.Properties.Announced}
+
+	return s.newTask(cerberusJob, "reserve_ip for Org "+s.org.name+" for public ip", "Reserve public IP", ip.ip,
+		s.edgeGatewayExists(edge),
+		func() {
+			edge.publicIPs = append(edge.publicIPs, ip)
+		})
+}
+
+// enableCloudavenueServices connects the edge gateway to the Cloud Avenue services with a Cerberus job.
+func (s *Simulator) enableCloudavenueServices(r *http.Request) (any, error) {
+	var req itypes.ApiRequestNetworkServicesCavSvc
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	if req.NetworkType != "cav-services" {
+		return nil, errBadRequest("invalid network type %s", req.NetworkType)
+	}
+
+	edge := s.findEdgeGatewayByID(req.EdgeGatewayID)
+	if edge == nil {
+		return nil, errNotFound("edge gateway %s not found", req.EdgeGatewayID)
+	}
+	if edge.services != nil {
+		// The message of Cerberus when the service is already enabled.
+		return nil, errBadRequest("subnet not fully consumed for edge gateway %s", edge.name)
+	}
+
+	prefixLength := req.Properties.PrefixLength
+	if prefixLength == 0 {
+		prefixLength = 27
+	}
+
+	return s.newTask(cerberusJob, "enableCloudavenueServices", "Enable Cloud Avenue services", edge.name,
+		s.edgeGatewayExists(edge),
+		func() {
+			edge.services = newSimServices(edge, prefixLength)
+		})
+}
+
+// deleteService deletes the public IP (ip-a-b-c-d) or the Cloud Avenue services of the path parameter serviceId
+// with a Cerberus job.
+func (s *Simulator) deleteService(r *http.Request) (any, error) {
+	serviceID := chi.URLParam(r, "serviceId")
+
+	for _, e := range s.edgeGateways {
+		for _, ip := range e.publicIPs {
+			if publicIPServiceID(ip.ip) != serviceID {
+				continue
+			}
+			return s.newTask(cerberusJob, "deleteService", "Release public IP "+ip.ip, ip.ip,
+				s.edgeGatewayExists(e),
+				func() {
+					e.publicIPs = slices.DeleteFunc(e.publicIPs, func(p *simPublicIP) bool { return p == ip })
+				})
+		}
+
+		if e.services != nil && e.services.id == serviceID {
+			return s.newTask(cerberusJob, "deleteService", "Disable Cloud Avenue services", e.name,
+				s.edgeGatewayExists(e),
+				func() {
+					e.services = nil
+				})
+		}
+	}
+
+	return nil, errNotFound("service %s not found", serviceID)
+}
@@ -0,0 +1,125 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/common-go/urn"
+)
+
+// simFilter is the FIQL filter of a list request, matched against the fields of the simulated resources.
+// The zero value matches all the resources.
+type simFilter struct {
+	filter cav.Filter
+}
+
+// parseFilter parses the filter of the query. An invalid filter is rejected as VMware does.
+func parseFilter(r *http.Request) (simFilter, error) {
+	value := r.URL.Query().Get("filter")
+	if value == "" {
+		return simFilter{}, nil
+	}
+
+	f, err := cav.ParseFilter(value)
+	if err != nil {
+		return simFilter{}, errBadRequest("%v", err)
+	}
+	return simFilter{filter: f}, nil
+}
+
+// match returns true if the fields of the resource, by name, match the filter.
+// A condition on a field the resource does not have is not matched.
+func (f simFilter) match(fields map[string]string) bool {
+	if f.filter == nil {
+		return true
+	}
+	return matchFilter(f.filter, fields)
+}
+
+func matchFilter(f cav.Filter, fields map[string]string) bool {
+	switch f := f.(type) {
+	case cav.FilterGroup:
+		for _, sub := range f.Filters {
+			matched := matchFilter(sub, fields)
+			if f.Or && matched {
+				return true
+			}
+			if !f.Or && !matched {
+				return false
+			}
+		}
+		return !f.Or || len(f.Filters) == 0
+	case cav.FilterCondition:
+		value, ok := fields[f.Field]
+		if !ok {
+			return false
+		}
+		return matchCondition(f, value)
+	default:
+		return false
+	}
+}
+
+func matchCondition(c cav.FilterCondition, value string) bool {
+	switch c.Operator {
+	case cav.FilterEqual:
+		return matchValue(c.Value, value)
+	case cav.FilterNotEqual:
+		return !matchValue(c.Value, value)
+	case cav.FilterGreaterThan:
+		return compareValues(value, c.Value) > 0
+	case cav.FilterGreaterOrEqual:
+		return compareValues(value, c.Value) >= 0
+	case cav.FilterLessThan:
+		return compareValues(value, c.Value) < 0
+	case cav.FilterLessOrEqual:
+		return compareValues(value, c.Value) <= 0
+	default:
+		return false
+	}
+}
+
+// matchValue returns true if the value matches the pattern, which can contain the wildcard *.
+// The IDs match by URN or by UUID, as the /api/query endpoints are filtered by UUID.
+func matchValue(pattern, value string) bool {
+	if strings.Contains(pattern, "*") {
+		re := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		return regexp.MustCompile(re).MatchString(value)
+	}
+	if pattern == value {
+		return true
+	}
+
+	id := urn.ExtractUUID(pattern)
+	return id != "" && id == urn.ExtractUUID(value)
+}
+
+// compareValues compares the values as numbers if both are numbers, as strings otherwise.
+func compareValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	switch {
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	default:
+		return 0
+	}
+}
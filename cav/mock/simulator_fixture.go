@@ -0,0 +1,304 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/orange-cloudavenue/common-go/generator"
+	"github.com/orange-cloudavenue/common-go/urn"
+)
+
+type (
+	// Fixture describes the resources loaded in the simulator.
+	// The resources reference each other by name.
+	Fixture struct {
+		Organization *FixtureOrganization `json:"organization,omitempty"`
+		// T0s replaces the T0 router created by default.
+		T0s          []FixtureT0          `json:"t0s,omitempty"`
+		Vdcs         []FixtureVdc         `json:"vdcs,omitempty"`
+		VdcGroups    []FixtureVdcGroup    `json:"vdcGroups,omitempty"`
+		EdgeGateways []FixtureEdgeGateway `json:"edgeGateways,omitempty"`
+	}
+
+	// FixtureOrganization holds the settings of the organization. The empty fields keep their default value.
+	FixtureOrganization struct {
+		FullName            string `json:"fullName,omitempty"`
+		Description         string `json:"description,omitempty"`
+		CustomerMail        string `json:"customerMail,omitempty"`
+		InternetBillingMode string `json:"internetBillingMode,omitempty"`
+	}
+
+	// FixtureT0 is a T0 router. ClassOfService is SHARED_STANDARD by default.
+	FixtureT0 struct {
+		Name           string `json:"name"`
+		ClassOfService string `json:"classOfService,omitempty"`
+	}
+
+	// FixtureVdc is a VDC. The ID is generated if empty.
+	FixtureVdc struct {
+		ID                  string `json:"id,omitempty"`
+		Name                string `json:"name"`
+		Description         string `json:"description,omitempty"`
+		ServiceClass        string `json:"serviceClass,omitempty"`
+		DisponibilityClass  string `json:"disponibilityClass,omitempty"`
+		BillingModel        string `json:"billingModel,omitempty"`
+		StorageBillingModel string `json:"storageBillingModel,omitempty"`
+		// VCPUInMhz is the frequency of a vCPU, 2200 by default.
+		VCPUInMhz int `json:"vcpuInMhz,omitempty"`
+		// CPUAllocated is the CPU allocated in MHz.
+		CPUAllocated int `json:"cpuAllocated,omitempty"`
+		// MemoryAllocated is the memory allocated in GiB.
+		MemoryAllocated int `json:"memoryAllocated,omitempty"`
+		// StorageProfiles are the storage profiles of the VDC. A default one is created if empty.
+		StorageProfiles []FixtureStorageProfile `json:"storageProfiles,omitempty"`
+	}
+
+	// FixtureStorageProfile is a storage profile of a VDC. The limit and the used storage are in GiB.
+	FixtureStorageProfile struct {
+		Class   string `json:"class"`
+		Limit   int    `json:"limit"`
+		Used    int    `json:"used,omitempty"`
+		Default bool   `json:"default,omitempty"`
+	}
+
+	// FixtureVdcGroup is a VDC group and the names of its VDCs. The ID is generated if empty.
+	FixtureVdcGroup struct {
+		ID          string   `json:"id,omitempty"`
+		Name        string   `json:"name"`
+		Description string   `json:"description,omitempty"`
+		Vdcs        []string `json:"vdcs"`
+	}
+
+	// FixtureEdgeGateway is an edge gateway owned by a VDC or a VDC group.
+	// The ID and the name are generated if empty. The T0 router may be omitted if there is only one.
+	FixtureEdgeGateway struct {
+		ID          string `json:"id,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		// Owner is the name of the VDC or the VDC group owning the edge gateway.
+		Owner  string `json:"owner"`
+		T0Name string `json:"t0Name,omitempty"`
+		// Bandwidth is the rate limit in Mbps, 5 by default.
+		Bandwidth           int               `json:"bandwidth,omitempty"`
+		PublicIPs           []FixturePublicIP `json:"publicIps,omitempty"`
+		CloudavenueServices bool              `json:"cloudavenueServices,omitempty"`
+	}
+
+	// FixturePublicIP is a public IP of an edge gateway.
+	FixturePublicIP struct {
+		IP        string `json:"ip"`
+		Announced bool   `json:"announced,omitempty"`
+	}
+)
+
+// WithFixture seeds the simulator with the resources of the fixture.
+func WithFixture(f Fixture) SimulatorOptionFunc {
+	return func(s *Simulator) error {
+		return s.seed(f)
+	}
+}
+
+// WithFixtureFile seeds the simulator with the resources of the JSON fixture file.
+func WithFixtureFile(path string) SimulatorOptionFunc {
+	return func(s *Simulator) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read the fixture: %w", err)
+		}
+
+		var f Fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("failed to decode the fixture %s: %w", path, err)
+		}
+
+		return s.seed(f)
+	}
+}
+
+// seed adds the resources of the fixture to the simulator.
+func (s *Simulator) seed(f Fixture) error {
+	if f.Organization != nil {
+		s.org.update(f.Organization.FullName, f.Organization.Description, f.Organization.CustomerMail, f.Organization.InternetBillingMode)
+	}
+
+	if len(f.T0s) > 0 {
+		s.t0s = nil
+		for _, t0 := range f.T0s {
+			if t0.Name == "" {
+				return errors.New("fixture: the name of a T0 cannot be empty")
+			}
+			if s.findT0(t0.Name) != nil {
+				return fmt.Errorf("fixture: duplicate T0 %s", t0.Name)
+			}
+			s.t0s = append(s.t0s, newSimT0(t0.Name, t0.ClassOfService))
+		}
+	}
+
+	for _, v := range f.Vdcs {
+		if err := s.seedVdc(v); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range f.VdcGroups {
+		if err := s.seedVdcGroup(g); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range f.EdgeGateways {
+		if err := s.seedEdgeGateway(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) seedVdc(v FixtureVdc) error {
+	if v.Name == "" {
+		return errors.New("fixture: the name of a VDC cannot be empty")
+	}
+	if s.findVdcByName(v.Name) != nil {
+		return fmt.Errorf("fixture: duplicate VDC %s", v.Name)
+	}
+
+	vdc := &simVdc{
+		id:                  v.ID,
+		name:                v.Name,
+		description:         v.Description,
+		serviceClass:        defaultString(v.ServiceClass, "STD"),
+		disponibilityClass:  defaultString(v.DisponibilityClass, "ONE-ROOM"),
+		billingModel:        defaultString(v.BillingModel, "PAYG"),
+		storageBillingModel: defaultString(v.StorageBillingModel, "PAYG"),
+		vcpuInMhz:           v.VCPUInMhz,
+		cpuAllocated:        v.CPUAllocated,
+		memoryAllocated:     v.MemoryAllocated,
+	}
+	if vdc.id == "" {
+		vdc.id = generator.MustGenerate("{urn:vdc}")
+	}
+	if !urn.IsVDC(vdc.id) {
+		return fmt.Errorf("fixture: invalid ID %s for VDC %s", vdc.id, v.Name)
+	}
+	if vdc.vcpuInMhz == 0 {
+		vdc.vcpuInMhz = 2200
+	}
+
+	if len(v.StorageProfiles) == 0 {
+		v.StorageProfiles = []FixtureStorageProfile{{Class: "platinum3k_r1", Limit: 100, Default: true}}
+	}
+	for _, sp := range v.StorageProfiles {
+		if sp.Class == "" {
+			return fmt.Errorf("fixture: the class of a storage profile of VDC %s cannot be empty", v.Name)
+		}
+		vdc.storageProfiles = append(vdc.storageProfiles, newSimStorageProfile(sp.Class, sp.Limit, sp.Used, sp.Default))
+	}
+	vdc.ensureDefaultStorageProfile()
+
+	s.vdcs = append(s.vdcs, vdc)
+	return nil
+}
+
+func (s *Simulator) seedVdcGroup(g FixtureVdcGroup) error {
+	if g.Name == "" {
+		return errors.New("fixture: the name of a VDC group cannot be empty")
+	}
+	if s.findVdcGroupByName(g.Name) != nil {
+		return fmt.Errorf("fixture: duplicate VDC group %s", g.Name)
+	}
+
+	group := &simVdcGroup{
+		id:          g.ID,
+		name:        g.Name,
+		description: g.Description,
+	}
+	if group.id == "" {
+		group.id = generator.MustGenerate("{urn:vdcGroup}")
+	}
+
+	for _, name := range g.Vdcs {
+		vdc := s.findVdcByName(name)
+		if vdc == nil {
+			return fmt.Errorf("fixture: VDC %s of VDC group %s not found", name, g.Name)
+		}
+		group.vdcs = append(group.vdcs, vdc)
+	}
+
+	s.vdcGroups = append(s.vdcGroups, group)
+	return nil
+}
+
+func (s *Simulator) seedEdgeGateway(e FixtureEdgeGateway) error {
+	owner, err := s.findOwner(e.Owner)
+	if err != nil {
+		return fmt.Errorf("fixture: %w", err)
+	}
+
+	var t0 *simT0
+	switch {
+	case e.T0Name != "":
+		t0 = s.findT0(e.T0Name)
+	case len(s.t0s) == 1:
+		t0 = s.t0s[0]
+	}
+	if t0 == nil {
+		return fmt.Errorf("fixture: T0 %q of edge gateway %s not found", e.T0Name, e.Name)
+	}
+
+	if e.Name != "" && s.findEdgeGatewayByName(e.Name) != nil {
+		return fmt.Errorf("fixture: duplicate edge gateway %s", e.Name)
+	}
+
+	edge := s.newEdgeGateway(owner, t0)
+	if e.ID != "" {
+		if !urn.IsEdgeGateway(e.ID) {
+			return fmt.Errorf("fixture: invalid ID %s for edge gateway %s", e.ID, e.Name)
+		}
+		edge.id = e.ID
+	}
+	if e.Name != "" {
+		edge.name = e.Name
+	}
+	edge.description = e.Description
+	if e.Bandwidth > 0 {
+		edge.rateLimit = e.Bandwidth
+	}
+	s.edgeGateways = append(s.edgeGateways, edge)
+
+	for _, ip := range e.PublicIPs {
+		if net.ParseIP(ip.IP).To4() == nil {
+			return fmt.Errorf("fixture: invalid public IP %q of edge gateway %s", ip.IP, edge.name)
+		}
+		if s.findPublicIP(ip.IP) != nil {
+			return fmt.Errorf("fixture: duplicate public IP %s", ip.IP)
+		}
+		edge.publicIPs = append(edge.publicIPs, &simPublicIP{ip: ip.IP, announced: ip.Announced})
+	}
+
+	if e.CloudavenueServices {
+		edge.services = newSimServices(edge, 27)
+	}
+
+	return nil
+}
+
+// defaultString returns value, or def if value is empty.
+func defaultString(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,79 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+)
+
+// simOrg holds the settings of the organization.
+type simOrg struct {
+	id                  string
+	name                string
+	fullName            string
+	description         string
+	customerMail        string
+	internetBillingMode string
+}
+
+// update sets the settings not empty.
+func (o *simOrg) update(fullName, description, customerMail, internetBillingMode string) {
+	o.fullName = defaultString(fullName, o.fullName)
+	o.description = defaultString(description, o.description)
+	o.customerMail = defaultString(customerMail, o.customerMail)
+	o.internetBillingMode = defaultString(internetBillingMode, o.internetBillingMode)
+}
+
+// getOrganization returns the settings of the organization from Cerberus.
+func (s *Simulator) getOrganization(_ *http.Request) (any, error) {
+	return itypes.ApiResponseGetOrg{
+		Name:                s.org.name,
+		FullName:            s.org.fullName,
+		Description:         s.org.description,
+		IsEnabled:           true,
+		CustomerMail:        s.org.customerMail,
+		InternetBillingMode: s.org.internetBillingMode,
+	}, nil
+}
+
+// getOrganizationDetails returns the organization from VMware with the count of its VDCs.
+func (s *Simulator) getOrganizationDetails(_ *http.Request) (any, error) {
+	return itypes.ApiResponseGetOrgs{
+		Organizations: []itypes.ApiResponseGetOrgDetails{
+			{
+				ID:          s.org.id,
+				Name:        s.org.name,
+				DisplayName: s.org.fullName,
+				Description: s.org.description,
+				IsEnabled:   true,
+				OrgVdcCount: len(s.vdcs),
+			},
+		},
+	}, nil
+}
+
+// updateOrganization updates the settings of the organization with a Cerberus job.
+func (s *Simulator) updateOrganization(r *http.Request) (any, error) {
+	var req itypes.ApiRequestUpdateOrg
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	if req.InternetBillingMode != "" && !slices.Contains([]string{"PAYG", "TRAFFIC_VOLUME"}, req.InternetBillingMode) {
+		return nil, errBadRequest("invalid internet billing mode %s", req.InternetBillingMode)
+	}
+
+	return s.newTask(cerberusJob, "updateOrganization", "Update organization "+s.org.name, s.org.name, nil, func() {
+		s.org.update(req.FullName, req.Description, req.CustomerMail, req.InternetBillingMode)
+	})
+}
@@ -0,0 +1,90 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// simDefaultPageSize is the page size of VMware Cloud Director when the query has no pageSize.
+const simDefaultPageSize = 25
+
+type (
+	// simQueryPage is the pagination metadata of the /api/query endpoints.
+	simQueryPage struct {
+		Page     int           `json:"page"`
+		PageSize int           `json:"pageSize"`
+		Total    int           `json:"total"`
+		Link     []simPageLink `json:"link,omitempty"`
+	}
+
+	simPageLink struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	}
+
+	// simCloudAPIPage is the pagination metadata of the cloudapi endpoints.
+	simCloudAPIPage struct {
+		Page        int `json:"page"`
+		PageSize    int `json:"pageSize"`
+		PageCount   int `json:"pageCount"`
+		ResultTotal int `json:"resultTotal"`
+	}
+)
+
+// paginate returns the items of the page requested by the query parameters page and pageSize.
+func paginate[T any](r *http.Request, items []T) (pageItems []T, page, pageSize int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err = strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = simDefaultPageSize
+	}
+
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+	return items[start:end], page, pageSize
+}
+
+// queryPage returns the items of the requested page and the metadata of an /api/query page,
+// with a nextPage link if the page is not the last one.
+func queryPage[T any](r *http.Request, items []T) ([]T, simQueryPage) {
+	pageItems, page, pageSize := paginate(r, items)
+
+	meta := simQueryPage{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    len(items),
+	}
+	if page*pageSize < len(items) {
+		next := *r.URL
+		q := next.Query()
+		q.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = q.Encode()
+		meta.Link = append(meta.Link, simPageLink{Rel: "nextPage", Href: baseURL(r) + next.RequestURI()})
+	}
+
+	return pageItems, meta
+}
+
+// cloudAPIPage returns the items of the requested page and the metadata of a cloudapi page.
+func cloudAPIPage[T any](r *http.Request, items []T) ([]T, simCloudAPIPage) {
+	pageItems, page, pageSize := paginate(r, items)
+
+	return pageItems, simCloudAPIPage{
+		Page:        page,
+		PageSize:    pageSize,
+		PageCount:   (len(items) + pageSize - 1) / pageSize,
+		ResultTotal: len(items),
+	}
+}
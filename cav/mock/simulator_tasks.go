@@ -0,0 +1,309 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/common-go/generator"
+)
+
+type (
+	// SimulatorTask is the state of a Cerberus job or a VMware task of the simulator.
+	SimulatorTask struct {
+		ID        string
+		Operation string
+		// Status is the status in the format of the API
+		// (CREATED, IN_PROGRESS, DONE, FAILED for Cerberus and queued, running, success, error for VMware).
+		Status string
+		// Error is the reason of the failure of the task.
+		Error string
+	}
+
+	// simTask is an asynchronous operation. The operation is applied when the task completes.
+	simTask struct {
+		id          string
+		kind        simTaskKind
+		operation   string
+		description string
+		// details is the identifier of the resource created (e.g. the public IP),
+		// returned in the actions of the Cerberus jobs.
+		details string
+
+		// check verifies that the operation can be applied, apply applies it.
+		check func() error
+		apply func()
+
+		status    simTaskStatus
+		err       error
+		polls     int
+		startTime time.Time
+		endTime   time.Time
+	}
+
+	simTaskKind   int
+	simTaskStatus int
+
+	// vmwareTaskResponse is the body of a VMware task.
+	vmwareTaskResponse struct {
+		HREF          string             `json:"href"`
+		ID            string             `json:"id"`
+		Name          string             `json:"name"`
+		Status        string             `json:"status"`
+		Operation     string             `json:"operation"`
+		OperationName string             `json:"operationName"`
+		StartTime     string             `json:"startTime,omitempty"`
+		EndTime       string             `json:"endTime,omitempty"`
+		Description   string             `json:"description,omitempty"`
+		Progress      int                `json:"progress"`
+		Error         *vmwareTaskFailure `json:"error,omitempty"`
+	}
+
+	// vmwareTaskFailure is the error of a failed VMware task.
+	vmwareTaskFailure struct {
+		Message        string `json:"message"`
+		MajorErrorCode int    `json:"majorErrorCode"`
+		MinorErrorCode string `json:"minorErrorCode"`
+	}
+)
+
+const (
+	cerberusJob simTaskKind = iota
+	vmwareTask
+)
+
+const (
+	taskQueued simTaskStatus = iota
+	taskRunning
+	taskSuccess
+	taskError
+	taskAborted
+)
+
+// newTask checks the operation and registers the task applying it.
+// The error of the check is returned synchronously, as the API rejects the request.
+func (s *Simulator) newTask(kind simTaskKind, operation, description, details string, check func() error, apply func()) (*simTask, error) {
+	if check == nil {
+		check = func() error { return nil }
+	}
+
+	if err := check(); err != nil {
+		return nil, err
+	}
+
+	t := &simTask{
+		id:          generator.MustGenerate("{uuid}"),
+		kind:        kind,
+		operation:   operation,
+		description: description,
+		details:     details,
+		check:       check,
+		apply:       apply,
+		status:      taskQueued,
+		startTime:   time.Now(),
+	}
+
+	s.tasks[t.id] = t
+	s.taskOrder = append(s.taskOrder, t.id)
+
+	logger.Debug("Simulated task created", "id", t.id, "operation", operation)
+	return t, nil
+}
+
+// poll advances the task: it is running during the first polls, then the operation
+// is checked again and applied. The check fails if the state changed since the task was created.
+func (t *simTask) poll(runningPolls int) {
+	if t.terminated() {
+		return
+	}
+
+	t.polls++
+	if t.polls <= runningPolls {
+		t.status = taskRunning
+		return
+	}
+
+	t.endTime = time.Now()
+	if err := t.check(); err != nil {
+		t.status = taskError
+		t.err = err
+		return
+	}
+
+	t.apply()
+	t.status = taskSuccess
+}
+
+// terminated returns true if the task has completed or has been cancelled.
+func (t *simTask) terminated() bool {
+	return t.status == taskSuccess || t.status == taskError || t.status == taskAborted
+}
+
+// Tasks returns the jobs and the tasks created by the simulator, in creation order.
+func (s *Simulator) Tasks() []SimulatorTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := make([]SimulatorTask, 0, len(s.taskOrder))
+	for _, id := range s.taskOrder {
+		t := s.tasks[id]
+		task := SimulatorTask{
+			ID:        t.id,
+			Operation: t.operation,
+			Status:    t.apiStatus(),
+		}
+		if t.err != nil {
+			task.Error = t.err.Error()
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// apiStatus returns the status of the task in the format of its API.
+func (t *simTask) apiStatus() string {
+	statuses := map[simTaskStatus]string{
+		taskQueued:  "CREATED",
+		taskRunning: "IN_PROGRESS",
+		taskSuccess: "DONE",
+		taskError:   "FAILED",
+		taskAborted: "FAILED",
+	}
+	if t.kind == vmwareTask {
+		statuses = map[simTaskStatus]string{
+			taskQueued:  "queued",
+			taskRunning: "running",
+			taskSuccess: "success",
+			taskError:   "error",
+			taskAborted: "aborted",
+		}
+	}
+	return statuses[t.status]
+}
+
+// writeJobCreated writes the response of the request creating a job.
+// Cerberus returns the ID of the job in the body, VMware the HREF of the task in the Location header.
+func writeJobCreated(w http.ResponseWriter, r *http.Request, kind simTaskKind, id, operation string) {
+	if kind == vmwareTask {
+		w.Header().Set("Location", baseURL(r)+"/api/task/"+id)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"jobId":   id,
+		"message": fmt.Sprintf("Job %s created successfully", operation),
+	})
+}
+
+// lookupTask returns the task of the request polled.
+func (s *Simulator) lookupTask(r *http.Request, kind simTaskKind) (*simTask, error) {
+	id := chi.URLParam(r, "taskId")
+	t, ok := s.tasks[id]
+	if !ok || t.kind != kind {
+		return nil, errNotFound("task %s not found", id)
+	}
+
+	t.poll(s.runningPolls)
+	return t, nil
+}
+
+// getJobCerberus returns the status of a Cerberus job.
+func (s *Simulator) getJobCerberus(r *http.Request) (any, error) {
+	t, err := s.lookupTask(r, cerberusJob)
+	if err != nil {
+		return nil, err
+	}
+
+	action := cav.CerberusJobAPIResponseAction{
+		Name:   t.operation,
+		Status: t.apiStatus(),
+	}
+	description := t.description
+
+	switch t.status {
+	case taskSuccess:
+		action.Details = t.details
+	case taskError:
+		action.Details = t.err.Error()
+		description = t.err.Error()
+	}
+
+	return cav.CerberusJobAPIResponse{
+		{
+			Actions:     []cav.CerberusJobAPIResponseAction{action},
+			Description: description,
+			Name:        t.operation,
+			Status:      t.apiStatus(),
+		},
+	}, nil
+}
+
+// cancelJobVmware cancels a VMware task, the operation of the task is not applied.
+// A task already terminated cannot be cancelled.
+func (s *Simulator) cancelJobVmware(r *http.Request) (any, error) {
+	id := chi.URLParam(r, "taskId")
+	t, ok := s.tasks[id]
+	if !ok || t.kind != vmwareTask {
+		return nil, errNotFound("task %s not found", id)
+	}
+
+	if t.terminated() {
+		return nil, errBadRequest("task %s is already terminated", id)
+	}
+
+	t.status = taskAborted
+	t.endTime = time.Now()
+	logger.Debug("Simulated task cancelled", "id", t.id, "operation", t.operation)
+	return nil, nil
+}
+
+// getJobVmware returns the status of a VMware task.
+func (s *Simulator) getJobVmware(r *http.Request) (any, error) {
+	t, err := s.lookupTask(r, vmwareTask)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := vmwareTaskResponse{
+		HREF:          baseURL(r) + "/api/task/" + t.id,
+		ID:            "urn:vcloud:task:" + t.id,
+		Name:          "task",
+		Status:        t.apiStatus(),
+		Operation:     t.description,
+		OperationName: t.operation,
+		StartTime:     t.startTime.Format(time.RFC3339),
+		Description:   t.description,
+	}
+
+	switch t.status {
+	case taskRunning:
+		resp.Progress = t.polls * 100 / (s.runningPolls + 1)
+	case taskSuccess:
+		resp.Progress = 100
+		resp.EndTime = t.endTime.Format(time.RFC3339)
+	case taskAborted:
+		resp.EndTime = t.endTime.Format(time.RFC3339)
+	case taskError:
+		resp.EndTime = t.endTime.Format(time.RFC3339)
+		resp.Error = &vmwareTaskFailure{
+			Message:        t.err.Error(),
+			MajorErrorCode: http.StatusBadRequest,
+			MinorErrorCode: "BAD_REQUEST",
+		}
+	}
+
+	return resp, nil
+}
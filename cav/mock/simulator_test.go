@@ -0,0 +1,491 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	edgegateway "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/edgegateway/v1"
+	vdc "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/vdc/v1"
+	vdcgroup "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/api/vdcgroup/v1"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav/mock"
+	cerrors "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+var simulatorFixture = mock.Fixture{
+	Vdcs: []mock.FixtureVdc{
+		{Name: "vdc-with-edge", CPUAllocated: 11000, MemoryAllocated: 16},
+		{Name: "vdc-empty"},
+	},
+	EdgeGateways: []mock.FixtureEdgeGateway{
+		{
+			Name:      "tn01e02ocb0001234spt101",
+			Owner:     "vdc-with-edge",
+			PublicIPs: []mock.FixturePublicIP{{IP: "195.25.13.4", Announced: true}},
+		},
+	},
+}
+
+func newSimulatorClients(t *testing.T, opts ...mock.SimulatorOptionFunc) (*mock.Simulator, *vdc.Client, *edgegateway.Client) {
+	t.Helper()
+
+	sim, err := mock.NewSimulator(opts...)
+	require.NoError(t, err)
+
+	mC, err := mock.NewClient(mock.WithSimulator(sim))
+	require.NoError(t, err)
+
+	vC, err := vdc.New(mC)
+	require.NoError(t, err)
+
+	eC, err := edgegateway.New(mC)
+	require.NoError(t, err)
+
+	return sim, vC, eC
+}
+
+func TestSimulator_CreateThenGetVDC(t *testing.T) {
+	_, vC, _ := newSimulatorClients(t)
+	ctx := context.Background()
+
+	created, err := vC.CreateVDC(ctx, types.ParamsCreateVDC{
+		Name:                "vdc-created",
+		Description:         "Created by the test",
+		ServiceClass:        "STD",
+		BillingModel:        "PAYG",
+		DisponibilityClass:  "ONE-ROOM",
+		StorageBillingModel: "PAYG",
+		Vcpu:                5,
+		Memory:              16,
+		StorageProfiles: []types.ParamsCreateVDCStorageProfile{
+			{Class: "silver", Limit: 100, Default: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "vdc-created", created.Name)
+
+	got, err := vC.GetVDC(ctx, types.ParamsGetVDC{ID: created.ID})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, got.ID)
+	assert.Equal(t, "Created by the test", got.Description)
+	assert.Equal(t, 5, got.ComputeCapacity.CPU.Limit)
+	assert.Equal(t, 16, got.ComputeCapacity.Memory.Limit)
+	require.Len(t, got.StorageProfiles, 1)
+	assert.Equal(t, "silver", got.StorageProfiles[0].Name)
+
+	// The name is unique.
+	_, err = vC.CreateVDC(ctx, types.ParamsCreateVDC{
+		Name:                "vdc-created",
+		ServiceClass:        "STD",
+		BillingModel:        "PAYG",
+		DisponibilityClass:  "ONE-ROOM",
+		StorageBillingModel: "PAYG",
+		Vcpu:                5,
+		Memory:              16,
+		StorageProfiles: []types.ParamsCreateVDCStorageProfile{
+			{Class: "silver", Limit: 100, Default: true},
+		},
+	})
+	assert.Error(t, err)
+
+	require.NoError(t, vC.DeleteVDC(ctx, types.ParamsDeleteVDC{Name: "vdc-created"}))
+	_, err = vC.GetVDC(ctx, types.ParamsGetVDC{Name: "vdc-created"})
+	assert.Error(t, err)
+}
+
+func TestSimulator_DeleteVDCWithEdgeGateway(t *testing.T) {
+	sim, vC, _ := newSimulatorClients(t, mock.WithFixture(simulatorFixture))
+	ctx := context.Background()
+
+	err := vC.DeleteVDC(ctx, types.ParamsDeleteVDC{Name: "vdc-with-edge"})
+	assert.ErrorContains(t, err, "owns the edge gateway")
+
+	_, err = vC.GetVDC(ctx, types.ParamsGetVDC{Name: "vdc-with-edge"})
+	assert.NoError(t, err)
+
+	require.NoError(t, vC.DeleteVDC(ctx, types.ParamsDeleteVDC{Name: "vdc-empty"}))
+
+	tasks := sim.Tasks()
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "deleteVdc", tasks[0].Operation)
+	assert.Equal(t, "DONE", tasks[0].Status)
+}
+
+func TestSimulator_EdgeGateway(t *testing.T) {
+	_, _, eC := newSimulatorClients(t, mock.WithFixture(simulatorFixture))
+	ctx := context.Background()
+
+	// The owner must exist.
+	_, err := eC.CreateEdgeGateway(ctx, types.ParamsCreateEdgeGateway{OwnerName: "vdc-unknown"})
+	assert.Error(t, err)
+
+	edge, err := eC.CreateEdgeGateway(ctx, types.ParamsCreateEdgeGateway{OwnerName: "vdc-empty"})
+	require.NoError(t, err)
+	require.NotNil(t, edge.OwnerRef)
+	assert.Equal(t, "vdc-empty", edge.OwnerRef.Name)
+
+	got, err := eC.GetEdgeGateway(ctx, types.ParamsEdgeGateway{ID: edge.ID})
+	require.NoError(t, err)
+	assert.Equal(t, edge.Name, got.Name)
+
+	ip, err := eC.CreatePublicIP(ctx, types.ParamsEdgeGateway{ID: edge.ID})
+	require.NoError(t, err)
+	assert.NotEmpty(t, ip.IP)
+
+	ips, err := eC.ListPublicIP(ctx, types.ParamsEdgeGateway{ID: edge.ID})
+	require.NoError(t, err)
+	require.Len(t, ips.PublicIPs, 1)
+	assert.Equal(t, ip.IP, ips.PublicIPs[0].IP)
+
+	// The public IP of the fixture is on the other edge gateway.
+	ips, err = eC.ListPublicIP(ctx, types.ParamsEdgeGateway{Name: "tn01e02ocb0001234spt101"})
+	require.NoError(t, err)
+	require.Len(t, ips.PublicIPs, 1)
+	assert.Equal(t, "195.25.13.4", ips.PublicIPs[0].IP)
+
+	require.NoError(t, eC.DeleteEdgeGateway(ctx, types.ParamsEdgeGateway{ID: edge.ID}))
+	_, err = eC.GetEdgeGateway(ctx, types.ParamsEdgeGateway{ID: edge.ID})
+	assert.Error(t, err)
+}
+
+func TestSimulator_RunningPolls(t *testing.T) {
+	sim, _, eC := newSimulatorClients(t, mock.WithFixture(simulatorFixture), mock.WithRunningPolls(1))
+	ctx := context.Background()
+
+	edge, err := eC.UpdateEdgeGateway(ctx, types.ParamsUpdateEdgeGateway{
+		Name:      "tn01e02ocb0001234spt101",
+		Bandwidth: 25,
+	})
+	require.NoError(t, err)
+
+	bandwidth, err := eC.GetBandwidth(ctx, types.ParamsEdgeGateway{ID: edge.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 25, bandwidth.Bandwidth)
+
+	tasks := sim.Tasks()
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "DONE", tasks[0].Status)
+}
+
+func TestSimulator_FixtureFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"vdcs": [{"name": "vdc-a"}, {"name": "vdc-b"}],
+		"vdcGroups": [{"name": "group-a", "vdcs": ["vdc-a", "vdc-b"]}],
+		"edgeGateways": [{"owner": "group-a", "cloudavenueServices": true}]
+	}`), 0o600))
+
+	_, vC, eC := newSimulatorClients(t, mock.WithFixtureFile(path))
+	ctx := context.Background()
+
+	vdcs, err := vC.ListVDC(ctx, types.ParamsListVDC{})
+	require.NoError(t, err)
+	assert.Len(t, vdcs.VDCS, 2)
+
+	edges, err := eC.ListEdgeGateway(ctx)
+	require.NoError(t, err)
+	require.Len(t, edges.EdgeGateways, 1)
+	assert.Equal(t, "group-a", edges.EdgeGateways[0].OwnerRef.Name)
+
+	svc, err := eC.GetCloudavenueServices(ctx, types.ParamsEdgeGateway{ID: edges.EdgeGateways[0].ID})
+	require.NoError(t, err)
+	assert.NotEmpty(t, svc.Network)
+}
+
+func TestSimulator_InvalidFixture(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture mock.Fixture
+	}{
+		{
+			name:    "VDC without name",
+			fixture: mock.Fixture{Vdcs: []mock.FixtureVdc{{}}},
+		},
+		{
+			name:    "Duplicate VDC",
+			fixture: mock.Fixture{Vdcs: []mock.FixtureVdc{{Name: "vdc"}, {Name: "vdc"}}},
+		},
+		{
+			name:    "Unknown VDC in VDC group",
+			fixture: mock.Fixture{VdcGroups: []mock.FixtureVdcGroup{{Name: "group", Vdcs: []string{"vdc"}}}},
+		},
+		{
+			name:    "Unknown owner of edge gateway",
+			fixture: mock.Fixture{EdgeGateways: []mock.FixtureEdgeGateway{{Owner: "vdc"}}},
+		},
+		{
+			name: "Duplicate public IP",
+			fixture: mock.Fixture{
+				Vdcs: []mock.FixtureVdc{{Name: "vdc"}},
+				EdgeGateways: []mock.FixtureEdgeGateway{
+					{Owner: "vdc", PublicIPs: []mock.FixturePublicIP{{IP: "195.25.13.4"}, {IP: "195.25.13.4"}}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mock.NewSimulator(mock.WithFixture(tt.fixture))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSimulator_ListPaginated(t *testing.T) {
+	t.Parallel()
+
+	// The VDCs are listed by pages of 100.
+	fixture := mock.Fixture{}
+	for i := range 120 {
+		fixture.Vdcs = append(fixture.Vdcs, mock.FixtureVdc{Name: fmt.Sprintf("vdc-%03d", i)})
+	}
+
+	sim, err := mock.NewSimulator(mock.WithFixture(fixture))
+	require.NoError(t, err)
+	mC := mock.NewTestClient(t, mock.WithSimulator(sim))
+
+	vC, err := vdc.New(mC)
+	require.NoError(t, err)
+
+	vdcs, err := vC.ListVDC(t.Context(), types.ParamsListVDC{})
+	require.NoError(t, err)
+	assert.Len(t, vdcs.VDCS, 120)
+	assert.Equal(t, "vdc-119", vdcs.VDCS[119].Name)
+	assert.Equal(t, 2, mC.CallCount("ListVdc"))
+
+	// The pages after the limit are not requested.
+	mC.ResetCalls()
+	vdcs, err = vC.ListVDC(t.Context(), types.ParamsListVDC{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, vdcs.VDCS, 10)
+	assert.Equal(t, 1, mC.CallCount("ListVdc"))
+
+	// The next page is not requested when the limit is the end of a page.
+	mC.ResetCalls()
+	vdcs, err = vC.ListVDC(t.Context(), types.ParamsListVDC{Limit: 100})
+	require.NoError(t, err)
+	assert.Len(t, vdcs.VDCS, 100)
+	assert.Equal(t, 1, mC.CallCount("ListVdc"))
+
+	// The names of the VDCs of a VDC group are resolved from all the pages.
+	gC, err := vdcgroup.New(mC)
+	require.NoError(t, err)
+
+	group, err := gC.CreateVdcGroup(t.Context(), types.ParamsCreateVdcGroup{
+		Name: "group-paginated",
+		Vdcs: []types.ParamsCreateVdcGroupVdc{{Name: "vdc-001"}, {Name: "vdc-110"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, group.Vdcs, 2)
+	assert.NotEmpty(t, group.Vdcs[1].ID)
+
+	_, err = gC.CreateVdcGroup(t.Context(), types.ParamsCreateVdcGroup{
+		Name: "group-unknown",
+		Vdcs: []types.ParamsCreateVdcGroupVdc{{Name: "vdc-unknown"}},
+	})
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+	require.ErrorContains(t, err, "vdc-unknown")
+
+	storageProfiles, err := vC.ListStorageProfile(t.Context(), types.ParamsListStorageProfile{})
+	require.NoError(t, err)
+	assert.Len(t, storageProfiles.VDCS, 120)
+	// The storage profiles are listed by pages of 30.
+	assert.Equal(t, 4, mC.CallCount("ListStorageProfile"))
+}
+
+func TestSimulator_ListFiltered(t *testing.T) {
+	t.Parallel()
+
+	sim, err := mock.NewSimulator(mock.WithFixture(mock.Fixture{
+		Vdcs: []mock.FixtureVdc{
+			{Name: "prod-a", Description: "production"},
+			{Name: "prod-b", StorageProfiles: []mock.FixtureStorageProfile{{Class: "gold"}, {Class: "silver"}}},
+			{Name: "dev-a", Description: "development"},
+		},
+	}))
+	require.NoError(t, err)
+	mC := mock.NewTestClient(t, mock.WithSimulator(sim))
+
+	vC, err := vdc.New(mC)
+	require.NoError(t, err)
+
+	vdcs, err := vC.ListVDC(t.Context(), types.ParamsListVDC{Filter: "name==prod-*"})
+	require.NoError(t, err)
+	require.Len(t, vdcs.VDCS, 2)
+	assert.Equal(t, "prod-a", vdcs.VDCS[0].Name)
+
+	vdcs, err = vC.ListVDC(t.Context(), types.ParamsListVDC{Filter: "numberOfStorageProfiles=gt=1,description==development"})
+	require.NoError(t, err)
+	require.Len(t, vdcs.VDCS, 2)
+	assert.Equal(t, "prod-b", vdcs.VDCS[0].Name)
+	assert.Equal(t, "dev-a", vdcs.VDCS[1].Name)
+
+	// The filter is combined with the name.
+	vdcs, err = vC.ListVDC(t.Context(), types.ParamsListVDC{Name: "dev-a", Filter: "name==prod-*"})
+	require.NoError(t, err)
+	assert.Empty(t, vdcs.VDCS)
+
+	// An invalid filter fails before the request.
+	mC.ResetCalls()
+	_, err = vC.ListVDC(t.Context(), types.ParamsListVDC{Filter: "serviceClass==STD"})
+	require.Error(t, err)
+	_, err = vC.ListVDC(t.Context(), types.ParamsListVDC{Filter: "numberOfVms=gt=many"})
+	require.Error(t, err)
+	mC.AssertNotCalled(t, "ListVdc")
+}
+
+func TestSimulator_AsyncJobs(t *testing.T) {
+	sim, vC, eC := newSimulatorClients(t, mock.WithFixture(mock.Fixture{
+		Vdcs: []mock.FixtureVdc{{Name: "vdc-a"}, {Name: "vdc-b"}, {Name: "vdc-c"}},
+	}))
+
+	group := cav.NewJobGroup()
+	ctx := cav.ContextWithRequestOptions(t.Context(), cav.WithAsyncJobs(group))
+
+	for _, name := range []string{"vdc-a", "vdc-b", "vdc-c"} {
+		require.NoError(t, vC.DeleteVDC(ctx, types.ParamsDeleteVDC{Name: name}))
+	}
+
+	// The jobs are returned before they are polled.
+	jobs := group.Jobs()
+	require.Len(t, jobs, 3)
+	for _, task := range sim.Tasks() {
+		assert.Equal(t, "CREATED", task.Status)
+	}
+	assert.Equal(t, cav.JobQueued, jobs[0].CurrentStatus())
+
+	// The commands returning a model wait for their job.
+	edge, err := eC.CreateEdgeGateway(ctx, types.ParamsCreateEdgeGateway{OwnerName: "vdc-c"})
+	require.NoError(t, err)
+	assert.Equal(t, "vdc-c", edge.OwnerRef.Name)
+	assert.Len(t, group.Jobs(), 3)
+
+	// vdc-c owns an edge gateway when its deletion is applied.
+	err = group.Wait(ctx)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "owns the edge gateway")
+
+	assert.Equal(t, cav.JobSuccess, jobs[0].CurrentStatus())
+	assert.Equal(t, cav.JobSuccess, jobs[1].CurrentStatus())
+	assert.Equal(t, cav.JobError, jobs[2].CurrentStatus())
+
+	_, err = vC.GetVDC(ctx, types.ParamsGetVDC{Name: "vdc-a"})
+	assert.Error(t, err)
+	_, err = vC.GetVDC(ctx, types.ParamsGetVDC{Name: "vdc-c"})
+	assert.NoError(t, err)
+}
+
+func TestSimulator_CancelJobs(t *testing.T) {
+	newClient := func(t *testing.T) (*mock.Simulator, cav.Client, *vdcgroup.Client) {
+		t.Helper()
+
+		sim, err := mock.NewSimulator(mock.WithFixture(mock.Fixture{
+			Vdcs:      []mock.FixtureVdc{{Name: "vdc-a"}, {Name: "vdc-b"}},
+			VdcGroups: []mock.FixtureVdcGroup{{Name: "group-a", Vdcs: []string{"vdc-a"}}},
+		}), mock.WithRunningPolls(1000))
+		require.NoError(t, err)
+
+		mC, err := mock.NewClient(mock.WithSimulator(sim))
+		require.NoError(t, err)
+
+		gC, err := vdcgroup.New(mC)
+		require.NoError(t, err)
+
+		return sim, mC, gC
+	}
+
+	t.Run("Polling stopped", func(t *testing.T) {
+		sim, _, gC := newClient(t)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+		defer cancel()
+		require.Error(t, gC.DeleteVdcGroup(ctx, types.ParamsDeleteVdcGroup{Name: "group-a"}))
+
+		// The task keeps running.
+		assert.Equal(t, "running", sim.Tasks()[0].Status)
+	})
+
+	t.Run("Task cancelled on context done", func(t *testing.T) {
+		sim, _, gC := newClient(t)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+		defer cancel()
+		ctx = cav.ContextWithRequestOptions(ctx, cav.WithJobCancelOnContextDone())
+
+		err := gC.DeleteVdcGroup(ctx, types.ParamsDeleteVdcGroup{Name: "group-a"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// The error reports the final state of the task.
+		var jobErr *cav.JobFailedError
+		require.ErrorAs(t, err, &jobErr)
+		assert.Equal(t, cav.JobAborted, jobErr.Job.Status)
+		assert.Equal(t, "aborted", sim.Tasks()[0].Status)
+
+		_, err = gC.GetVdcGroup(t.Context(), types.ParamsGetVdcGroup{Name: "group-a"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Asynchronous task cancelled", func(t *testing.T) {
+		sim, mC, gC := newClient(t)
+
+		group := cav.NewJobGroup()
+		ctx := cav.ContextWithRequestOptions(t.Context(), cav.WithAsyncJobs(group))
+		require.NoError(t, gC.DeleteVdcGroup(ctx, types.ParamsDeleteVdcGroup{Name: "group-a"}))
+		require.Len(t, group.Jobs(), 1)
+
+		job := group.Jobs()[0]
+		require.NoError(t, mC.CancelJob(t.Context(), job))
+		assert.Equal(t, cav.JobAborted, job.CurrentStatus())
+		assert.Equal(t, "aborted", sim.Tasks()[0].Status)
+
+		var jobErr *cav.JobFailedError
+		require.ErrorAs(t, job.Wait(t.Context()), &jobErr)
+
+		// A terminated job is not cancelled again.
+		require.NoError(t, mC.CancelJob(t.Context(), job))
+	})
+
+	t.Run("Asynchronous task cancelled on context done", func(t *testing.T) {
+		sim, _, gC := newClient(t)
+
+		group := cav.NewJobGroup()
+		ctx := cav.ContextWithRequestOptions(t.Context(), cav.WithAsyncJobs(group), cav.WithJobCancelOnContextDone())
+		require.NoError(t, gC.DeleteVdcGroup(ctx, types.ParamsDeleteVdcGroup{Name: "group-a"}))
+
+		waitCtx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, group.Wait(waitCtx), context.DeadlineExceeded)
+		assert.Equal(t, cav.JobAborted, group.Jobs()[0].CurrentStatus())
+		assert.Equal(t, "aborted", sim.Tasks()[0].Status)
+	})
+
+	t.Run("Cerberus job", func(t *testing.T) {
+		_, mC, _ := newClient(t)
+
+		vC, err := vdc.New(mC)
+		require.NoError(t, err)
+
+		group := cav.NewJobGroup()
+		ctx := cav.ContextWithRequestOptions(t.Context(), cav.WithAsyncJobs(group))
+		require.NoError(t, vC.DeleteVDC(ctx, types.ParamsDeleteVDC{Name: "vdc-b"}))
+		assert.ErrorContains(t, mC.CancelJob(t.Context(), group.Jobs()[0]), "cannot be cancelled")
+	})
+}
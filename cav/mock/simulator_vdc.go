@@ -0,0 +1,624 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package mock
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/common-go/generator"
+	"github.com/orange-cloudavenue/common-go/urn"
+)
+
+type (
+	// simVdc is a VDC. The CPU is in MHz, the memory in GiB.
+	simVdc struct {
+		id                  string
+		name                string
+		description         string
+		serviceClass        string
+		disponibilityClass  string
+		billingModel        string
+		storageBillingModel string
+		vcpuInMhz           int
+		cpuAllocated        int
+		memoryAllocated     int
+		storageProfiles     []*simStorageProfile
+	}
+
+	// simStorageProfile is a storage profile of a VDC. The limit and the used storage are in GiB.
+	simStorageProfile struct {
+		id        string
+		class     string
+		limit     int
+		used      int
+		isDefault bool
+	}
+
+	// simVdcGroup is a VDC group.
+	simVdcGroup struct {
+		id          string
+		name        string
+		description string
+		vdcs        []*simVdc
+	}
+)
+
+func newSimStorageProfile(class string, limit, used int, isDefault bool) *simStorageProfile {
+	return &simStorageProfile{
+		id:        generator.MustGenerate("{urn:vdcstorageProfile}"),
+		class:     class,
+		limit:     limit,
+		used:      used,
+		isDefault: isDefault,
+	}
+}
+
+// ensureDefaultStorageProfile makes the first storage profile the default one if there is none.
+func (v *simVdc) ensureDefaultStorageProfile() {
+	for _, sp := range v.storageProfiles {
+		if sp.isDefault {
+			return
+		}
+	}
+	if len(v.storageProfiles) > 0 {
+		v.storageProfiles[0].isDefault = true
+	}
+}
+
+// href returns the HREF of the VDC in the VMware API.
+func (v *simVdc) href(r *http.Request) string {
+	return baseURL(r) + "/api/vdc/" + urn.ExtractUUID(v.id)
+}
+
+func (s *Simulator) findVdcByName(name string) *simVdc {
+	for _, v := range s.vdcs {
+		if v.name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// findVdcByID returns the VDC by URN or UUID.
+func (s *Simulator) findVdcByID(id string) *simVdc {
+	for _, v := range s.vdcs {
+		if v.id == id || urn.ExtractUUID(v.id) == id {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) findVdcGroupByName(name string) *simVdcGroup {
+	for _, g := range s.vdcGroups {
+		if g.name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) findVdcGroupByID(id string) *simVdcGroup {
+	for _, g := range s.vdcGroups {
+		if g.id == id || urn.ExtractUUID(g.id) == id {
+			return g
+		}
+	}
+	return nil
+}
+
+// vdcDependents returns an error if the VDC is used by an edge gateway or a VDC group.
+func (s *Simulator) vdcDependents(vdc *simVdc) error {
+	for _, e := range s.edgeGateways {
+		if e.ownerVdc == vdc {
+			return errConflict("VDC %s cannot be deleted: it owns the edge gateway %s", vdc.name, e.name)
+		}
+	}
+	for _, g := range s.vdcGroups {
+		if slices.Contains(g.vdcs, vdc) {
+			return errConflict("VDC %s cannot be deleted: it is a member of the VDC group %s", vdc.name, g.name)
+		}
+	}
+	return nil
+}
+
+// * VDC
+
+// listVdc returns the VDCs matching the filter of the query.
+// The simulated VDCs have no VMs, vApps or disks.
+func (s *Simulator) listVdc(r *http.Request) (any, error) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := itypes.ApiResponseListVDC{
+		Records: make([]itypes.ApiResponseListVDCRecord, 0),
+	}
+	for _, v := range s.vdcs {
+		if !filter.match(map[string]string{
+			"name":                    v.name,
+			"id":                      v.id,
+			"description":             v.description,
+			"numberOfVms":             "0",
+			"numberOfRunningVms":      "0",
+			"numberOfDeployedVApps":   "0",
+			"numberOfStorageProfiles": strconv.Itoa(len(v.storageProfiles)),
+			"numberOfDisks":           "0",
+			"isEnabled":               "true",
+		}) {
+			continue
+		}
+
+		resp.Records = append(resp.Records, itypes.ApiResponseListVDCRecord{
+			HREF:                    v.href(r),
+			Name:                    v.name,
+			Description:             v.description,
+			NumberOfStorageProfiles: len(v.storageProfiles),
+		})
+	}
+
+	records, page := queryPage(r, resp.Records)
+	resp.Records = records
+
+	return struct {
+		itypes.ApiResponseListVDC
+		simQueryPage
+	}{resp, page}, nil
+}
+
+// lookupVdc returns the VDC of the path parameter vdc-id.
+func (s *Simulator) lookupVdc(r *http.Request) (*simVdc, error) {
+	id := chi.URLParam(r, "vdc-id")
+	vdc := s.findVdcByID(id)
+	if vdc == nil {
+		return nil, errNotFound("VDC %s not found", id)
+	}
+	return vdc, nil
+}
+
+// getVdc returns the VDC. The memory is returned in MiB as VMware does.
+func (s *Simulator) getVdc(r *http.Request) (any, error) {
+	vdc, err := s.lookupVdc(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := itypes.ApiResponseGetVDC{
+		ID:          vdc.id,
+		Name:        vdc.name,
+		Description: vdc.description,
+		IsEnabled:   true,
+		ComputeCapacity: itypes.ApiResponseGetVDCComputeCapacity{
+			CPU: itypes.ApiResponseGetVDCComputeCapacityDetails{
+				Units:     "MHz",
+				Limit:     vdc.cpuAllocated,
+				Allocated: vdc.cpuAllocated,
+			},
+			Memory: itypes.ApiResponseGetVDCComputeCapacityDetails{
+				Units:     "MB",
+				Limit:     vdc.memoryAllocated * 1024,
+				Allocated: vdc.memoryAllocated * 1024,
+			},
+		},
+		Networks: itypes.ApiResponseGetVDCNetworks{
+			Networks: make([]itypes.ApiResponseGetVDCNetwork, 0),
+		},
+		VCPUInMhz: vdc.vcpuInMhz,
+	}
+	for _, sp := range vdc.storageProfiles {
+		resp.StorageProfiles.StorageProfiles = append(resp.StorageProfiles.StorageProfiles, itypes.ApiResponseGetVDCStorageProfile{
+			ID:   sp.id,
+			Name: sp.class,
+		})
+	}
+
+	return resp, nil
+}
+
+// getVdcMetadata returns the Cloud Avenue settings of the VDC stored in its metadata.
+func (s *Simulator) getVdcMetadata(r *http.Request) (any, error) {
+	vdc, err := s.lookupVdc(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := itypes.ApiResponseGetVDCMetadatas{}
+	for key, value := range map[string]string{
+		"vdcBillingModel":        vdc.billingModel,
+		"vdcStorageBillingModel": vdc.storageBillingModel,
+		"vdcServiceClass":        vdc.serviceClass,
+		"vdcDisponibilityClass":  vdc.disponibilityClass,
+	} {
+		resp.Metadatas = append(resp.Metadatas, itypes.ApiResponseGetVDCMetadata{
+			Name:  key,
+			Value: itypes.ApiResponseGetVDCMetadataValue{Value: value},
+		})
+	}
+
+	return resp, nil
+}
+
+// createVdc creates the VDC with a Cerberus job.
+func (s *Simulator) createVdc(r *http.Request) (any, error) {
+	var req itypes.ApiRequestCreateVDC
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	if req.VDC.Name == "" {
+		return nil, errBadRequest("the name of the VDC is required")
+	}
+	if len(req.VDC.StorageProfiles) == 0 {
+		return nil, errBadRequest("at least one storage profile is required")
+	}
+
+	vdc := &simVdc{
+		id:                  generator.MustGenerate("{urn:vdc}"),
+		name:                req.VDC.Name,
+		description:         req.VDC.Description,
+		serviceClass:        req.VDC.ServiceClass,
+		disponibilityClass:  req.VDC.DisponibilityClass,
+		billingModel:        req.VDC.BillingModel,
+		storageBillingModel: req.VDC.StorageBillingModel,
+		vcpuInMhz:           req.VDC.VCPUInMhz,
+		cpuAllocated:        req.VDC.CPUAllocated,
+		memoryAllocated:     req.VDC.MemoryAllocated,
+	}
+	if vdc.vcpuInMhz == 0 {
+		return nil, errBadRequest("the vCPU frequency of the VDC is required")
+	}
+	for _, sp := range req.VDC.StorageProfiles {
+		vdc.storageProfiles = append(vdc.storageProfiles, newSimStorageProfile(sp.Class, sp.Limit, 0, sp.Default))
+	}
+	vdc.ensureDefaultStorageProfile()
+
+	return s.newTask(cerberusJob, "createVdc", "Create VDC "+vdc.name, vdc.name,
+		func() error {
+			if s.findVdcByName(vdc.name) != nil {
+				return errConflict("VDC %s already exists", vdc.name)
+			}
+			return nil
+		},
+		func() {
+			s.vdcs = append(s.vdcs, vdc)
+		})
+}
+
+// lookupVdcByName returns the VDC of the path parameter vdc-name.
+func (s *Simulator) lookupVdcByName(r *http.Request) (*simVdc, error) {
+	name := chi.URLParam(r, "vdc-name")
+	vdc := s.findVdcByName(name)
+	if vdc == nil {
+		return nil, errNotFound("VDC %s not found", name)
+	}
+	return vdc, nil
+}
+
+// updateVdc updates the VDC with a Cerberus job.
+// The storage profiles of the request are merged with the existing ones, a limit of 0 removes the profile.
+func (s *Simulator) updateVdc(r *http.Request) (any, error) {
+	vdc, err := s.lookupVdcByName(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req itypes.ApiRequestUpdateVDC
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	for _, sp := range req.VDC.StorageProfiles {
+		if sp.Limit != 0 {
+			continue
+		}
+		i := slices.IndexFunc(vdc.storageProfiles, func(current *simStorageProfile) bool { return current.class == sp.Class })
+		switch {
+		case i < 0:
+			return nil, errNotFound("storage profile %s not found in VDC %s", sp.Class, vdc.name)
+		case vdc.storageProfiles[i].isDefault:
+			return nil, errBadRequest("the default storage profile %s cannot be removed", sp.Class)
+		case vdc.storageProfiles[i].used > 0:
+			return nil, errBadRequest("the storage profile %s is not empty", sp.Class)
+		}
+	}
+
+	return s.newTask(cerberusJob, "updateVdc", "Update VDC "+vdc.name, vdc.name,
+		func() error {
+			if s.findVdcByName(vdc.name) != vdc {
+				return errNotFound("VDC %s not found", vdc.name)
+			}
+			return nil
+		},
+		func() {
+			vdc.description = defaultString(req.VDC.Description, vdc.description)
+			if req.VDC.CPUAllocated > 0 {
+				vdc.cpuAllocated = req.VDC.CPUAllocated
+			}
+			if req.VDC.MemoryAllocated > 0 {
+				vdc.memoryAllocated = req.VDC.MemoryAllocated
+			}
+			vdc.updateStorageProfiles(req.VDC.StorageProfiles)
+		})
+}
+
+// updateStorageProfiles adds, updates or removes (limit 0) the storage profiles.
+func (v *simVdc) updateStorageProfiles(profiles []itypes.ApiRequestVDCStorageProfile) {
+	for _, sp := range profiles {
+		i := slices.IndexFunc(v.storageProfiles, func(current *simStorageProfile) bool { return current.class == sp.Class })
+		switch {
+		case sp.Limit == 0:
+			if i >= 0 {
+				v.storageProfiles = slices.Delete(v.storageProfiles, i, i+1)
+			}
+			continue
+		case i < 0:
+			v.storageProfiles = append(v.storageProfiles, newSimStorageProfile(sp.Class, sp.Limit, 0, false))
+			i = len(v.storageProfiles) - 1
+		default:
+			v.storageProfiles[i].limit = sp.Limit
+		}
+
+		if sp.Default {
+			for _, current := range v.storageProfiles {
+				current.isDefault = false
+			}
+			v.storageProfiles[i].isDefault = true
+		}
+	}
+	v.ensureDefaultStorageProfile()
+}
+
+// deleteVdc deletes the VDC with a Cerberus job.
+// A VDC owning an edge gateway or member of a VDC group cannot be deleted.
+func (s *Simulator) deleteVdc(r *http.Request) (any, error) {
+	vdc, err := s.lookupVdcByName(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newTask(cerberusJob, "deleteVdc", "Delete VDC "+vdc.name, vdc.name,
+		func() error {
+			if s.findVdcByName(vdc.name) != vdc {
+				return errNotFound("VDC %s not found", vdc.name)
+			}
+			return s.vdcDependents(vdc)
+		},
+		func() {
+			s.vdcs = slices.DeleteFunc(s.vdcs, func(v *simVdc) bool { return v == vdc })
+		})
+}
+
+// * Storage profiles
+
+// listStorageProfile returns the storage profiles matching the filter of the query.
+// The storage is returned in MiB as VMware does.
+func (s *Simulator) listStorageProfile(r *http.Request) (any, error) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := itypes.ApiResponseListStorageProfiles{
+		StorageProfiles: make([]itypes.ApiResponseListStorageProfile, 0),
+	}
+	for _, v := range s.vdcs {
+		for _, sp := range v.storageProfiles {
+			if !filter.match(map[string]string{
+				"vdc":                     v.id,
+				"vdcName":                 v.name,
+				"name":                    sp.class,
+				"id":                      sp.id,
+				"isEnabled":               "true",
+				"isDefaultStorageProfile": strconv.FormatBool(sp.isDefault),
+			}) {
+				continue
+			}
+
+			resp.StorageProfiles = append(resp.StorageProfiles, itypes.ApiResponseListStorageProfile{
+				HREF:                    baseURL(r) + "/api/vdcStorageProfile/" + urn.ExtractUUID(sp.id),
+				Name:                    sp.class,
+				IsEnabled:               true,
+				IsDefaultStorageProfile: sp.isDefault,
+				Limit:                   sp.limit * 1024,
+				Used:                    sp.used * 1024,
+				VdcID:                   v.href(r),
+				VdcName:                 v.name,
+			})
+		}
+	}
+
+	storageProfiles, page := queryPage(r, resp.StorageProfiles)
+	resp.StorageProfiles = storageProfiles
+
+	return struct {
+		itypes.ApiResponseListStorageProfiles
+		simQueryPage
+	}{resp, page}, nil
+}
+
+// * VDC Group
+
+// listVdcGroup returns the VDC groups matching the filter of the query.
+func (s *Simulator) listVdcGroup(r *http.Request) (any, error) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := itypes.ApiResponseListVdcGroup{
+		Values: make([]itypes.ApiResponseListVdcGroupDetails, 0),
+	}
+	for _, g := range s.vdcGroups {
+		if !filter.match(map[string]string{"name": g.name, "id": g.id, "description": g.description}) {
+			continue
+		}
+
+		details := itypes.ApiResponseListVdcGroupDetails{
+			ID:          g.id,
+			OrgID:       s.org.id,
+			Name:        g.name,
+			Description: g.description,
+			Vdcs:        make([]itypes.ApiResponseVdcGroupParticipatingVdc, 0, len(g.vdcs)),
+		}
+		for _, v := range g.vdcs {
+			details.Vdcs = append(details.Vdcs, itypes.ApiResponseVdcGroupParticipatingVdc{
+				Vdc:                  itypes.ApiResponseVdcGroupParticipatingVdcRef{ID: v.id, Name: v.name},
+				FaultDomainTag:       v.disponibilityClass,
+				NetworkProviderScope: v.disponibilityClass,
+			})
+		}
+		resp.Values = append(resp.Values, details)
+	}
+
+	values, page := cloudAPIPage(r, resp.Values)
+	resp.Values = values
+
+	return struct {
+		itypes.ApiResponseListVdcGroup
+		simCloudAPIPage
+	}{resp, page}, nil
+}
+
+// participatingVdcs returns the VDCs of the request, by ID or by name.
+func (s *Simulator) participatingVdcs(refs []itypes.ApiResponseVdcGroupParticipatingVdc) ([]*simVdc, error) {
+	if len(refs) == 0 {
+		return nil, errBadRequest("at least one participating VDC is required")
+	}
+
+	vdcs := make([]*simVdc, 0, len(refs))
+	for _, ref := range refs {
+		vdc := s.findVdcByID(ref.Vdc.ID)
+		if vdc == nil {
+			vdc = s.findVdcByName(ref.Vdc.Name)
+		}
+		if vdc == nil {
+			return nil, errBadRequest("participating VDC %s not found", strings.TrimSpace(ref.Vdc.ID+" "+ref.Vdc.Name))
+		}
+		vdcs = append(vdcs, vdc)
+	}
+	return vdcs, nil
+}
+
+// createVdcGroup creates the VDC group with a VMware task.
+func (s *Simulator) createVdcGroup(r *http.Request) (any, error) {
+	var req itypes.ApiRequestCreateVdcGroup
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, errBadRequest("the name of the VDC group is required")
+	}
+
+	vdcs, err := s.participatingVdcs(req.Vdcs)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &simVdcGroup{
+		id:          generator.MustGenerate("{urn:vdcGroup}"),
+		name:        req.Name,
+		description: req.Description,
+		vdcs:        vdcs,
+	}
+
+	return s.newTask(vmwareTask, "vdcGroupCreate", "Creating VDC group "+group.name, group.name,
+		func() error {
+			if s.findVdcGroupByName(group.name) != nil {
+				return errConflict("VDC group %s already exists", group.name)
+			}
+			for _, v := range group.vdcs {
+				if s.findVdcByID(v.id) != v {
+					return errBadRequest("participating VDC %s not found", v.name)
+				}
+			}
+			return nil
+		},
+		func() {
+			s.vdcGroups = append(s.vdcGroups, group)
+		})
+}
+
+// lookupVdcGroup returns the VDC group of the path parameter vdcGroupId.
+func (s *Simulator) lookupVdcGroup(r *http.Request) (*simVdcGroup, error) {
+	id := chi.URLParam(r, "vdcGroupId")
+	group := s.findVdcGroupByID(id)
+	if group == nil {
+		return nil, errNotFound("VDC group %s not found", id)
+	}
+	return group, nil
+}
+
+// updateVdcGroup updates the name, the description and the VDCs of the VDC group with a VMware task.
+func (s *Simulator) updateVdcGroup(r *http.Request) (any, error) {
+	group, err := s.lookupVdcGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req itypes.ApiRequestUpdateVdcGroup
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	name := defaultString(req.Name, group.name)
+	vdcs, err := s.participatingVdcs(req.Vdcs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newTask(vmwareTask, "vdcGroupUpdate", "Updating VDC group "+group.name, group.name,
+		func() error {
+			if s.findVdcGroupByID(group.id) != group {
+				return errNotFound("VDC group %s not found", group.name)
+			}
+			if other := s.findVdcGroupByName(name); other != nil && other != group {
+				return errConflict("VDC group %s already exists", name)
+			}
+			return nil
+		},
+		func() {
+			group.name = name
+			group.description = req.Description
+			group.vdcs = vdcs
+		})
+}
+
+// deleteVdcGroup deletes the VDC group with a VMware task.
+// A VDC group owning an edge gateway cannot be deleted, even with force.
+func (s *Simulator) deleteVdcGroup(r *http.Request) (any, error) {
+	group, err := s.lookupVdcGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newTask(vmwareTask, "vdcGroupDelete", "Deleting VDC group "+group.name, group.name,
+		func() error {
+			if s.findVdcGroupByID(group.id) != group {
+				return errNotFound("VDC group %s not found", group.name)
+			}
+			for _, e := range s.edgeGateways {
+				if e.ownerVdcGroup == group {
+					return errBadRequest("VDC group %s cannot be deleted: it owns the edge gateway %s", group.name, e.name)
+				}
+			}
+			return nil
+		},
+		func() {
+			s.vdcGroups = slices.DeleteFunc(s.vdcGroups, func(g *simVdcGroup) bool { return g == group })
+		})
+}
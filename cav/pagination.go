@@ -0,0 +1,184 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"resty.dev/v3"
+)
+
+type (
+	// pageInfo is the pagination metadata of a page of a list endpoint of VMware Cloud Director.
+	// The /api/query endpoints return page, pageSize, total and a nextPage link in the body,
+	// the cloudapi endpoints return page, pageSize, pageCount and resultTotal
+	// and a nextPage link in the Link header.
+	pageInfo struct {
+		Page        int        `json:"page"`
+		PageSize    int        `json:"pageSize"`
+		Total       int        `json:"total"`
+		PageCount   int        `json:"pageCount"`
+		ResultTotal int        `json:"resultTotal"`
+		Links       []pageLink `json:"link"`
+	}
+
+	pageLink struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	}
+)
+
+// Paginate returns an iterator over the items of all the pages of a list endpoint of VMware Cloud Director.
+// The pages are requested one after the other while the iterator is consumed, so stopping
+// the loop stops the requests. items returns the items of a page from its response and
+// the request options are applied to the request of every page.
+// The iteration stops at the first error, which is yielded with the zero value of T.
+//
+// Example:
+//
+//	records := cav.Paginate(ctx, client, ep, func(resp *resty.Response) []itypes.ApiResponseListVDCRecord {
+//		return resp.Result().(*itypes.ApiResponseListVDC).Records
+//	})
+//	for record, err := range records {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Paginate[T any](ctx context.Context, client Client, endpoint *Endpoint, items func(resp *resty.Response) []T, opts ...EndpointRequestOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page := 1
+		for {
+			pageOpts := make([]EndpointRequestOption, 0, len(opts)+1)
+			pageOpts = append(pageOpts, opts...)
+			pageOpts = append(pageOpts, withPage(page))
+
+			resp, err := client.Do(ctx, endpoint, pageOpts...)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			pageItems := items(resp)
+			for _, item := range pageItems {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next, ok := nextPage(resp, page, len(pageItems))
+			if !ok {
+				return
+			}
+			page = next
+		}
+	}
+}
+
+// CollectPages returns the items of the iterator returned by Paginate.
+// If limit is greater than 0, at most limit items are returned and
+// the pages after the one of the last item are not requested.
+func CollectPages[T any](seq iter.Seq2[T, error], limit int) ([]T, error) {
+	items := make([]T, 0)
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// withPage sets the page to request. The page is a query parameter
+// accepted by all the list endpoints, it is not declared by the endpoints.
+func withPage(page int) EndpointRequestOption {
+	return SetCustomRestyOption(func(req *resty.Request) {
+		req.SetQueryParam("page", strconv.Itoa(page))
+		// The body is decoded a second time to read the pagination metadata.
+		req.SetResponseBodyUnlimitedReads(true)
+	})
+}
+
+// nextPage returns the page following the page of the response and false if it is the last page.
+// A page without items is the last page, whatever the pagination metadata say.
+func nextPage(resp *resty.Response, page, count int) (int, bool) {
+	if count == 0 {
+		return 0, false
+	}
+
+	info := pageInfo{}
+	if err := json.Unmarshal(resp.Bytes(), &info); err != nil {
+		// The response has no pagination metadata, it is a single page.
+		return 0, false
+	}
+	if info.Page > 0 {
+		page = info.Page
+	}
+
+	// The link to the next page is the most reliable, the totals may change between two pages.
+	for _, link := range info.Links {
+		if link.Rel == "nextPage" {
+			return pageFromHref(link.Href, page+1), true
+		}
+	}
+	for _, link := range resp.Header().Values("Link") {
+		if href, ok := nextPageFromHeader(link); ok {
+			return pageFromHref(href, page+1), true
+		}
+	}
+
+	switch {
+	case info.PageCount > 0:
+		return page + 1, page < info.PageCount
+	case info.PageSize > 0 && info.Total > 0:
+		return page + 1, page*info.PageSize < info.Total
+	case info.PageSize > 0 && info.ResultTotal > 0:
+		return page + 1, page*info.PageSize < info.ResultTotal
+	default:
+		return 0, false
+	}
+}
+
+// nextPageFromHeader returns the URL of the nextPage link of a Link header.
+// e.g. <https://example.com/cloudapi/1.0.0/edgeGateways?page=2&pageSize=25>;rel="nextPage";type="application/json"
+func nextPageFromHeader(header string) (string, bool) {
+	for link := range strings.SplitSeq(header, ",") {
+		parts := strings.Split(link, ";")
+		for _, p := range parts[1:] {
+			if strings.TrimSpace(p) == `rel="nextPage"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>"), true
+			}
+		}
+	}
+	return "", false
+}
+
+// pageFromHref returns the page of the query of the URL, or fallback if the URL has no page.
+// The page returned is never before fallback, so the pagination always moves forward.
+func pageFromHref(href string, fallback int) int {
+	u, err := url.Parse(href)
+	if err != nil {
+		return fallback
+	}
+	page, err := strconv.Atoi(u.Query().Get("page"))
+	if err != nil {
+		return fallback
+	}
+	return max(page, fallback)
+}
@@ -0,0 +1,217 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"resty.dev/v3"
+)
+
+const (
+	paginateItems    = 7
+	paginatePageSize = 3
+)
+
+var (
+	paginateQueryCalls    atomic.Int32
+	paginateCloudAPICalls atomic.Int32
+)
+
+type (
+	paginateItem struct {
+		Name string `json:"name"`
+	}
+
+	paginateQueryResponse struct {
+		Records []paginateItem `json:"record"`
+	}
+
+	paginateCloudAPIResponse struct {
+		Values []paginateItem `json:"values"`
+	}
+)
+
+// paginateTestPage returns the items of the page requested.
+func paginateTestPage(r *http.Request) (items []paginateItem, page int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	for i := (page-1)*paginatePageSize + 1; i <= min(page*paginatePageSize, paginateItems); i++ {
+		items = append(items, paginateItem{Name: fmt.Sprintf("item-%d", i)})
+	}
+	return items, page
+}
+
+func init() {
+	// The /api/query endpoints link the next page in the body.
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestPaginateQuery",
+		Description:      "Test paginate query",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/paginate/query",
+		BodyResponseType: paginateQueryResponse{},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paginateQueryCalls.Add(1)
+			items, page := paginateTestPage(r)
+
+			body := map[string]any{
+				"page":     page,
+				"pageSize": paginatePageSize,
+				"total":    paginateItems,
+				"record":   items,
+			}
+			if page*paginatePageSize < paginateItems {
+				body["link"] = []map[string]string{
+					{"rel": "nextPage", "href": fmt.Sprintf("https://example.com/api/query?page=%d&pageSize=%d", page+1, paginatePageSize)},
+				}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(body) //nolint:errcheck
+		}),
+	}.Register()
+
+	// The cloudapi endpoints return the number of pages.
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-openapi/v38.1/",
+		Name:             "TestPaginateCloudapi",
+		Description:      "Test paginate cloudapi",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/cloudapi/1.0.0/test/paginate",
+		BodyResponseType: paginateCloudAPIResponse{},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paginateCloudAPICalls.Add(1)
+			items, page := paginateTestPage(r)
+
+			if r.URL.Query().Get("error") != "" && page == 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+				"page":        page,
+				"pageSize":    paginatePageSize,
+				"pageCount":   (paginateItems + paginatePageSize - 1) / paginatePageSize,
+				"resultTotal": paginateItems,
+				"values":      items,
+			})
+		}),
+	}.Register()
+}
+
+func Test_Paginate(t *testing.T) {
+	c, err := newMockClient()
+	require.NoError(t, err)
+
+	queryItems := func(resp *resty.Response) []paginateItem {
+		return resp.Result().(*paginateQueryResponse).Records
+	}
+	cloudAPIItems := func(resp *resty.Response) []paginateItem {
+		return resp.Result().(*paginateCloudAPIResponse).Values
+	}
+
+	epQuery := MustGetEndpoint("TestPaginateQuery")
+	epCloudAPI := MustGetEndpoint("TestPaginateCloudapi")
+
+	// All the pages are requested.
+	paginateQueryCalls.Store(0)
+	items, err := CollectPages(Paginate(t.Context(), c, epQuery, queryItems), 0)
+	require.NoError(t, err)
+	assert.Len(t, items, paginateItems)
+	assert.Equal(t, "item-7", items[6].Name)
+	assert.Equal(t, int32(3), paginateQueryCalls.Load())
+
+	paginateCloudAPICalls.Store(0)
+	items, err = CollectPages(Paginate(t.Context(), c, epCloudAPI, cloudAPIItems), 0)
+	require.NoError(t, err)
+	assert.Len(t, items, paginateItems)
+	assert.Equal(t, int32(3), paginateCloudAPICalls.Load())
+
+	// The pages after the limit are not requested.
+	paginateQueryCalls.Store(0)
+	items, err = CollectPages(Paginate(t.Context(), c, epQuery, queryItems), 2)
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, int32(1), paginateQueryCalls.Load())
+
+	paginateQueryCalls.Store(0)
+	items, err = CollectPages(Paginate(t.Context(), c, epQuery, queryItems), 4)
+	require.NoError(t, err)
+	assert.Len(t, items, 4)
+	assert.Equal(t, int32(2), paginateQueryCalls.Load())
+
+	// The limit is the last item of the first page.
+	paginateQueryCalls.Store(0)
+	items, err = CollectPages(Paginate(t.Context(), c, epQuery, queryItems), 3)
+	require.NoError(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, int32(1), paginateQueryCalls.Load())
+
+	// The error of a page stops the iteration.
+	_, err = CollectPages(Paginate(t.Context(), c, epCloudAPI, cloudAPIItems, SetCustomRestyOption(func(req *resty.Request) {
+		req.SetQueryParam("error", "true")
+	})), 0)
+	require.Error(t, err)
+}
+
+func Test_nextPageFromHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "next page",
+			header: `<https://example.com/cloudapi/1.0.0/edgeGateways?page=2&pageSize=25>;rel="nextPage";type="application/json"`,
+			want:   "https://example.com/cloudapi/1.0.0/edgeGateways?page=2&pageSize=25",
+			wantOK: true,
+		},
+		{
+			name:   "several links",
+			header: `<https://example.com/cloudapi/1.0.0/edgeGateways?page=1>;rel="firstPage", <https://example.com/cloudapi/1.0.0/edgeGateways?page=3>;rel="nextPage"`,
+			want:   "https://example.com/cloudapi/1.0.0/edgeGateways?page=3",
+			wantOK: true,
+		},
+		{
+			name:   "last page",
+			header: `<https://example.com/cloudapi/1.0.0/edgeGateways?page=1>;rel="previousPage"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nextPageFromHeader(tt.header)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_pageFromHref(t *testing.T) {
+	assert.Equal(t, 3, pageFromHref("https://example.com/api/query?page=3", 2))
+	assert.Equal(t, 2, pageFromHref("https://example.com/api/query?pageSize=25", 2))
+	// The pagination never goes back.
+	assert.Equal(t, 2, pageFromHref("https://example.com/api/query?page=1", 2))
+}
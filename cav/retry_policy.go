@@ -0,0 +1,318 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/common-go/validators"
+)
+
+type (
+	// RetryPolicy defines how the failed requests are retried.
+	// The zero value of a field is replaced by its default value.
+	RetryPolicy struct {
+		// MaxAttempts is the maximum number of attempts of a request, including the first one.
+		// Set to 1 to disable the retries.
+		// Default is 5.
+		MaxAttempts int `default:"5" validate:"min=1"`
+
+		// WaitTime is the wait time before the first retry.
+		// The wait time is doubled after each retry (exponential backoff).
+		// Default is 1 second.
+		WaitTime time.Duration `default:"1s" validate:"gt=0"`
+
+		// MaxWaitTime is the maximum wait time between two attempts.
+		// Default is 30 seconds.
+		MaxWaitTime time.Duration `default:"30s" validate:"gtefield=WaitTime"`
+
+		// DisableJitter disables the random part of the wait time.
+		// The jitter prevents concurrent clients from retrying at the same time.
+		DisableJitter bool
+
+		// MaxBusyRetries is the maximum number of retries when the entity is busy
+		// (e.g. BUSY_ENTITY on VMware). These retries are not counted in MaxAttempts.
+		// Set to -1 to disable the retries on busy entities.
+		// Default is 30.
+		MaxBusyRetries int `default:"30" validate:"min=-1"`
+
+		// Budget is the maximum duration of a call including all its retries.
+		// No retry is done if the next attempt would start after the budget.
+		// Default is 0 (no budget).
+		Budget time.Duration `validate:"min=0"`
+
+		// OnRetry is called for each retry decision.
+		OnRetry RetryHookFunc
+	}
+
+	// RetryHookFunc is called for each retry decision.
+	RetryHookFunc func(ctx context.Context, decision RetryDecision)
+
+	// RetryDecision describes the decision taken after a failed attempt.
+	RetryDecision struct {
+		// Operation is the description of the endpoint.
+		Operation string
+		// Attempt is the number of the attempt that failed, starting at 1.
+		Attempt int
+		// StatusCode is the HTTP status code of the failed attempt (0 if no response was received).
+		StatusCode int
+		// Err is the error of the failed attempt, if any.
+		Err error
+		// Retry is true if the request is retried.
+		Retry bool
+		// Reason explains the decision.
+		Reason RetryReason
+		// Wait is the wait time before the next attempt.
+		Wait time.Duration
+	}
+
+	// RetryReason explains a retry decision.
+	RetryReason string
+)
+
+const (
+	// RetryReasonTransientError means the attempt failed with a network error or a transient HTTP status (429, 5xx).
+	RetryReasonTransientError RetryReason = "transient_error"
+	// RetryReasonBusyEntity means the entity targeted by the request is busy.
+	RetryReasonBusyEntity RetryReason = "busy_entity"
+	// RetryReasonEndpointCondition means a retry condition of the endpoint matched.
+	RetryReasonEndpointCondition RetryReason = "endpoint_condition"
+	// RetryReasonBusyRetriesExceeded means the entity is still busy after MaxBusyRetries retries.
+	RetryReasonBusyRetriesExceeded RetryReason = "busy_retries_exceeded"
+	// RetryReasonMaxAttemptsReached means the request failed MaxAttempts times.
+	RetryReasonMaxAttemptsReached RetryReason = "max_attempts_reached"
+	// RetryReasonBudgetExceeded means the next attempt would start after the budget of the call.
+	RetryReasonBudgetExceeded RetryReason = "budget_exceeded"
+	// RetryReasonNotRetryable means the error is not retryable.
+	RetryReasonNotRetryable RetryReason = "not_retryable"
+)
+
+// DefaultRetryPolicy returns the retry policy used when no policy is provided.
+func DefaultRetryPolicy() RetryPolicy {
+	// The defaults are set by the validator, the policy is always valid.
+	p, _ := newRetryPolicy(RetryPolicy{})
+	return p
+}
+
+// mockRetryPolicy is the retry policy of the mock client, with short wait times to keep the tests fast.
+func mockRetryPolicy() RetryPolicy {
+	p, _ := newRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+		WaitTime:    5 * time.Millisecond,
+		MaxWaitTime: 10 * time.Millisecond,
+	})
+	return p
+}
+
+// newRetryPolicy sets the default values of the policy and validates it.
+func newRetryPolicy(p RetryPolicy) (RetryPolicy, error) {
+	if err := validators.New().Struct(&p); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// backoff returns the wait time before the retry following the given attempt.
+// The wait time is doubled after each attempt and capped by maxWaitTime.
+// With jitter, the wait time is randomly chosen between half and all of it.
+func backoff(waitTime, maxWaitTime time.Duration, attempt int, jitter bool) time.Duration {
+	wait := time.Duration(math.Min(float64(maxWaitTime), float64(waitTime)*math.Exp2(float64(max(attempt-1, 0)))))
+	if jitter && wait > 1 {
+		half := wait / 2
+		wait = half + rand.N(wait-half+1) //nolint:gosec // The jitter does not need a secure random.
+	}
+	return wait
+}
+
+// retryState holds the retry state of a call.
+// It is stored in the request context to be shared by the retry condition and the retry strategy.
+type retryState struct {
+	policy    RetryPolicy
+	operation string
+	start     time.Time
+
+	busyRetries int
+	// nextWait is the wait time computed by the retry condition and returned by the retry strategy.
+	nextWait time.Duration
+}
+
+// retryCount returns the maximum number of retries of a request for resty.
+// The limits of the policy are enforced by the retry condition. resty does not evaluate
+// the retry conditions after its last retry, so one more retry is allowed to report
+// the decision of the last attempt.
+func (s *retryState) retryCount(busyRetries bool) int {
+	if busyRetries && s.policy.MaxBusyRetries > 0 {
+		return s.policy.MaxAttempts + s.policy.MaxBusyRetries
+	}
+	return s.policy.MaxAttempts
+}
+
+func newRetryState(policy RetryPolicy, operation string) *retryState {
+	return &retryState{
+		policy:    policy,
+		operation: operation,
+		start:     time.Now(),
+	}
+}
+
+// retryCondition returns the only retry condition of the request.
+// It decides whether the request is retried, computes the wait time and reports the decision to the hook.
+// busyCondition reports whether the entity is busy, it can be nil if the request is not concerned.
+func (s *retryState) retryCondition(busyCondition resty.RetryConditionFunc, conditions ...resty.RetryConditionFunc) resty.RetryConditionFunc {
+	return func(resp *resty.Response, err error) bool {
+		decision := RetryDecision{
+			Operation:  s.operation,
+			Attempt:    resp.Request.Attempt,
+			StatusCode: resp.StatusCode(),
+			Err:        err,
+			Reason:     RetryReasonNotRetryable,
+		}
+
+		busy := busyCondition != nil && busyCondition(resp, err)
+		switch {
+		case busy && s.policy.MaxBusyRetries >= 0 && s.busyRetries >= s.policy.MaxBusyRetries:
+			decision.Reason = RetryReasonBusyRetriesExceeded
+		case busy && s.policy.MaxBusyRetries < 0:
+			decision.Reason = RetryReasonNotRetryable
+		case busy:
+			decision.Retry, decision.Reason = true, RetryReasonBusyEntity
+		case isTransientError(resp, err):
+			decision.Retry, decision.Reason = true, RetryReasonTransientError
+		default:
+			for _, condition := range conditions {
+				if condition(resp, err) {
+					decision.Retry, decision.Reason = true, RetryReasonEndpointCondition
+					break
+				}
+			}
+		}
+
+		// The busy entity retries are not counted in MaxAttempts.
+		if decision.Retry && !busy && resp.Request.Attempt-s.busyRetries >= s.policy.MaxAttempts {
+			decision.Retry, decision.Reason = false, RetryReasonMaxAttemptsReached
+		}
+
+		if decision.Retry {
+			decision.Wait = s.wait(resp, busy)
+			if s.policy.Budget > 0 && time.Since(s.start)+decision.Wait > s.policy.Budget {
+				decision.Retry, decision.Reason, decision.Wait = false, RetryReasonBudgetExceeded, 0
+			}
+		}
+
+		if decision.Retry && busy {
+			s.busyRetries++
+		}
+
+		s.nextWait = decision.Wait
+
+		// The successful attempts are not reported.
+		if decision.Retry || err != nil || resp.IsError() {
+			s.notify(resp.Request.Context(), decision)
+		}
+
+		if call := getTelemetryCallFromContext(resp.Request.Context()); call != nil && decision.Retry {
+			call.retried(decision)
+		}
+
+		return decision.Retry
+	}
+}
+
+// wait returns the wait time before the next attempt.
+// The Retry-After header is used if the API returns it, as resty does.
+func (s *retryState) wait(resp *resty.Response, busy bool) time.Duration {
+	if resp.StatusCode() == http.StatusTooManyRequests || resp.StatusCode() == http.StatusServiceUnavailable {
+		if v := resp.Header().Get("Retry-After"); v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+			if date, err := http.ParseTime(v); err == nil {
+				return max(time.Until(date), 0)
+			}
+		}
+	}
+
+	// The busy entity retries are not counted in the attempts, so they use their own backoff.
+	attempt := resp.Request.Attempt - s.busyRetries
+	if busy {
+		attempt = s.busyRetries + 1
+	}
+
+	return backoff(s.policy.WaitTime, s.policy.MaxWaitTime, attempt, !s.policy.DisableJitter)
+}
+
+// notify logs the decision and calls the hook of the policy.
+func (s *retryState) notify(ctx context.Context, decision RetryDecision) {
+	xlogger.DebugContext(ctx, "Retry decision",
+		slog.String("operation", decision.Operation),
+		slog.Int("attempt", decision.Attempt),
+		slog.Int("statusCode", decision.StatusCode),
+		slog.Bool("retry", decision.Retry),
+		slog.String("reason", string(decision.Reason)),
+		slog.Duration("wait", decision.Wait),
+	)
+
+	if s.policy.OnRetry != nil {
+		s.policy.OnRetry(ctx, decision)
+	}
+}
+
+// retryStrategy is the retry strategy of the HTTP clients.
+// resty only uses the retry strategy of the client, so the wait time computed by the
+// retry condition of the request is retrieved from the request context.
+// The requests without retry state (e.g. the job polling) use an exponential backoff.
+func retryStrategy(resp *resty.Response, _ error) (time.Duration, error) {
+	if s := getRetryStateFromContext(resp.Request.Context()); s != nil {
+		return s.nextWait, nil
+	}
+
+	return backoff(resp.Request.RetryWaitTime, resp.Request.RetryMaxWaitTime, resp.Request.Attempt, true), nil
+}
+
+// isTransientError returns true if the attempt failed with an error that can be fixed by a retry:
+// a temporary network error, 429 Too Many Requests or a 5xx error except 501 Not Implemented.
+// Nothing is transient once the context of the request is done.
+func isTransientError(resp *resty.Response, err error) bool {
+	if resp != nil && resp.Request != nil && resp.Request.Context().Err() != nil {
+		// context.DeadlineExceeded is a net.Error, and resty would replace the error
+		// of the attempt (e.g. the error of the job) by the error of the context.
+		return false
+	}
+
+	if err != nil {
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) || errors.Is(err, context.Canceled) {
+			return false
+		}
+
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			return true
+		}
+	}
+
+	if resp == nil || resp.RawResponse == nil {
+		return false
+	}
+
+	status := resp.StatusCode()
+	return status == http.StatusTooManyRequests || (status >= 500 && status != http.StatusNotImplemented)
+}
@@ -0,0 +1,188 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	retryTransientCalls, retryBusyCalls, retryBudgetCalls atomic.Int32
+	retryDecisions                                        []RetryDecision
+)
+
+func recordRetryDecision(_ context.Context, d RetryDecision) {
+	retryDecisions = append(retryDecisions, d)
+}
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestRetryTransient",
+		Description:      "Test retry transient",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/retry/transient",
+		RetryPolicy:      &RetryPolicy{MaxAttempts: 3, WaitTime: time.Millisecond, MaxWaitTime: 2 * time.Millisecond, OnRetry: recordRetryDecision},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			// The first two calls fail with a transient error.
+			if retryTransientCalls.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`)) //nolint:errcheck
+		}),
+	}.Register()
+
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestRetryBusy",
+		Description:      "Test retry busy",
+		Method:           MethodPUT,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/retry/busy",
+		RetryPolicy:      &RetryPolicy{MaxAttempts: 1, WaitTime: time.Millisecond, MaxWaitTime: 2 * time.Millisecond, MaxBusyRetries: 2, OnRetry: recordRetryDecision},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			retryBusyCalls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"majorErrorCode":409,"minorErrorCode":"BUSY_ENTITY","message":"The entity is busy"}`)) //nolint:errcheck
+		}),
+	}.Register()
+
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestRetryBudget",
+		Description:      "Test retry budget",
+		Method:           MethodGET,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/retry/budget",
+		RetryPolicy:      &RetryPolicy{MaxAttempts: 10, WaitTime: 40 * time.Millisecond, MaxWaitTime: time.Second, DisableJitter: true, Budget: 100 * time.Millisecond, OnRetry: recordRetryDecision},
+		MockResponseFunc: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			retryBudgetCalls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}),
+	}.Register()
+}
+
+func Test_DefaultRetryPolicy(t *testing.T) {
+	p := DefaultRetryPolicy()
+	assert.Equal(t, 5, p.MaxAttempts)
+	assert.Equal(t, time.Second, p.WaitTime)
+	assert.Equal(t, 30*time.Second, p.MaxWaitTime)
+	assert.Equal(t, 30, p.MaxBusyRetries)
+	assert.Zero(t, p.Budget)
+	assert.False(t, p.DisableJitter)
+}
+
+func Test_WithRetryPolicy(t *testing.T) {
+	s := newSettings(mockOrg)
+
+	assert.Nil(t, WithRetryPolicy(RetryPolicy{MaxAttempts: 2})(s))
+	assert.Equal(t, 2, s.RetryPolicy.MaxAttempts)
+	assert.Equal(t, time.Second, s.RetryPolicy.WaitTime, "The zero values must be replaced by the default values")
+
+	assert.NotNil(t, WithRetryPolicy(RetryPolicy{WaitTime: time.Minute, MaxWaitTime: time.Second})(s), "MaxWaitTime lower than WaitTime must be rejected")
+	assert.NotNil(t, WithRetryPolicy(RetryPolicy{MaxBusyRetries: -2})(s))
+}
+
+func Test_Backoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(time.Second, 10*time.Second, 1, false))
+	assert.Equal(t, 2*time.Second, backoff(time.Second, 10*time.Second, 2, false))
+	assert.Equal(t, 4*time.Second, backoff(time.Second, 10*time.Second, 3, false))
+	assert.Equal(t, 10*time.Second, backoff(time.Second, 10*time.Second, 10, false))
+
+	for range 100 {
+		wait := backoff(time.Second, 10*time.Second, 3, true)
+		assert.GreaterOrEqual(t, wait, 2*time.Second)
+		assert.LessOrEqual(t, wait, 4*time.Second)
+	}
+}
+
+func Test_Do_RetryPolicy(t *testing.T) {
+	client, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+
+	tests := []struct {
+		name            string
+		endpoint        string
+		calls           *atomic.Int32
+		initialCalls    int32
+		expectedErr     bool
+		expectedCalls   int32
+		expectedReasons []RetryReason
+	}{
+		{
+			name:            "transient errors",
+			endpoint:        "TestRetryTransient",
+			calls:           &retryTransientCalls,
+			expectedCalls:   3,
+			expectedReasons: []RetryReason{RetryReasonTransientError, RetryReasonTransientError},
+		},
+		{
+			name:            "max attempts reached",
+			endpoint:        "TestRetryTransient",
+			calls:           &retryTransientCalls,
+			initialCalls:    -2,
+			expectedErr:     true,
+			expectedCalls:   1,
+			expectedReasons: []RetryReason{RetryReasonTransientError, RetryReasonTransientError, RetryReasonMaxAttemptsReached},
+		},
+		{
+			name:            "busy entity retries are capped",
+			endpoint:        "TestRetryBusy",
+			calls:           &retryBusyCalls,
+			expectedErr:     true,
+			expectedCalls:   3,
+			expectedReasons: []RetryReason{RetryReasonBusyEntity, RetryReasonBusyEntity, RetryReasonBusyRetriesExceeded},
+		},
+		{
+			name:            "budget exceeded",
+			endpoint:        "TestRetryBudget",
+			calls:           &retryBudgetCalls,
+			expectedErr:     true,
+			expectedCalls:   2,
+			expectedReasons: []RetryReason{RetryReasonTransientError, RetryReasonBudgetExceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.calls.Store(tt.initialCalls)
+			retryDecisions = nil
+
+			ep, err := GetEndpoint(tt.endpoint)
+			assert.Nil(t, err)
+
+			_, err = client.Do(t.Context(), ep)
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, tt.expectedCalls, tt.calls.Load())
+
+			reasons := make([]RetryReason, 0, len(retryDecisions))
+			for _, d := range retryDecisions {
+				assert.Equal(t, ep.Description, d.Operation)
+				reasons = append(reasons, d.Reason)
+			}
+			assert.Equal(t, tt.expectedReasons, reasons)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+)
+
+// ErrInvalidSessionStore is returned when the content of a session store cannot be
+// decrypted, because the passphrase is wrong or the data has been altered.
+var ErrInvalidSessionStore = errors.New("session store: invalid passphrase or corrupted data")
+
+type (
+	// SessionStore stores the sessions of the clients, so they can be reused by another
+	// client or another process without a new authentication.
+	// The sessions of several organizations can be stored in the same store.
+	//
+	// Implementations must be safe for concurrent use.
+	SessionStore interface {
+		// Load returns the session of the organization.
+		// It returns nil without error if no session is stored or if the session is expired.
+		Load(ctx context.Context, organization string) (*Session, error)
+
+		// Save stores the session of the organization, replacing the previous one.
+		Save(ctx context.Context, organization string, session Session) error
+
+		// Delete removes the session of the organization.
+		Delete(ctx context.Context, organization string) error
+	}
+
+	// Session contains the session data of the sub-clients of an organization.
+	Session struct {
+		// SubClients contains the session data of each sub-client, indexed by sub-client ID.
+		SubClients map[string]map[string]string `json:"subClients"`
+
+		// UpdatedAt is the time the session was saved.
+		UpdatedAt time.Time `json:"updatedAt"`
+
+		// ExpiresAt is the time after which the session is no longer returned by the store.
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+)
+
+// IsExpired returns true if the session is expired.
+func (s Session) IsExpired() bool {
+	return !s.ExpiresAt.IsZero() && !time.Now().Before(s.ExpiresAt)
+}
+
+// clone returns a deep copy of the session.
+func (s Session) clone() Session {
+	c := s
+	c.SubClients = make(map[string]map[string]string, len(s.SubClients))
+	for id, data := range s.SubClients {
+		c.SubClients[id] = maps.Clone(data)
+	}
+	return c
+}
+
+var _ SessionStore = (*memorySessionStore)(nil)
+
+// memorySessionStore stores the sessions in memory.
+type memorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]Session
+}
+
+// NewMemorySessionStore returns a session store keeping the sessions in memory.
+// It can be shared between the clients of the same process.
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{
+		sessions: make(map[string]Session),
+	}
+}
+
+// Load returns the session of the organization.
+func (m *memorySessionStore) Load(_ context.Context, organization string) (*Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, ok := m.sessions[organization]
+	if !ok || session.IsExpired() {
+		return nil, nil
+	}
+
+	s := session.clone()
+	return &s, nil
+}
+
+// Save stores the session of the organization.
+func (m *memorySessionStore) Save(_ context.Context, organization string, session Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[organization] = session.clone()
+	return nil
+}
+
+// Delete removes the session of the organization.
+func (m *memorySessionStore) Delete(_ context.Context, organization string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, organization)
+	return nil
+}
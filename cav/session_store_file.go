@@ -0,0 +1,299 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// fileSessionStoreVersion is the version of the file format.
+	fileSessionStoreVersion = 1
+	// fileSessionStoreKDFIterations is the number of PBKDF2-SHA256 iterations used to derive the key.
+	fileSessionStoreKDFIterations = 600_000
+	// fileSessionStoreSaltSize is the size of the random salt of the KDF.
+	fileSessionStoreSaltSize = 16
+	// fileSessionStoreAdditionalData authenticates the format of the encrypted data.
+	fileSessionStoreAdditionalData = "cloudavenue-sdk-go-v2/session-store/v1"
+
+	// fileSessionStoreLockTimeout is the maximum time to wait for the lock of the file.
+	fileSessionStoreLockTimeout = 10 * time.Second
+	// fileSessionStoreLockStale is the age after which a lock is considered left by a crashed process.
+	fileSessionStoreLockStale = 30 * time.Second
+)
+
+var _ SessionStore = (*fileSessionStore)(nil)
+
+type (
+	// fileSessionStore stores the sessions in an encrypted file.
+	//
+	// The sessions are encrypted with AES-256-GCM, the key is derived from the passphrase
+	// with PBKDF2-SHA256 and a random salt stored in the file.
+	// The file is locked during the updates and replaced atomically, so several processes
+	// can share the same file.
+	fileSessionStore struct {
+		path       string
+		passphrase []byte
+
+		// mu serializes the access to the file within the process and protects keys.
+		mu sync.Mutex
+		// keys caches the keys derived from the passphrase, indexed by salt.
+		keys map[string][]byte
+	}
+
+	// fileSessionStoreContent is the content of the file.
+	fileSessionStoreContent struct {
+		Version    int    `json:"version"`
+		Salt       []byte `json:"salt"`
+		Nonce      []byte `json:"nonce"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+)
+
+// NewFileSessionStore returns a session store keeping the sessions in an encrypted file.
+// The passphrase can have any length, the encryption key is derived from it.
+// The file is created on the first save.
+func NewFileSessionStore(path, passphrase string) (SessionStore, error) {
+	if path == "" {
+		return nil, errors.New("session store: path is required")
+	}
+	if passphrase == "" {
+		return nil, errors.New("session store: passphrase is required")
+	}
+
+	return &fileSessionStore{
+		path:       path,
+		passphrase: []byte(passphrase),
+		keys:       make(map[string][]byte),
+	}, nil
+}
+
+// Load returns the session of the organization.
+func (f *fileSessionStore) Load(_ context.Context, organization string) (*Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sessions, _, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	session, ok := sessions[organization]
+	if !ok || session.IsExpired() {
+		return nil, nil
+	}
+
+	return &session, nil
+}
+
+// Save stores the session of the organization.
+// The sessions of the other organizations are kept.
+func (f *fileSessionStore) Save(ctx context.Context, organization string, session Session) error {
+	return f.update(ctx, func(sessions map[string]Session) {
+		sessions[organization] = session
+	})
+}
+
+// Delete removes the session of the organization.
+func (f *fileSessionStore) Delete(ctx context.Context, organization string) error {
+	return f.update(ctx, func(sessions map[string]Session) {
+		delete(sessions, organization)
+	})
+}
+
+// update reads the sessions, applies fn and writes the sessions back.
+// The expired sessions are removed.
+func (f *fileSessionStore) update(ctx context.Context, fn func(map[string]Session)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	unlock, err := f.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	sessions, salt, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	fn(sessions)
+
+	for organization, session := range sessions {
+		if session.IsExpired() {
+			delete(sessions, organization)
+		}
+	}
+
+	return f.write(sessions, salt)
+}
+
+// read reads and decrypts the sessions of the file.
+// It returns the salt of the file, or nil if the file does not exist yet.
+// A file that cannot be parsed (e.g. created by an older version) is ignored.
+func (f *fileSessionStore) read() (map[string]Session, []byte, error) {
+	sessions := make(map[string]Session)
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return sessions, nil, nil
+		}
+		return nil, nil, fmt.Errorf("session store: %w", err)
+	}
+
+	var content fileSessionStoreContent
+	if err := json.Unmarshal(data, &content); err != nil || content.Version != fileSessionStoreVersion {
+		xlogger.Warn("Session store file has an unknown format, it will be overwritten", "path", f.path)
+		return sessions, nil, nil
+	}
+
+	gcm, err := f.cipher(content.Salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(content.Nonce) != gcm.NonceSize() {
+		return nil, nil, ErrInvalidSessionStore
+	}
+
+	plaintext, err := gcm.Open(nil, content.Nonce, content.Ciphertext, []byte(fileSessionStoreAdditionalData))
+	if err != nil {
+		return nil, nil, ErrInvalidSessionStore
+	}
+
+	if err := json.Unmarshal(plaintext, &sessions); err != nil {
+		return nil, nil, ErrInvalidSessionStore
+	}
+
+	return sessions, content.Salt, nil
+}
+
+// write encrypts the sessions and replaces the file atomically.
+// A new salt is generated if salt is nil.
+func (f *fileSessionStore) write(sessions map[string]Session, salt []byte) error {
+	if salt == nil {
+		salt = make([]byte, fileSessionStoreSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("session store: %w", err)
+		}
+	}
+
+	gcm, err := f.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(sessions)
+	if err != nil {
+		return fmt.Errorf("session store: %w", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("session store: %w", err)
+	}
+
+	data, err := json.Marshal(fileSessionStoreContent{
+		Version:    fileSessionStoreVersion,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, []byte(fileSessionStoreAdditionalData)),
+	})
+	if err != nil {
+		return fmt.Errorf("session store: %w", err)
+	}
+
+	// Write a temporary file and rename it, so the readers never see a partial file.
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("session store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("session store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("session store: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("session store: %w", err)
+	}
+
+	return nil
+}
+
+// cipher returns the AES-GCM cipher using the key derived from the passphrase and the salt.
+func (f *fileSessionStore) cipher(salt []byte) (cipher.AEAD, error) {
+	key, ok := f.keys[string(salt)]
+	if !ok {
+		var err error
+		key, err = pbkdf2.Key(sha256.New, string(f.passphrase), salt, fileSessionStoreKDFIterations, 32)
+		if err != nil {
+			return nil, fmt.Errorf("session store: %w", err)
+		}
+		f.keys[string(salt)] = key
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("session store: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// lock acquires the lock file shared with the other processes.
+// A lock older than fileSessionStoreLockStale is considered left by a crashed process and is removed.
+func (f *fileSessionStore) lock(ctx context.Context) (unlock func(), err error) {
+	lockPath := f.path + ".lock"
+
+	ctx, cancel := context.WithTimeout(ctx, fileSessionStoreLockTimeout)
+	defer cancel()
+
+	for {
+		lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			lockFile.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("session store: %w", err)
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > fileSessionStoreLockStale {
+			xlogger.Warn("Removing stale session store lock", "path", lockPath)
+			os.Remove(lockPath)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("session store: failed to acquire the lock %s: %w", lockPath, ctx.Err())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
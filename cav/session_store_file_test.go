@@ -0,0 +1,154 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFileSessionStore(t *testing.T) {
+	_, err := NewFileSessionStore("", "passphrase")
+	assert.NotNil(t, err)
+
+	_, err = NewFileSessionStore(filepath.Join(t.TempDir(), "sessions"), "")
+	assert.NotNil(t, err)
+
+	_, err = NewFileSessionStore(filepath.Join(t.TempDir(), "sessions"), "passphrase")
+	assert.Nil(t, err)
+}
+
+func TestFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions")
+
+	store, err := NewFileSessionStore(path, "passphrase")
+	assert.Nil(t, err)
+
+	// The file does not exist yet.
+	session, err := store.Load(t.Context(), "org1")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	assert.Nil(t, store.Save(t.Context(), "org1", newTestSession("bearer1", time.Hour)))
+	assert.Nil(t, store.Save(t.Context(), "org2", newTestSession("bearer2", time.Hour)))
+
+	// The file does not contain the session in clear text.
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "bearer1")
+
+	// Another store using the same file and passphrase reads both sessions.
+	other, err := NewFileSessionStore(path, "passphrase")
+	assert.Nil(t, err)
+
+	for org, bearer := range map[string]string{"org1": "bearer1", "org2": "bearer2"} {
+		session, err := other.Load(t.Context(), org)
+		assert.Nil(t, err)
+		assert.NotNil(t, session)
+		assert.Equal(t, bearer, session.SubClients["vmware"]["bearer"])
+	}
+
+	// Expired and deleted sessions are not returned.
+	assert.Nil(t, store.Save(t.Context(), "org1", newTestSession("bearer1", -time.Second)))
+	assert.Nil(t, store.Delete(t.Context(), "org2"))
+
+	for _, org := range []string{"org1", "org2"} {
+		session, err := other.Load(t.Context(), org)
+		assert.Nil(t, err)
+		assert.Nil(t, session)
+	}
+}
+
+func TestFileSessionStore_InvalidContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions")
+
+	store, err := NewFileSessionStore(path, "passphrase")
+	assert.Nil(t, err)
+	assert.Nil(t, store.Save(t.Context(), "org1", newTestSession("bearer1", time.Hour)))
+
+	// Wrong passphrase.
+	wrong, err := NewFileSessionStore(path, "wrong-passphrase")
+	assert.Nil(t, err)
+	_, err = wrong.Load(t.Context(), "org1")
+	assert.ErrorIs(t, err, ErrInvalidSessionStore)
+	assert.ErrorIs(t, wrong.Save(t.Context(), "org2", newTestSession("bearer2", time.Hour)), ErrInvalidSessionStore)
+
+	// Altered content.
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+
+	var content fileSessionStoreContent
+	assert.Nil(t, json.Unmarshal(data, &content))
+	content.Ciphertext[0] ^= 0xff
+	data, err = json.Marshal(content)
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(path, data, 0o600))
+
+	_, err = store.Load(t.Context(), "org1")
+	assert.ErrorIs(t, err, ErrInvalidSessionStore)
+}
+
+func TestFileSessionStore_UnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions")
+	assert.Nil(t, os.WriteFile(path, []byte("legacy cache content"), 0o600))
+
+	store, err := NewFileSessionStore(path, "passphrase")
+	assert.Nil(t, err)
+
+	// The file is ignored and overwritten.
+	session, err := store.Load(t.Context(), "org1")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	assert.Nil(t, store.Save(t.Context(), "org1", newTestSession("bearer1", time.Hour)))
+	session, err = store.Load(t.Context(), "org1")
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+}
+
+func TestFileSessionStore_ConcurrentSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions")
+
+	// Two stores on the same file simulate two processes.
+	stores := make([]SessionStore, 2)
+	for i := range stores {
+		store, err := NewFileSessionStore(path, "passphrase")
+		assert.Nil(t, err)
+		stores[i] = store
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			org := fmt.Sprintf("org%d", i)
+			assert.Nil(t, stores[i%2].Save(t.Context(), org, newTestSession("bearer-"+org, time.Hour)))
+		}()
+	}
+	wg.Wait()
+
+	// No session has been lost.
+	for i := range 10 {
+		session, err := stores[0].Load(t.Context(), fmt.Sprintf("org%d", i))
+		assert.Nil(t, err)
+		assert.NotNil(t, session)
+	}
+
+	_, err := os.Stat(path + ".lock")
+	assert.ErrorIs(t, err, os.ErrNotExist, "The lock must be released")
+}
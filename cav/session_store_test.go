@@ -0,0 +1,74 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSession(bearer string, ttl time.Duration) Session {
+	return Session{
+		SubClients: map[string]map[string]string{
+			"vmware": {"bearer": bearer},
+		},
+		UpdatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	store := NewMemorySessionStore()
+
+	// No session stored.
+	session, err := store.Load(t.Context(), "org1")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	// Sessions of several organizations.
+	assert.Nil(t, store.Save(t.Context(), "org1", newTestSession("bearer1", time.Hour)))
+	assert.Nil(t, store.Save(t.Context(), "org2", newTestSession("bearer2", time.Hour)))
+
+	session, err = store.Load(t.Context(), "org1")
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+	assert.Equal(t, "bearer1", session.SubClients["vmware"]["bearer"])
+
+	session, err = store.Load(t.Context(), "org2")
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+	assert.Equal(t, "bearer2", session.SubClients["vmware"]["bearer"])
+
+	// The returned session is a copy.
+	session.SubClients["vmware"]["bearer"] = "modified"
+	session, err = store.Load(t.Context(), "org2")
+	assert.Nil(t, err)
+	assert.Equal(t, "bearer2", session.SubClients["vmware"]["bearer"])
+
+	// Expired session.
+	assert.Nil(t, store.Save(t.Context(), "org1", newTestSession("bearer1", -time.Second)))
+	session, err = store.Load(t.Context(), "org1")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	// Deleted session.
+	assert.Nil(t, store.Delete(t.Context(), "org2"))
+	session, err = store.Load(t.Context(), "org2")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+}
+
+func TestSession_IsExpired(t *testing.T) {
+	assert.False(t, Session{}.IsExpired(), "A session without expiry date never expires")
+	assert.False(t, newTestSession("bearer", time.Hour).IsExpired())
+	assert.True(t, newTestSession("bearer", -time.Second).IsExpired())
+}
@@ -11,6 +11,8 @@ package cav
 
 import (
 	"context"
+	"net/http"
+	"sync"
 
 	"resty.dev/v3"
 
@@ -18,9 +20,13 @@ import (
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 )
 
-var subClients = map[subClientName]subClientInterface{
-	ClientVmware:   newVmwareClient(),
-	ClientCerberus: newCerberusClient(),
+// subClients contains the constructors of the sub-clients.
+// Each client creates its own sub-clients, they are not shared between clients.
+var subClients = map[subClientName]func() subClientInterface{
+	ClientVmware:    newVmwareClient,
+	ClientCerberus:  newCerberusClient,
+	ClientNetbackup: newNetbackupClient,
+	ClientS3:        newS3Client,
 }
 
 type subClientName string
@@ -29,22 +35,41 @@ const (
 	ClientVmware    subClientName = "vmware"
 	ClientCerberus  subClientName = "cerberus"
 	ClientNetbackup subClientName = "netbackup"
+	ClientS3        subClientName = "s3"
 )
 
 type subclient struct {
 	credential auth
 	console    consoles.ConsoleName
+
+	// httpC is the HTTP client shared by all the requests of the subclient.
+	httpC        *resty.Client
+	httpMu       sync.Mutex
+	httpSettings HTTPClientSettings
+
+	// mock is true if the subclient belongs to a mock client,
+	// its credential is requested from the mock server.
+	mock bool
 }
 
 type subClientInterface interface {
 	setCredential(auth)
 	getCredential() auth
 	setConsole(consoles.ConsoleName)
-	newHTTPClient(context.Context) (*resty.Client, error)
+	setHTTPClientSettings(HTTPClientSettings)
+	setMock(bool)
+	// httpClient returns the HTTP client shared by all the requests of the subclient.
+	httpClient(context.Context) (*resty.Client, error)
 
 	parseAPIError(operation string, resp *resty.Response) *errors.APIError
 	idempotentRetryCondition() resty.RetryConditionFunc
 
+	// sessionExpired returns true if the API rejected the response because
+	// the session (token) used for the request is expired or invalid.
+	sessionExpired(resp *resty.Response) bool
+	// renewCredential renews the credential after the API rejected the session used by resp.
+	renewCredential(ctx context.Context, operation string, resp *resty.Response) error
+
 	// getID returns the unique identifier for the subclient
 	getID() string
 
@@ -65,3 +90,32 @@ func (s *subclient) setCredential(a auth) {
 func (s *subclient) setConsole(console consoles.ConsoleName) {
 	s.console = console
 }
+
+// setMock marks the subclient as a subclient of a mock client.
+func (s *subclient) setMock(mock bool) {
+	s.mock = mock
+}
+
+// renewCredential renews the credential after the API rejected the session used by resp.
+// The credential serializes the renewals, so concurrent requests rejected with the same
+// session trigger only one Refresh.
+// If the renewal fails, an errors.AuthError is returned.
+func (s *subclient) renewCredential(ctx context.Context, operation string, resp *resty.Response) error {
+	rejected := http.Header{}
+	if resp != nil && resp.Request != nil {
+		rejected = resp.Request.Header
+	}
+
+	if s.mock {
+		ctx = storeMockInContext(ctx)
+	}
+
+	if err := s.credential.renew(ctx, rejected); err != nil {
+		return &errors.AuthError{
+			Operation: operation,
+			Err:       err,
+		}
+	}
+
+	return nil
+}
@@ -12,11 +12,11 @@ package cav
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 
 	"resty.dev/v3"
 
-	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/consoles"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 )
@@ -44,23 +44,14 @@ func (v *cerberus) getID() string {
 	return string(ClientCerberus)
 }
 
-// NewClient creates a new request for the Cerberus subclient.
-func (v *cerberus) newHTTPClient(ctx context.Context) (*resty.Client, error) {
-	hC := httpclient.NewHTTPClient().
-		SetBaseURL(v.console.GetAPICerberusEndpoint()).
-		SetHeader("Accept", "application/json;version="+cerberusVCDVersion).
-		SetError(cerberusError{})
-
-	if !v.credential.IsInitialized() {
-		if err := v.credential.Refresh(ctx); err != nil {
-			return nil, err
-		}
-	}
-
-	hC.
-		SetHeaders(v.credential.Headers())
-
-	return hC, nil
+// httpClient returns the HTTP client shared by the requests of the Cerberus subclient.
+func (v *cerberus) httpClient(ctx context.Context) (*resty.Client, error) {
+	return v.sharedHTTPClient(ctx, func(hC *resty.Client) {
+		hC.
+			SetBaseURL(v.console.GetAPICerberusEndpoint()).
+			SetHeader("Accept", "application/json;version="+cerberusVCDVersion).
+			SetError(cerberusError{})
+	})
 }
 
 // setCredential sets the authentication credential for the Cerberus client.
@@ -75,7 +66,7 @@ func (v *cerberus) setConsole(c consoles.ConsoleName) {
 
 // Close closes the Cerberus client and releases any resources.
 func (v *cerberus) close() error {
-	return nil
+	return v.closeHTTPClient()
 }
 
 // ParseAPIError parses the API error response from the Cerberus client.
@@ -94,6 +85,9 @@ func (v *cerberus) parseAPIError(operation string, resp *resty.Response) *errors
 			Duration:   resp.Duration(),
 			Endpoint:   resp.Request.URL,
 			Method:     resp.Request.Method,
+			Code:       err.Code,
+			Reason:     err.Reason,
+			Kind:       err.kind(),
 		}
 	}
 
@@ -117,12 +111,27 @@ func (v *cerberus) parseAPIError(operation string, resp *resty.Response) *errors
 //	}
 var regexCerberusJobAlreadyExists = regexp.MustCompile(`Job already exists`)
 
+// kind returns the sentinel error of the reason or of the message, nil if the
+// status code is enough to classify the error.
+func (e *cerberusError) kind() error {
+	switch {
+	case regexCerberusJobAlreadyExists.MatchString(e.Reason) || regexCerberusJobAlreadyExists.MatchString(e.Message):
+		return errors.ErrBusyEntity
+	case isQuotaExceeded(e.Reason) || isQuotaExceeded(e.Message):
+		return errors.ErrQuotaExceeded
+	}
+	return nil
+}
+
 // idempotentRetryCondition returns a retry condition function for idempotent operations.
 // Retries are triggered if the error message indicates that the job already exists.
 func (v *cerberus) idempotentRetryCondition() resty.RetryConditionFunc {
 	return func(resp *resty.Response, err error) bool {
-		if err, ok := resp.Error().(*cerberusError); ok {
-			return regexCerberusJobAlreadyExists.MatchString(err.Reason) || regexCerberusJobAlreadyExists.MatchString(err.Message)
+		// The error body is only decoded for the error responses.
+		if resp != nil && resp.IsError() {
+			if err, ok := resp.Error().(*cerberusError); ok {
+				return regexCerberusJobAlreadyExists.MatchString(err.Reason) || regexCerberusJobAlreadyExists.MatchString(err.Message)
+			}
 		}
 
 		if err != nil {
@@ -132,3 +141,25 @@ func (v *cerberus) idempotentRetryCondition() resty.RetryConditionFunc {
 		return false
 	}
 }
+
+// regexCerberusSessionExpired matches the error messages returned by Cerberus
+// when the token used for the request is no longer valid.
+var regexCerberusSessionExpired = regexp.MustCompile(`(?i)((session|token).*(expired|invalid|not found))|not authenticated`)
+
+// sessionExpired returns true if the response indicates that the session is expired or invalid.
+func (v *cerberus) sessionExpired(resp *resty.Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		if err, ok := resp.Error().(*cerberusError); ok {
+			return regexCerberusSessionExpired.MatchString(err.Reason) || regexCerberusSessionExpired.MatchString(err.Message)
+		}
+	}
+
+	return false
+}
@@ -32,6 +32,7 @@ func init() {
 		SubClient:        ClientCerberus,
 		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Jobs/getJobById",
 		PathTemplate:     "/api/customers/v1.0/jobs/{taskId}",
+		DisableCache:     true,
 		PathParams: []PathParam{
 			{
 				Name:        "taskId",
@@ -55,7 +56,7 @@ func init() {
 				}
 			}
 
-			if isMockClient {
+			if isMockFromContext(ctx) {
 				return r.Get(endpoint.MockPath())
 			}
 
@@ -98,7 +99,7 @@ func (v *cerberus) JobRefresh(httpC *resty.Client, resp *resty.Response, reqOpts
 
 	ep, err := GetEndpoint("GetJobCerberus")
 	if err != nil {
-		return nil, errors.New("failed to get endpoint for JobCerberus: " + err.Error())
+		return nil, errors.Newf("failed to get endpoint for JobCerberus: %w", err)
 	}
 
 	reqOpts = append(reqOpts,
@@ -111,8 +112,17 @@ func (v *cerberus) JobRefresh(httpC *resty.Client, resp *resty.Response, reqOpts
 	)
 
 	respR, err := ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	if v.sessionExpired(respR) {
+		// The session has expired during the job polling.
+		// Renew the credential and replay the polling with the new session.
+		if errAuth := v.renewCredential(resp.Request.Context(), ep.Description, respR); errAuth != nil {
+			return nil, errAuth
+		}
+
+		respR, err = ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	}
 	if err != nil {
-		return nil, errors.New("failed to refresh job status: " + err.Error())
+		return nil, errors.Newf("failed to refresh job status: %w", err)
 	}
 
 	return v.JobParser(respR)
@@ -162,20 +172,44 @@ func (v *cerberus) JobParser(resp *resty.Response) (job *Job, err error) {
 
 		status, err := v.JobStatusParser((*apiR)[0].Status)
 		if err != nil {
-			return nil, errors.New("failed to parse job status: " + err.Error())
+			return nil, errors.Newf("failed to parse job status: %w", err)
 		}
 
 		job.Status = status
 
+		done := 0
+		for _, a := range (*apiR)[0].Actions {
+			action := JobAction{
+				Name:    a.Name,
+				Status:  JobStatus(a.Status),
+				Details: a.Details,
+			}
+			if actionStatus, err := v.JobStatusParser(a.Status); err == nil {
+				action.Status = actionStatus
+			}
+			if action.Status == JobSuccess {
+				done++
+			}
+			job.Actions = append(job.Actions, action)
+		}
+
+		// Cerberus does not return a progress, it is computed from the actions done.
+		switch {
+		case status == JobSuccess:
+			job.Progress = 100
+		case len(job.Actions) > 0:
+			job.Progress = done * 100 / len(job.Actions)
+		}
+
 		if (*apiR)[0].Status == "FAILED" {
-			return job, &errors.APIError{
+			return job, &JobFailedError{Job: job, Err: &errors.APIError{
 				StatusCode:    resp.StatusCode(),
 				StatusMessage: status.String(),
 				Operation:     "Fetching job status",
 				Message:       (*apiR)[0].Description,
 				Duration:      resp.Duration(),
 				Endpoint:      resp.Request.URL,
-			}
+			}}
 		}
 
 		return job, nil
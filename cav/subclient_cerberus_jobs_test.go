@@ -14,7 +14,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 )
 
 func TestCerberusJobStatusParser(t *testing.T) {
@@ -93,6 +96,41 @@ func TestCerberusJobParser_NormalResponse(t *testing.T) {
 	assert.Equal(t, JobSuccess, job.Status)
 }
 
+func TestCerberusJobParser_Actions(t *testing.T) {
+	v := &cerberus{}
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: http.StatusOK,
+		},
+		Request: &resty.Request{
+			PathParams: map[string]string{"taskId": "id-123"},
+			URL:        "http://example.com/job",
+			Result: &CerberusJobAPIResponse{
+				{
+					Name:   "test-job",
+					Status: "FAILED",
+					Actions: []CerberusJobAPIResponseAction{
+						{Name: "create", Status: "DONE", Details: "created"},
+						{Name: "attach", Status: "FAILED", Details: "no capacity"},
+						{Name: "announce", Status: "CREATED"},
+					},
+				},
+			},
+		},
+	}
+	job, err := v.JobParser(resp)
+
+	var jobErr *JobFailedError
+	assert.ErrorAs(t, err, &jobErr)
+	assert.Same(t, job, jobErr.Job)
+	assert.Equal(t, []JobAction{
+		{Name: "create", Status: JobSuccess, Details: "created"},
+		{Name: "attach", Status: JobError, Details: "no capacity"},
+		{Name: "announce", Status: JobQueued},
+	}, job.Actions)
+	assert.Equal(t, 33, job.Progress)
+}
+
 func TestCerberusJobParser_FailedStatus(t *testing.T) {
 	v := &cerberus{}
 	resp := &resty.Response{
@@ -196,3 +234,37 @@ func TestCerberusJobParser_InvalidResponseType(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, job)
 }
+
+func TestCerberus_ParseAPIError(t *testing.T) {
+	v := &cerberus{}
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: http.StatusConflict,
+		},
+		Request: &resty.Request{
+			Method: http.MethodPost,
+			URL:    "http://example.com/api/customers/v2.0/vdcs",
+			Error: &cerberusError{
+				Code:    "cf-0002",
+				Reason:  "Job already exists",
+				Message: "A job is already running on the VDC",
+			},
+		},
+	}
+	err := v.parseAPIError("op", resp)
+
+	require.NotNil(t, err)
+	assert.Equal(t, "cf-0002", err.Code)
+	assert.Equal(t, "Job already exists", err.Reason)
+	require.ErrorIs(t, err, errors.ErrBusyEntity)
+	require.ErrorIs(t, err, errors.ErrConflict)
+
+	resp.Request.Error = &cerberusError{Code: "cf-0005", Message: "VDC quota exceeded for the organization"}
+	require.ErrorIs(t, v.parseAPIError("op", resp), errors.ErrQuotaExceeded)
+
+	resp.Request.Error = &cerberusError{Code: "cf-0006", Message: "Invalid quota value"}
+	assert.NotErrorIs(t, v.parseAPIError("op", resp), errors.ErrQuotaExceeded)
+
+	resp.Request.Error = &cerberusError{Code: "cf-0007", Reason: "Too many requests", Message: "Rate limit exceeded"}
+	assert.NotErrorIs(t, v.parseAPIError("op", resp), errors.ErrQuotaExceeded)
+}
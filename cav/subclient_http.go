@@ -0,0 +1,166 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"resty.dev/v3"
+
+	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
+)
+
+// HTTPClientSettings configures the HTTP client shared by all the requests of a sub-client.
+// The zero value uses the default connection pool settings.
+type HTTPClientSettings struct {
+	// Transport is a custom transport used to send the requests.
+	// If set, the connection pool settings below are ignored.
+	Transport http.RoundTripper
+
+	// MaxIdleConns is the maximum number of idle connections across all hosts. Default is 100.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections per host.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is the maximum amount of time an idle connection remains open. Default is 90 seconds.
+	IdleConnTimeout time.Duration
+	// KeepAlive is the interval between keep-alive probes of the active connections. Default is 30 seconds.
+	KeepAlive time.Duration
+	// DisableKeepAlives disables the reuse of the connections.
+	DisableKeepAlives bool
+
+	// cassette records or replays the requests, it is set by WithCassette.
+	cassette *Cassette
+}
+
+// newHTTPClient creates a new HTTP client from the settings.
+func (s HTTPClientSettings) newHTTPClient() *resty.Client {
+	var hC *resty.Client
+	if s.Transport != nil {
+		hC = httpclient.NewHTTPClientWithTransport(s.Transport)
+	} else {
+		hC = httpclient.NewPooledHTTPClient(&resty.TransportSettings{
+			MaxIdleConns:        s.MaxIdleConns,
+			MaxIdleConnsPerHost: s.MaxIdleConnsPerHost,
+			IdleConnTimeout:     s.IdleConnTimeout,
+			DialerKeepAlive:     s.KeepAlive,
+			DisableKeepAlives:   s.DisableKeepAlives,
+		})
+	}
+
+	if s.cassette != nil {
+		s.cassette.wrap(hC)
+	}
+
+	return hC
+}
+
+// setHTTPClientSettings sets the settings used to create the HTTP client of the subclient.
+// It must be called before the first request.
+func (s *subclient) setHTTPClientSettings(settings HTTPClientSettings) {
+	s.httpSettings = settings
+}
+
+// sharedHTTPClient returns the HTTP client of the subclient.
+// The client is created on the first call with configure and reused by all the following requests,
+// so the connections are kept alive between the requests.
+// The credential is refreshed if it is not initialized yet.
+func (s *subclient) sharedHTTPClient(ctx context.Context, configure func(*resty.Client)) (*resty.Client, error) {
+	s.httpMu.Lock()
+	if s.httpC == nil {
+		hC := s.httpSettings.newHTTPClient()
+		configure(hC)
+
+		// The middlewares are registered once on the shared client.
+		// The middlewares specific to a request are stored in the request context.
+		hC.
+			SetRetryStrategy(retryStrategy).
+			AddRequestMiddleware(s.authMiddleware).
+			AddRequestMiddleware(requestMiddlewaresDispatcher).
+			AddResponseMiddleware(responseMiddlewaresDispatcher)
+
+		s.httpC = hC
+	}
+	hC := s.httpC
+	s.httpMu.Unlock()
+
+	// The credential of a mock client is requested from the mock server.
+	if s.mock {
+		ctx = storeMockInContext(ctx)
+	}
+
+	// If the credential is not initialized, refresh it.
+	// This is necessary to ensure that the client has the latest authentication token.
+	if !s.credential.IsInitialized() {
+		if err := s.credential.Refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return hC, nil
+}
+
+// closeHTTPClient closes the HTTP client of the subclient and its idle connections.
+// A new client is created if the subclient is used again.
+func (s *subclient) closeHTTPClient() error {
+	s.httpMu.Lock()
+	defer s.httpMu.Unlock()
+
+	if s.httpC == nil {
+		return nil
+	}
+
+	s.httpC.Client().CloseIdleConnections()
+	err := s.httpC.Close()
+	s.httpC = nil
+
+	return err
+}
+
+// authMiddleware sets the authentication headers on each attempt of the request.
+// The headers are read at each attempt to use the session renewed by another request.
+func (s *subclient) authMiddleware(_ *resty.Client, r *resty.Request) error {
+	r.SetHeaders(s.credential.Headers())
+	return nil
+}
+
+// requestMiddlewares holds the middlewares specific to a request.
+type requestMiddlewares struct {
+	request  []resty.RequestMiddleware
+	response []resty.ResponseMiddleware
+}
+
+// requestMiddlewaresDispatcher runs the request middlewares stored in the request context.
+func requestMiddlewaresDispatcher(c *resty.Client, r *resty.Request) error {
+	for _, mw := range getRequestMiddlewaresFromContext(r.Context()).request {
+		if err := mw(c, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// responseMiddlewaresDispatcher runs the response middlewares stored in the request context.
+// As with the resty client, all the middlewares are run even if one of them fails.
+func responseMiddlewaresDispatcher(c *resty.Client, resp *resty.Response) error {
+	var errs []error
+	for _, mw := range getRequestMiddlewaresFromContext(resp.Request.Context()).response {
+		if err := mw(c, resp); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,117 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"resty.dev/v3"
+)
+
+func Test_SubClient_HTTPClientReused(t *testing.T) {
+	c, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+
+	sc, err := c.(*client).identifyClient(t.Context(), ClientVmware)
+	assert.Nil(t, err)
+
+	hC1, err := sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	hC2, err := sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.Same(t, hC1, hC2, "The HTTP client must be reused between the requests")
+
+	// Each client has its own sub-clients and HTTP clients.
+	other, err := newMockClient()
+	assert.Nil(t, err, "Error creating mock client")
+	otherSC, err := other.(*client).identifyClient(t.Context(), ClientVmware)
+	assert.Nil(t, err)
+	assert.NotSame(t, sc, otherSC)
+
+	otherHC, err := otherSC.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.NotSame(t, hC1, otherHC)
+
+	// After Close, a new HTTP client is created on the next request.
+	assert.Nil(t, c.Close())
+	hC3, err := sc.httpClient(t.Context())
+	assert.Nil(t, err)
+	assert.NotSame(t, hC1, hC3)
+}
+
+func Test_WithHTTPClientSettings(t *testing.T) {
+	httpSettings := HTTPClientSettings{
+		MaxIdleConns:        20,
+		MaxIdleConnsPerHost: 7,
+		IdleConnTimeout:     10 * time.Second,
+	}
+
+	c, err := NewClient(mockOrg, WithCloudAvenueCredential("mockuser", "mockpassword"), WithHTTPClientSettings(httpSettings))
+	assert.Nil(t, err)
+
+	for _, name := range []subClientName{ClientVmware, ClientCerberus} {
+		sc, err := c.(*client).identifyClient(t.Context(), name)
+		assert.Nil(t, err)
+
+		switch v := sc.(type) {
+		case *vmware:
+			assert.Equal(t, httpSettings, v.httpSettings)
+		case *cerberus:
+			assert.Equal(t, httpSettings, v.httpSettings)
+		}
+	}
+
+	transport, ok := httpSettings.newHTTPClient().Transport().(*http.Transport)
+	assert.True(t, ok)
+	assert.Equal(t, 20, transport.MaxIdleConns)
+	assert.Equal(t, 7, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 10*time.Second, transport.IdleConnTimeout)
+
+	customTransport := &http.Transport{}
+	assert.Same(t, customTransport, HTTPClientSettings{Transport: customTransport}.newHTTPClient().Transport())
+}
+
+func Test_MiddlewaresDispatcher(t *testing.T) {
+	var calls []string
+	errMiddleware := errors.New("middleware error")
+
+	ctx := storeRequestMiddlewaresInContext(t.Context(), requestMiddlewares{
+		request: []resty.RequestMiddleware{
+			func(_ *resty.Client, _ *resty.Request) error {
+				calls = append(calls, "request")
+				return nil
+			},
+		},
+		response: []resty.ResponseMiddleware{
+			func(_ *resty.Client, _ *resty.Response) error {
+				calls = append(calls, "response1")
+				return errMiddleware
+			},
+			func(_ *resty.Client, _ *resty.Response) error {
+				calls = append(calls, "response2")
+				return nil
+			},
+		},
+	})
+
+	r := resty.New().R().SetContext(ctx)
+	assert.Nil(t, requestMiddlewaresDispatcher(nil, r))
+	assert.ErrorIs(t, responseMiddlewaresDispatcher(nil, &resty.Response{Request: r}), errMiddleware)
+	assert.Equal(t, []string{"request", "response1", "response2"}, calls)
+
+	// Without middlewares in the context, nothing is run.
+	r = resty.New().R().SetContext(t.Context())
+	assert.Nil(t, requestMiddlewaresDispatcher(nil, r))
+	assert.Nil(t, responseMiddlewaresDispatcher(nil, &resty.Response{Request: r}))
+}
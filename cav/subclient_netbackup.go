@@ -0,0 +1,154 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+)
+
+var _ subClientInterface = &netbackup{}
+
+type netbackup struct {
+	subclient
+}
+
+type netbackupError struct {
+	Message       string `json:"Message" fake:"{sentence:3,10}"`
+	MessageDetail string `json:"MessageDetail,omitempty" fake:"{sentence:3,10}"`
+}
+
+var newNetbackupClient = func() subClientInterface {
+	return &netbackup{}
+}
+
+// netbackupAPIPath is the path prefix of the Netbackup self-service API.
+const netbackupAPIPath = "/NetBackupSelfService/Api/v6"
+
+// getID returns the unique identifier for the subclient
+func (v *netbackup) getID() string {
+	return string(ClientNetbackup)
+}
+
+// httpClient returns the HTTP client shared by the requests of the Netbackup subclient.
+func (v *netbackup) httpClient(ctx context.Context) (*resty.Client, error) {
+	return v.sharedHTTPClient(ctx, func(hC *resty.Client) {
+		hC.
+			SetBaseURL(v.console.GetNetbackupEndpoint()).
+			SetHeader("Accept", "application/json").
+			SetError(netbackupError{})
+	})
+}
+
+// Close closes the Netbackup client and releases any resources.
+func (v *netbackup) close() error {
+	return v.closeHTTPClient()
+}
+
+// ParseAPIError parses the API error response from the Netbackup client.
+func (v *netbackup) parseAPIError(operation string, resp *resty.Response) *errors.APIError {
+	if resp == nil || !resp.IsError() {
+		return nil
+	}
+
+	// If resp.Error() is not nil, it means an error occurred.
+	// Parse the error response body.
+	if err, ok := resp.Error().(*netbackupError); ok {
+		message := err.Message
+		if err.MessageDetail != "" {
+			message = fmt.Sprintf("%s: %s", err.Message, err.MessageDetail)
+		}
+
+		return &errors.APIError{
+			Operation:  operation,
+			StatusCode: resp.StatusCode(),
+			Message:    message,
+			Duration:   resp.Duration(),
+			Endpoint:   resp.Request.URL,
+			Method:     resp.Request.Method,
+			Kind:       err.kind(),
+		}
+	}
+
+	// This is used to prevent nil pointer dereference if SetError() was not called or overrided by other object.
+	return &errors.APIError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode(),
+		Message:    "Unknown error occurred",
+		Duration:   resp.Duration(),
+		Endpoint:   resp.Request.URL,
+		Method:     resp.Request.Method,
+	}
+}
+
+// Regexp to match the error message indicating that another operation
+// is in progress on the protected machine.
+//
+//	{
+//	   "Message": "Another operation is already in progress on this machine."
+//	}
+var regexNetbackupOperationInProgress = regexp.MustCompile(`(?i)already in progress`)
+
+// kind returns the sentinel error of the message, nil if the status code is enough
+// to classify the error. Netbackup does not return error codes.
+func (e *netbackupError) kind() error {
+	if regexNetbackupOperationInProgress.MatchString(e.Message) || regexNetbackupOperationInProgress.MatchString(e.MessageDetail) {
+		return errors.ErrBusyEntity
+	}
+	return nil
+}
+
+// idempotentRetryCondition returns a retry condition function for idempotent operations.
+// Retries are triggered if another operation is in progress on the same machine.
+func (v *netbackup) idempotentRetryCondition() resty.RetryConditionFunc {
+	return func(resp *resty.Response, err error) bool {
+		if resp == nil || resp.StatusCode() != http.StatusConflict {
+			return false
+		}
+
+		if apiErr, ok := resp.Error().(*netbackupError); ok {
+			return regexNetbackupOperationInProgress.MatchString(apiErr.Message) || regexNetbackupOperationInProgress.MatchString(apiErr.MessageDetail)
+		}
+
+		if err != nil {
+			return regexNetbackupOperationInProgress.MatchString(err.Error())
+		}
+
+		return false
+	}
+}
+
+// regexNetbackupSessionExpired matches the error messages returned by Netbackup
+// when the token used for the request is no longer valid.
+var regexNetbackupSessionExpired = regexp.MustCompile(`(?i)((session|token).*(expired|invalid))|authorization has been denied`)
+
+// sessionExpired returns true if the response indicates that the token is expired or invalid.
+func (v *netbackup) sessionExpired(resp *resty.Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		if err, ok := resp.Error().(*netbackupError); ok {
+			return regexNetbackupSessionExpired.MatchString(err.Message)
+		}
+	}
+
+	return false
+}
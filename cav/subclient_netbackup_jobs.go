@@ -0,0 +1,191 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+	"github.com/orange-cloudavenue/common-go/validators"
+)
+
+func init() {
+	Endpoint{
+		Name:             "GetJobNetbackup",
+		Description:      "Get Netbackup Job",
+		Method:           MethodGET,
+		SubClient:        ClientNetbackup,
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/getJob",
+		PathTemplate:     netbackupAPIPath + "/jobs/{jobId}",
+		DisableCache:     true,
+		PathParams: []PathParam{
+			{
+				Name:        "jobId",
+				Description: "The identifier of the job to retrieve.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,number")
+				},
+			},
+		},
+		QueryParams: []QueryParam{},
+		RequestFunc: nil, // Will be set later in the Register function.
+		requestInternalFunc: func(ctx context.Context, client *resty.Client, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
+			r := client.R().
+				SetContext(ctx).
+				SetHeader("Accept", "application/json")
+
+			for _, opt := range opts {
+				if err := opt(endpoint, r); err != nil {
+					return nil, err
+				}
+			}
+
+			if isMockFromContext(ctx) {
+				return r.Get(endpoint.MockPath())
+			}
+
+			return r.Get(endpoint.PathTemplate)
+		},
+		BodyRequestType:  nil, // No request body for this endpoint.
+		BodyResponseType: netbackupJobAPIResponse{},
+	}.Register()
+}
+
+// Ensure netbackup implements the jobs interface.
+var _ jobsInterface = &netbackup{}
+
+// netbackupJobAPIResponse represents an asynchronous operation in Netbackup.
+type netbackupJobAPIResponse struct {
+	ID           int    `json:"Id" fake:"{number:1,100000}"`              // The identifier of the job.
+	Name         string `json:"Name" fake:"{word}"`                       // The name of the job.
+	Description  string `json:"Description" fake:"{sentence}"`            // The description of the operation tracked by the job.
+	Status       string `json:"Status" fake:"Successful"`                 // Status of the job. One of Queued, Running, Successful, Failed, Canceled.
+	ErrorMessage string `json:"ErrorMessage,omitempty" fake:"-"`          // The error message if the job failed.
+	Progress     int    `json:"Progress,omitempty" fake:"{number:0,100}"` // Progress of the job as a percentage between 0 and 100.
+}
+
+// JobRefresh is a function type that defines how to refresh a job status.
+func (v *netbackup) JobRefresh(httpC *resty.Client, resp *resty.Response, reqOpts []EndpointRequestOption) (job *Job, err error) {
+	job, err = v.JobParser(resp)
+	if err != nil {
+		return job, err
+	}
+
+	ep, err := GetEndpoint("GetJobNetbackup")
+	if err != nil {
+		return nil, errors.Newf("failed to get endpoint for GetJobNetbackup: %w", err)
+	}
+
+	reqOpts = append(reqOpts,
+		SetCustomRestyOption(func(r *resty.Request) { r.SetError(&netbackupError{}) }),
+		WithPathParam(ep.PathParams[0], job.ID),
+		OverrideSetResult(netbackupJobAPIResponse{}),
+	)
+
+	respR, err := ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	if v.sessionExpired(respR) {
+		// The token has expired during the job polling.
+		// Renew the credential and replay the polling with the new token.
+		if errAuth := v.renewCredential(resp.Request.Context(), ep.Description, respR); errAuth != nil {
+			return nil, errAuth
+		}
+
+		respR, err = ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	}
+	if err != nil {
+		return nil, errors.Newf("failed to refresh job status: %w", err)
+	}
+
+	return v.JobParser(respR)
+}
+
+// JobParser parses the job response and extracts the job information.
+// Netbackup returns the location of the job in the Location header
+// when an asynchronous operation is accepted.
+func (v *netbackup) JobParser(resp *resty.Response) (job *Job, err error) {
+	if resp == nil {
+		return job, errors.New("no response to parse")
+	}
+
+	// Example: /NetBackupSelfService/Api/v6/jobs/1234
+	if href := resp.Header().Get("Location"); href != "" {
+		id := path.Base(href)
+		if validators.New().Var(id, "number") != nil {
+			return nil, errors.New("failed to parse netbackup job ID from response header")
+		}
+
+		return &Job{
+			ID:   id,
+			HREF: href,
+		}, nil
+	}
+
+	if apiR, ok := resp.Result().(*netbackupJobAPIResponse); ok && apiR.Status != "" {
+		job = &Job{
+			ID:          strconv.Itoa(apiR.ID),
+			Name:        apiR.Name,
+			Description: apiR.Description,
+			HREF:        resp.Request.URL,
+			Progress:    apiR.Progress,
+			Details:     apiR.ErrorMessage,
+		}
+
+		status, err := v.JobStatusParser(apiR.Status)
+		if err != nil {
+			return nil, errors.Newf("failed to parse netbackup job status: %w", err)
+		}
+
+		job.Status = status
+
+		if status == JobError {
+			return job, &JobFailedError{Job: job, Err: &errors.APIError{
+				StatusCode:    resp.StatusCode(),
+				StatusMessage: status.String(),
+				Operation:     "Fetching job status",
+				Message:       apiR.ErrorMessage,
+				Duration:      resp.Duration(),
+				Endpoint:      resp.Request.URL,
+			}}
+		}
+
+		return job, nil
+	}
+
+	if err := v.parseAPIError("JobParser", resp); err != nil {
+		return nil, err
+	}
+
+	return nil, errors.New("failed to parse netbackup job response, unexpected type or empty response")
+}
+
+// JobStatusParser returns the job status from the status of the Netbackup job.
+func (v *netbackup) JobStatusParser(status string) (s JobStatus, err error) {
+	switch strings.ToLower(status) {
+	case "queued", "waiting":
+		s = JobQueued
+	case "running", "active":
+		s = JobRunning
+	case "successful", "done":
+		s = JobSuccess
+	case "failed":
+		s = JobError
+	case "canceled", "cancelled":
+		s = JobAborted
+	default:
+		return "", errors.New("unknown job status: " + status)
+	}
+	return s, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"resty.dev/v3"
+)
+
+func TestNetbackupJobStatusParser(t *testing.T) {
+	v := &netbackup{}
+	tests := []struct {
+		input    string
+		expected JobStatus
+		wantErr  bool
+	}{
+		{"Queued", JobQueued, false},
+		{"Waiting", JobQueued, false},
+		{"Running", JobRunning, false},
+		{"Active", JobRunning, false},
+		{"Successful", JobSuccess, false},
+		{"Failed", JobError, false},
+		{"Canceled", JobAborted, false},
+		{"unknown", JobStatus(""), true},
+	}
+	for _, tt := range tests {
+		got, err := v.JobStatusParser(tt.input)
+		if tt.wantErr {
+			assert.Error(t, err, tt.input)
+		} else {
+			assert.NoError(t, err, tt.input)
+			assert.Equal(t, tt.expected, got, tt.input)
+		}
+	}
+}
+
+func TestNetbackupJobParser_Accepted(t *testing.T) {
+	v := &netbackup{}
+
+	header := http.Header{}
+	header.Set("Location", netbackupAPIPath+"/jobs/1234")
+
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header:     header,
+		},
+		Request: &resty.Request{},
+	}
+	job, err := v.JobParser(resp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", job.ID)
+
+	// The job ID must be a number.
+	header.Set("Location", netbackupAPIPath+"/jobs/abcd")
+	_, err = v.JobParser(resp)
+	assert.Error(t, err)
+}
+
+func TestNetbackupJobParser_NormalResponse(t *testing.T) {
+	v := &netbackup{}
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: http.StatusOK,
+		},
+		Request: &resty.Request{
+			URL: "http://example.com/job",
+			Result: &netbackupJobAPIResponse{
+				ID:          1234,
+				Name:        "test-job",
+				Description: "desc",
+				Status:      "Successful",
+				Progress:    100,
+			},
+		},
+	}
+	job, err := v.JobParser(resp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", job.ID)
+	assert.Equal(t, "test-job", job.Name)
+	assert.Equal(t, "desc", job.Description)
+	assert.Equal(t, "http://example.com/job", job.HREF)
+	assert.Equal(t, JobSuccess, job.Status)
+	assert.Equal(t, 100, job.Progress)
+}
+
+func TestNetbackupJobParser_FailedStatus(t *testing.T) {
+	v := &netbackup{}
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: http.StatusOK,
+		},
+		Request: &resty.Request{
+			URL: "http://example.com/job",
+			Result: &netbackupJobAPIResponse{
+				ID:           1234,
+				Status:       "Failed",
+				ErrorMessage: "snapshot failed",
+			},
+		},
+	}
+	job, err := v.JobParser(resp)
+
+	assert.ErrorContains(t, err, "snapshot failed")
+	assert.NotNil(t, job)
+	assert.Equal(t, JobError, job.Status)
+	assert.Equal(t, "snapshot failed", job.Details)
+}
+
+func TestNetbackupJobParser_NilResponse(t *testing.T) {
+	v := &netbackup{}
+	job, err := v.JobParser(nil)
+	assert.Error(t, err)
+	assert.Nil(t, job)
+}
@@ -0,0 +1,75 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"resty.dev/v3"
+)
+
+func newNetbackupErrorResponse(statusCode int, apiErr *netbackupError) *resty.Response {
+	return &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: statusCode,
+		},
+		Request: &resty.Request{
+			Method: http.MethodPost,
+			URL:    "http://example.com/NetBackupSelfService/Api/v6/jobs",
+			Error:  apiErr,
+		},
+	}
+}
+
+func TestNetbackup_ParseAPIError(t *testing.T) {
+	v := &netbackup{}
+
+	assert.Nil(t, v.parseAPIError("op", nil))
+
+	err := v.parseAPIError("op", newNetbackupErrorResponse(http.StatusBadRequest, &netbackupError{
+		Message:       "The request is invalid.",
+		MessageDetail: "The protection level does not exist.",
+	}))
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, "The request is invalid.: The protection level does not exist.", err.Message)
+	assert.Equal(t, "op", err.Operation)
+}
+
+func TestNetbackup_IdempotentRetryCondition(t *testing.T) {
+	v := &netbackup{}
+	condition := v.idempotentRetryCondition()
+
+	assert.True(t, condition(newNetbackupErrorResponse(http.StatusConflict, &netbackupError{
+		Message: "Another operation is already in progress on this machine.",
+	}), nil))
+	assert.False(t, condition(newNetbackupErrorResponse(http.StatusConflict, &netbackupError{
+		Message: "The protection level is already assigned.",
+	}), nil))
+	assert.False(t, condition(newNetbackupErrorResponse(http.StatusBadRequest, &netbackupError{
+		Message: "Another operation is already in progress on this machine.",
+	}), nil))
+	assert.False(t, condition(nil, nil))
+}
+
+func TestNetbackup_SessionExpired(t *testing.T) {
+	v := &netbackup{}
+
+	assert.True(t, v.sessionExpired(newNetbackupErrorResponse(http.StatusUnauthorized, &netbackupError{})))
+	assert.True(t, v.sessionExpired(newNetbackupErrorResponse(http.StatusForbidden, &netbackupError{
+		Message: "Authorization has been denied for this request.",
+	})))
+	assert.False(t, v.sessionExpired(newNetbackupErrorResponse(http.StatusForbidden, &netbackupError{
+		Message: "Access to the machine is forbidden.",
+	})))
+	assert.False(t, v.sessionExpired(nil))
+}
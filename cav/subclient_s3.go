@@ -0,0 +1,157 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+)
+
+var _ subClientInterface = &s3{}
+
+// s3 is the sub-client of the S3 object storage API.
+// The requests are signed with the AWS Signature Version 4 using the keys of the S3 credential.
+type s3 struct {
+	subclient
+}
+
+type s3Error struct {
+	XMLName   xml.Name `xml:"Error" fake:"skip"`
+	Code      string   `xml:"Code" fake:"{randomstring:[NoSuchBucket,BucketAlreadyExists,AccessDenied]}"`
+	Message   string   `xml:"Message" fake:"{sentence:3,10}"`
+	Resource  string   `xml:"Resource,omitempty"`
+	RequestID string   `xml:"RequestId,omitempty" fake:"{uuid}"`
+}
+
+var newS3Client = func() subClientInterface {
+	return &s3{}
+}
+
+// s3Region is the region used in the signature of the requests.
+// The Cloud Avenue object storage has a single region.
+const s3Region = "region01"
+
+// getID returns the unique identifier for the subclient
+func (v *s3) getID() string {
+	return string(ClientS3)
+}
+
+// httpClient returns the HTTP client shared by the requests of the S3 subclient.
+// The transport of the client signs the requests.
+func (v *s3) httpClient(ctx context.Context) (*resty.Client, error) {
+	return v.sharedHTTPClient(ctx, func(hC *resty.Client) {
+		hC.
+			SetBaseURL(v.console.GetS3Endpoint()).
+			SetHeader("Accept", "application/xml").
+			SetError(s3Error{}).
+			SetTransport(newS3SigV4Transport(hC.Transport(), s3Region, v.keys))
+	})
+}
+
+// keys returns the keys of the S3 credential used to sign the requests.
+func (v *s3) keys() (accessKey, secretKey string) {
+	if cred, ok := v.credential.(*s3Credential); ok {
+		return cred.keys()
+	}
+	return "", ""
+}
+
+// Close closes the S3 client and releases any resources.
+func (v *s3) close() error {
+	return v.closeHTTPClient()
+}
+
+// ParseAPIError parses the API error response from the S3 client.
+func (v *s3) parseAPIError(operation string, resp *resty.Response) *errors.APIError {
+	if resp == nil || !resp.IsError() {
+		return nil
+	}
+
+	// If resp.Error() is not nil, it means an error occurred.
+	// Parse the error response body.
+	if err, ok := resp.Error().(*s3Error); ok && err.Code != "" {
+		return &errors.APIError{
+			Operation:  operation,
+			StatusCode: resp.StatusCode(),
+			Message:    fmt.Sprintf("%s: %s", err.Code, err.Message),
+			Duration:   resp.Duration(),
+			Endpoint:   resp.Request.URL,
+			Method:     resp.Request.Method,
+			Code:       err.Code,
+			Kind:       err.kind(),
+		}
+	}
+
+	// This is used to prevent nil pointer dereference if SetError() was not called or overrided by other object.
+	return &errors.APIError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode(),
+		Message:    "Unknown error occurred",
+		Duration:   resp.Duration(),
+		Endpoint:   resp.Request.URL,
+		Method:     resp.Request.Method,
+	}
+}
+
+// s3ErrorOperationAborted is returned by S3 when a conflicting operation is in progress on the bucket.
+//
+//	<Error>
+//	   <Code>OperationAborted</Code>
+//	   <Message>A conflicting conditional operation is currently in progress against this resource.</Message>
+//	</Error>
+const s3ErrorOperationAborted = "OperationAborted"
+
+// kind returns the sentinel error of the code, nil if the status code is enough
+// to classify the error.
+func (e *s3Error) kind() error {
+	switch e.Code {
+	case s3ErrorOperationAborted:
+		return errors.ErrBusyEntity
+	case "QuotaExceeded", "TooManyBuckets":
+		return errors.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// idempotentRetryCondition returns a retry condition function for idempotent operations.
+// Retries are triggered if a conflicting operation is in progress on the bucket.
+func (v *s3) idempotentRetryCondition() resty.RetryConditionFunc {
+	return func(resp *resty.Response, _ error) bool {
+		if resp == nil || resp.StatusCode() != http.StatusConflict {
+			return false
+		}
+
+		if apiErr, ok := resp.Error().(*s3Error); ok {
+			return apiErr.Code == s3ErrorOperationAborted
+		}
+
+		return false
+	}
+}
+
+// sessionExpired returns true if S3 rejected the access key of the request,
+// e.g. because the key has been deleted.
+func (v *s3) sessionExpired(resp *resty.Response) bool {
+	if resp == nil || resp.StatusCode() != http.StatusForbidden {
+		return false
+	}
+
+	if apiErr, ok := resp.Error().(*s3Error); ok {
+		return apiErr.Code == "InvalidAccessKeyId"
+	}
+
+	return false
+}
@@ -0,0 +1,249 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // Content-MD5 is required by the S3 API, it is not used for security.
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// s3SigV4Algorithm is the signing algorithm of the AWS Signature Version 4.
+	s3SigV4Algorithm = "AWS4-HMAC-SHA256"
+	// s3SigV4Service is the service name used in the scope of the signature.
+	s3SigV4Service = "s3"
+	// s3SigV4TimeFormat is the format of the X-Amz-Date header.
+	s3SigV4TimeFormat = "20060102T150405Z"
+	// s3SigV4DateFormat is the format of the date in the scope of the signature.
+	s3SigV4DateFormat = "20060102"
+
+	// s3UnsignedPayload is used as payload hash when the body cannot be read twice.
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	// s3EmptyPayloadHash is the SHA256 of an empty payload.
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// s3SigV4Transport signs the requests with the AWS Signature Version 4
+// before sending them with the base transport.
+type s3SigV4Transport struct {
+	base   http.RoundTripper
+	region string
+
+	// keys returns the access key and the secret key used to sign the request.
+	// They are read for each request to use the keys renewed by another request.
+	keys func() (accessKey, secretKey string)
+	now  func() time.Time
+}
+
+// newS3SigV4Transport returns a transport signing the requests with the keys returned by keys.
+func newS3SigV4Transport(base http.RoundTripper, region string, keys func() (string, string)) *s3SigV4Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &s3SigV4Transport{
+		base:   base,
+		region: region,
+		keys:   keys,
+		now:    time.Now,
+	}
+}
+
+// RoundTrip signs a copy of the request and sends it.
+func (t *s3SigV4Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	accessKey, secretKey := t.keys()
+	if accessKey == "" || secretKey == "" {
+		return nil, errors.New("s3: the request cannot be signed without access key")
+	}
+
+	// A RoundTripper must not modify the request.
+	signed := req.Clone(req.Context())
+	if err := signS3Request(signed, accessKey, secretKey, t.region, t.now()); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(signed)
+}
+
+// signS3Request adds the X-Amz-Date, X-Amz-Content-Sha256 and Authorization headers to the request.
+// The body is read with GetBody to compute its hash, the Content-MD5 header is added if missing.
+func signS3Request(req *http.Request, accessKey, secretKey, region string, t time.Time) error {
+	t = t.UTC()
+	amzDate := t.Format(s3SigV4TimeFormat)
+	scope := strings.Join([]string{t.Format(s3SigV4DateFormat), region, s3SigV4Service, "aws4_request"}, "/")
+
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		var err error
+		payloadHash, err = s3PayloadHash(req)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	canonicalHeaders, signedHeaders := s3CanonicalHeaders(req)
+
+	path := req.URL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3URIEncode(path, false),
+		s3CanonicalQuery(req),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		s3SigV4Algorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), []byte(t.Format(s3SigV4DateFormat)))
+	key = hmacSHA256(key, []byte(region))
+	key = hmacSHA256(key, []byte(s3SigV4Service))
+	key = hmacSHA256(key, []byte("aws4_request"))
+	signature := hex.EncodeToString(hmacSHA256(key, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigV4Algorithm, accessKey, scope, signedHeaders, signature))
+
+	return nil
+}
+
+// s3PayloadHash returns the hex encoded SHA256 of the body of the request
+// and sets the Content-MD5 header if it is not set.
+func s3PayloadHash(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return s3EmptyPayloadHash, nil
+	}
+
+	// The body can only be read twice if the request provides GetBody.
+	if req.GetBody == nil {
+		return s3UnsignedPayload, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", fmt.Errorf("s3: failed to read the body: %w", err)
+	}
+	defer body.Close()
+
+	sha := sha256.New()
+	md := md5.New() //nolint:gosec // See the import.
+	if _, err := io.Copy(io.MultiWriter(sha, md), body); err != nil {
+		return "", fmt.Errorf("s3: failed to read the body: %w", err)
+	}
+
+	if req.Header.Get("Content-MD5") == "" {
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md.Sum(nil)))
+	}
+
+	return hex.EncodeToString(sha.Sum(nil)), nil
+}
+
+// s3CanonicalHeaders returns the canonical headers and the list of the signed headers.
+// The host, the content headers and the X-Amz-* headers are signed.
+func s3CanonicalHeaders(req *http.Request) (canonical, signed string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name != "content-type" && name != "content-md5" && name != "range" && !strings.HasPrefix(name, "x-amz-") {
+			continue
+		}
+
+		trimmed := make([]string, len(values))
+		for i, v := range values {
+			trimmed[i] = strings.Join(strings.Fields(v), " ")
+		}
+		headers[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	return b.String(), strings.Join(names, ";")
+}
+
+// s3CanonicalQuery returns the query string sorted by name and value with the encoding of the signature.
+func s3CanonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, v := range values {
+			params = append(params, s3URIEncode(name, true)+"="+s3URIEncode(v, true))
+		}
+	}
+	slices.Sort(params)
+
+	return strings.Join(params, "&")
+}
+
+// s3URIEncode encodes s as required by the signature: all the characters except the
+// unreserved characters are percent-encoded. The slash is kept unless encodeSlash is true.
+func s3URIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
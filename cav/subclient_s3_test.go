@@ -0,0 +1,89 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+package cav
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
+)
+
+func newS3ErrorResponse(statusCode int, apiErr *s3Error) *resty.Response {
+	return &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: statusCode,
+		},
+		Request: &resty.Request{
+			Method: http.MethodPut,
+			URL:    "http://example.com/my-bucket",
+			Error:  apiErr,
+		},
+	}
+}
+
+func TestS3_ParseAPIError(t *testing.T) {
+	v := &s3{}
+
+	assert.Nil(t, v.parseAPIError("op", nil))
+
+	err := v.parseAPIError("op", newS3ErrorResponse(http.StatusConflict, &s3Error{
+		Code:    "BucketAlreadyExists",
+		Message: "The requested bucket name is not available.",
+	}))
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, "BucketAlreadyExists: The requested bucket name is not available.", err.Message)
+	assert.Equal(t, "op", err.Operation)
+
+	err = v.parseAPIError("op", newS3ErrorResponse(http.StatusInternalServerError, &s3Error{}))
+	assert.NotNil(t, err)
+	assert.Equal(t, "Unknown error occurred", err.Message)
+
+	err = v.parseAPIError("op", newS3ErrorResponse(http.StatusConflict, &s3Error{
+		Code:    "OperationAborted",
+		Message: "A conflicting conditional operation is currently in progress against this resource.",
+	}))
+	assert.Equal(t, "OperationAborted", err.Code)
+	assert.ErrorIs(t, err, errors.ErrBusyEntity)
+
+	err = v.parseAPIError("op", newS3ErrorResponse(http.StatusBadRequest, &s3Error{Code: "TooManyBuckets"}))
+	assert.ErrorIs(t, err, errors.ErrQuotaExceeded)
+}
+
+func TestS3_IdempotentRetryCondition(t *testing.T) {
+	v := &s3{}
+	condition := v.idempotentRetryCondition()
+
+	assert.True(t, condition(newS3ErrorResponse(http.StatusConflict, &s3Error{
+		Code: s3ErrorOperationAborted,
+	}), nil))
+	assert.False(t, condition(newS3ErrorResponse(http.StatusConflict, &s3Error{
+		Code: "BucketNotEmpty",
+	}), nil))
+	assert.False(t, condition(newS3ErrorResponse(http.StatusBadRequest, &s3Error{
+		Code: s3ErrorOperationAborted,
+	}), nil))
+	assert.False(t, condition(nil, nil))
+}
+
+func TestS3_SessionExpired(t *testing.T) {
+	v := &s3{}
+
+	assert.True(t, v.sessionExpired(newS3ErrorResponse(http.StatusForbidden, &s3Error{
+		Code: "InvalidAccessKeyId",
+	})))
+	assert.False(t, v.sessionExpired(newS3ErrorResponse(http.StatusForbidden, &s3Error{
+		Code: "AccessDenied",
+	})))
+	assert.False(t, v.sessionExpired(nil))
+}
@@ -11,11 +11,11 @@ package cav
 
 import (
 	"context"
+	"net/http"
 	"regexp"
 
 	"resty.dev/v3"
 
-	httpclient "github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/httpClient"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 )
 
@@ -32,31 +32,19 @@ func (v *vmware) getID() string {
 	return string(ClientVmware)
 }
 
-// NewClient creates a new request for the VMware subclient.
-func (v *vmware) newHTTPClient(ctx context.Context) (*resty.Client, error) {
-	// Create a new HTTP client with the base URL and headers.
-	hC := httpclient.NewHTTPClient().
-		SetBaseURL(v.console.GetAPIVCDEndpoint()).
-		SetHeader("Accept", "application/json;version="+vmwareVCDVersion).
-		SetError(vmwareError{})
-
-	// If the credential is not initialized, refresh it.
-	// This is necessary to ensure that the client has the latest authentication token.
-	if !v.credential.IsInitialized() {
-		if err := v.credential.Refresh(ctx); err != nil {
-			return nil, err
-		}
-	}
-
-	hC.
-		SetHeaders(v.credential.Headers())
-
-	return hC, nil
+// httpClient returns the HTTP client shared by the requests of the VMware subclient.
+func (v *vmware) httpClient(ctx context.Context) (*resty.Client, error) {
+	return v.sharedHTTPClient(ctx, func(hC *resty.Client) {
+		hC.
+			SetBaseURL(v.console.GetAPIVCDEndpoint()).
+			SetHeader("Accept", "application/json;version="+vmwareVCDVersion).
+			SetError(vmwareError{})
+	})
 }
 
 // Close closes the VMware client and releases any resources.
 func (v *vmware) close() error {
-	return nil
+	return v.closeHTTPClient()
 }
 
 // ParseAPIError parses the API error response from the VMware client.
@@ -75,6 +63,8 @@ func (v *vmware) parseAPIError(operation string, resp *resty.Response) *errors.A
 			Duration:   resp.Duration(),
 			Endpoint:   resp.Request.URL,
 			Method:     resp.Request.Method,
+			Code:       err.StatusMessage,
+			Kind:       err.kind(),
 		}
 	}
 
@@ -91,6 +81,31 @@ func (v *vmware) parseAPIError(operation string, resp *resty.Response) *errors.A
 
 var regexVmwareBusyEntity = regexp.MustCompile(`BUSY_ENTITY`)
 
+// regexQuotaExceeded matches the error messages returned when a quota or a limit
+// of the organization is reached.
+var regexQuotaExceeded = regexp.MustCompile(`(?i)(quota|limit) (is )?(exceeded|reached)`)
+
+// regexRateLimited matches the throttling messages (e.g. "rate limit exceeded"),
+// the request can be sent again later so they are not quota errors.
+var regexRateLimited = regexp.MustCompile(`(?i)rate[ -]?limit`)
+
+// isQuotaExceeded returns true if the message reports a quota or a limit of the organization reached.
+func isQuotaExceeded(message string) bool {
+	return regexQuotaExceeded.MatchString(message) && !regexRateLimited.MatchString(message)
+}
+
+// kind returns the sentinel error of the minorErrorCode or of the message, nil if the
+// status code is enough to classify the error.
+func (e *vmwareError) kind() error {
+	switch {
+	case regexVmwareBusyEntity.MatchString(e.StatusMessage) || regexVmwareBusyEntity.MatchString(e.Message):
+		return errors.ErrBusyEntity
+	case isQuotaExceeded(e.Message):
+		return errors.ErrQuotaExceeded
+	}
+	return nil
+}
+
 // idempotentRetryCondition returns a retry condition function for the VMware client.
 func (v *vmware) idempotentRetryCondition() resty.RetryConditionFunc {
 	return func(resp *resty.Response, err error) bool {
@@ -99,6 +114,11 @@ func (v *vmware) idempotentRetryCondition() resty.RetryConditionFunc {
 			return false
 		}
 
+		// Check if the error returned by the API indicates that the entity is busy.
+		if apiErr, ok := resp.Error().(*vmwareError); ok && (regexVmwareBusyEntity.MatchString(apiErr.StatusMessage) || regexVmwareBusyEntity.MatchString(apiErr.Message)) {
+			return true // Retry if the error message indicates that the entity is busy.
+		}
+
 		// Check if the error message indicates that the entity is busy.
 		if err != nil && regexVmwareBusyEntity.MatchString(err.Error()) {
 			return true // Retry if the error message indicates that the entity is busy.
@@ -107,3 +127,27 @@ func (v *vmware) idempotentRetryCondition() resty.RetryConditionFunc {
 		return false
 	}
 }
+
+// regexVmwareSessionExpired matches the error messages returned by VMware Cloud Director
+// when the session used for the request is no longer valid.
+var regexVmwareSessionExpired = regexp.MustCompile(`(?i)((session|token).*(expired|invalid|not found))|not authenticated`)
+
+// sessionExpired returns true if the response indicates that the session is expired or invalid.
+// VMware Cloud Director returns a 401 when the token is expired and in some cases a 403
+// with an explicit message.
+func (v *vmware) sessionExpired(resp *resty.Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		if err, ok := resp.Error().(*vmwareError); ok {
+			return regexVmwareSessionExpired.MatchString(err.Message)
+		}
+	}
+
+	return false
+}
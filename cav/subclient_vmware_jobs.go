@@ -12,6 +12,7 @@ package cav
 import (
 	"context"
 	"strings"
+	"time"
 
 	"resty.dev/v3"
 
@@ -28,6 +29,7 @@ func init() {
 		SubClient:        ClientVmware,
 		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/doc/types/TaskType.html",
 		PathTemplate:     "/api/task/{taskId}",
+		DisableCache:     true,
 		PathParams: []PathParam{
 			{
 				Name:        "taskId",
@@ -51,7 +53,7 @@ func init() {
 				}
 			}
 
-			if isMockClient {
+			if isMockFromContext(ctx) {
 				return r.Get(endpoint.MockPath())
 			}
 
@@ -60,29 +62,81 @@ func init() {
 		BodyRequestType:  nil, // No request body for this endpoint.
 		BodyResponseType: vmwareJobAPIResponse{},
 	}.Register()
+
+	Endpoint{
+		Name:             "CancelJobVmware",
+		Description:      "Cancel VMware Job",
+		Method:           MethodPOST,
+		SubClient:        ClientVmware,
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/doc/operations/POST-CancelTask.html",
+		PathTemplate:     "/api/task/{taskId}/action/cancel",
+		PathParams: []PathParam{
+			{
+				Name:        "taskId",
+				Description: "The identifier of the task to cancel.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,uuid4")
+				},
+			},
+		},
+		QueryParams: []QueryParam{},
+		RequestFunc: nil, // Will be set later in the Register function.
+		requestInternalFunc: func(ctx context.Context, client *resty.Client, endpoint *Endpoint, opts ...EndpointRequestOption) (*resty.Response, error) {
+			r := client.R().
+				SetContext(ctx).
+				SetHeader("Accept", "application/*+json;version="+vmwareVCDVersion)
+
+			for _, opt := range opts {
+				if err := opt(endpoint, r); err != nil {
+					return nil, err
+				}
+			}
+
+			if isMockFromContext(ctx) {
+				return r.Post(endpoint.MockPath())
+			}
+
+			return r.Post(endpoint.PathTemplate)
+		},
+		BodyRequestType:  nil, // No request body for this endpoint.
+		BodyResponseType: nil, // The API returns 204 No Content.
+	}.Register()
 }
 
-// Ensure vmware implements the jobs interface.
-var _ jobsInterface = &vmware{}
+// Ensure vmware implements the jobs interfaces.
+var (
+	_ jobsInterface = &vmware{}
+	_ jobCanceler   = &vmware{}
+)
 
 // vmwareJobAPIResponse represents an asynchronous operation in VCD.
 type vmwareJobAPIResponse struct {
-	HREF             string       `json:"href,omitempty" fake:"{href_uuid}"` // The URI of the entity.
-	ID               string       `json:"id,omitempty" fake:"{uuid}"`        // The entity identifier, expressed in URN format. The value of this attribute uniquely identifies the entity, persists for the life of the entity, and is never reused.
-	OperationKey     string       `json:"operationKey,omitempty"`            // Optional unique identifier to support idempotent semantics for create and delete operations.
-	Name             string       `json:"name,omitempty" fake:"{word}"`      // The name of the entity.
-	Status           string       `json:"status,omitempty" fake:"success"`   // The execution status of the task. One of queued, preRunning, running, success, error, aborted
-	Operation        string       `json:"operation,omitempty"`               // A message describing the operation that is tracked by this task.
-	OperationName    string       `json:"operationName,omitempty"`           // The short name of the operation that is tracked by this task.
-	ServiceNamespace string       `json:"serviceNamespace,omitempty"`        // Identifier of the service that created the task. It must not start with com.vmware.vcloud and the length must be between 1 and 128 symbols.
-	StartTime        string       `json:"startTime,omitempty"`               // The date and time the system started executing the task. May not be present if the task has not been executed yet.
-	EndTime          string       `json:"endTime,omitempty"`                 // The date and time that processing of the task was completed. May not be present if the task is still being executed.
-	ExpiryTime       string       `json:"expiryTime,omitempty"`              // The date and time at which the task resource will be destroyed and no longer available for retrieval. May not be present if the task has not been executed or is still being executed.
-	CancelRequested  bool         `json:"cancelRequested,omitempty"`         // Whether user has requested this processing to be canceled.
-	Description      string       `json:"description,omitempty" `            // Optional description.
-	Error            *vmwareError `json:"error,omitempty" fake:"-"`          // Represents error information from a failed task.
-	Progress         int          `json:"progress,omitempty"`                // Read-only indicator of task progress as an approximate percentage between 0 and 100. Not available for all tasks.
-	Details          string       `json:"details,omitempty"`                 // Detailed message about the task. Also contained by the Owner entity when task status is preRunning.
+	HREF             string                 `json:"href,omitempty" fake:"{href_uuid}"` // The URI of the entity.
+	ID               string                 `json:"id,omitempty" fake:"{uuid}"`        // The entity identifier, expressed in URN format. The value of this attribute uniquely identifies the entity, persists for the life of the entity, and is never reused.
+	OperationKey     string                 `json:"operationKey,omitempty"`            // Optional unique identifier to support idempotent semantics for create and delete operations.
+	Name             string                 `json:"name,omitempty" fake:"{word}"`      // The name of the entity.
+	Status           string                 `json:"status,omitempty" fake:"success"`   // The execution status of the task. One of queued, preRunning, running, success, error, aborted
+	Operation        string                 `json:"operation,omitempty"`               // A message describing the operation that is tracked by this task.
+	OperationName    string                 `json:"operationName,omitempty"`           // The short name of the operation that is tracked by this task.
+	ServiceNamespace string                 `json:"serviceNamespace,omitempty"`        // Identifier of the service that created the task. It must not start with com.vmware.vcloud and the length must be between 1 and 128 symbols.
+	StartTime        string                 `json:"startTime,omitempty"`               // The date and time the system started executing the task. May not be present if the task has not been executed yet.
+	EndTime          string                 `json:"endTime,omitempty"`                 // The date and time that processing of the task was completed. May not be present if the task is still being executed.
+	ExpiryTime       string                 `json:"expiryTime,omitempty"`              // The date and time at which the task resource will be destroyed and no longer available for retrieval. May not be present if the task has not been executed or is still being executed.
+	CancelRequested  bool                   `json:"cancelRequested,omitempty"`         // Whether user has requested this processing to be canceled.
+	Description      string                 `json:"description,omitempty" `            // Optional description.
+	Error            *vmwareError           `json:"error,omitempty" fake:"-"`          // Represents error information from a failed task.
+	Progress         int                    `json:"progress,omitempty"`                // Read-only indicator of task progress as an approximate percentage between 0 and 100. Not available for all tasks.
+	Details          string                 `json:"details,omitempty"`                 // Detailed message about the task. Also contained by the Owner entity when task status is preRunning.
+	Owner            *vmwareEntityReference `json:"owner,omitempty" fake:"-"`          // Reference to the owner of the task. This is typically the object that the task is creating or updating.
+}
+
+// vmwareEntityReference is a reference to a VCD entity.
+type vmwareEntityReference struct {
+	HREF string `json:"href,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
 }
 
 // JobRefresh is a function type that defines how to refresh a job status.
@@ -94,7 +148,7 @@ func (v *vmware) JobRefresh(httpC *resty.Client, resp *resty.Response, reqOpts [
 
 	ep, err := GetEndpoint("GetJobVmware")
 	if err != nil {
-		return nil, errors.New("failed to get endpoint for GetJobVmware: " + err.Error())
+		return nil, errors.Newf("failed to get endpoint for GetJobVmware: %w", err)
 	}
 
 	reqOpts = append(reqOpts,
@@ -104,13 +158,63 @@ func (v *vmware) JobRefresh(httpC *resty.Client, resp *resty.Response, reqOpts [
 	)
 
 	respR, err := ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	if v.sessionExpired(respR) {
+		// The session has expired during the job polling.
+		// Renew the credential and replay the polling with the new session.
+		if errAuth := v.renewCredential(resp.Request.Context(), ep.Description, respR); errAuth != nil {
+			return nil, errAuth
+		}
+
+		respR, err = ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	}
 	if err != nil {
-		return nil, errors.New("failed to refresh job status: " + err.Error())
+		return nil, errors.Newf("failed to refresh job status: %w", err)
 	}
 
 	return v.JobParser(respR)
 }
 
+// JobCancel requests the cancellation of the task of the response.
+// The task is aborted by VCD asynchronously, its status must be refreshed to know when it is aborted.
+func (v *vmware) JobCancel(httpC *resty.Client, resp *resty.Response, reqOpts []EndpointRequestOption) error {
+	job, err := v.JobParser(resp)
+	if job == nil {
+		if err == nil {
+			err = errors.New("no job to cancel")
+		}
+		return err
+	}
+
+	ep, err := GetEndpoint("CancelJobVmware")
+	if err != nil {
+		return errors.Newf("failed to get endpoint for CancelJobVmware: %w", err)
+	}
+
+	reqOpts = append(reqOpts,
+		SetCustomRestyOption(func(r *resty.Request) { r.SetError(&vmwareError{}) }),
+		WithPathParam(ep.PathParams[0], urn.ExtractUUID(job.ID)),
+	)
+
+	respR, err := ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	if v.sessionExpired(respR) {
+		// The session has expired during the job polling.
+		// Renew the credential and replay the cancellation with the new session.
+		if errAuth := v.renewCredential(resp.Request.Context(), ep.Description, respR); errAuth != nil {
+			return errAuth
+		}
+
+		respR, err = ep.requestInternalFunc(resp.Request.Context(), httpC, ep, reqOpts...)
+	}
+	if err != nil {
+		return errors.Newf("failed to cancel job: %w", err)
+	}
+
+	if err := v.parseAPIError("JobCancel", respR); err != nil {
+		return err
+	}
+	return nil
+}
+
 // JobParser parses the job response body and extracts the job information.
 func (v *vmware) JobParser(resp *resty.Response) (job *Job, err error) {
 	if resp == nil {
@@ -151,24 +255,52 @@ func (v *vmware) JobParser(resp *resty.Response) (job *Job, err error) {
 			Name:        apiR.Name,
 			Description: apiR.Description,
 			HREF:        apiR.HREF,
+			Operation:   apiR.Operation,
+			Progress:    apiR.Progress,
+			Details:     apiR.Details,
+			StartTime:   parseVmwareJobTime(apiR.StartTime),
+			EndTime:     parseVmwareJobTime(apiR.EndTime),
+		}
+
+		if apiR.Owner != nil {
+			job.Owner = &JobOwner{
+				ID:   apiR.Owner.ID,
+				Name: apiR.Owner.Name,
+				Type: apiR.Owner.Type,
+				HREF: apiR.Owner.HREF,
+			}
 		}
 
 		status, err := v.JobStatusParser(apiR.Status)
 		if err != nil {
-			return nil, errors.New("failed to parse vmware job status: " + err.Error())
+			return nil, errors.Newf("failed to parse vmware job status: %w", err)
 		}
 
 		job.Status = status
 
 		if apiR.Error != nil {
-			return job, &errors.APIError{
+			return job, &JobFailedError{Job: job, Err: &errors.APIError{
 				StatusCode:    apiR.Error.StatusCode,
 				StatusMessage: apiR.Error.StatusMessage,
 				Operation:     apiR.Operation,
 				Message:       apiR.Error.Message,
 				Duration:      resp.Duration(),
 				Endpoint:      apiR.HREF,
-			}
+				Code:          apiR.Error.StatusMessage,
+				Kind:          apiR.Error.kind(),
+			}}
+		}
+
+		// A task cancelled before its end is aborted without error information.
+		if job.Status == JobAborted {
+			return job, &JobFailedError{Job: job, Err: &errors.APIError{
+				StatusCode:    resp.StatusCode(),
+				StatusMessage: apiR.Status,
+				Operation:     apiR.Operation,
+				Message:       "the task has been aborted",
+				Duration:      resp.Duration(),
+				Endpoint:      apiR.HREF,
+			}}
 		}
 
 		return job, nil
@@ -201,3 +333,12 @@ func (v *vmware) JobStatusParser(status string) (s JobStatus, err error) {
 	}
 	return s, nil
 }
+
+// parseVmwareJobTime parses a date of a task, the zero time is returned if it is absent or invalid.
+func parseVmwareJobTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
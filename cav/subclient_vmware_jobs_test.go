@@ -12,11 +12,15 @@ package cav
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"resty.dev/v3"
 
 	"github.com/orange-cloudavenue/common-go/generator"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 )
 
 func TestVmwareJobStatusParser(t *testing.T) {
@@ -128,6 +132,59 @@ func TestVmwareJobParser_FailedStatus(t *testing.T) {
 	assert.NotNil(t, job)
 }
 
+func TestVmwareJobParser_BusyEntity(t *testing.T) {
+	v := &vmware{}
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: http.StatusOK,
+		},
+		Request: &resty.Request{
+			URL: "http://example.com/job",
+			Result: &vmwareJobAPIResponse{
+				Status: "error",
+				Error: &vmwareError{
+					StatusCode:    http.StatusBadRequest,
+					StatusMessage: "BUSY_ENTITY",
+					Message:       "The entity vdc-a is busy completing an operation.",
+				},
+			},
+		},
+	}
+	_, err := v.JobParser(resp)
+
+	// The error of the job is classified from the minorErrorCode.
+	require.ErrorIs(t, err, errors.ErrJobFailed)
+	require.ErrorIs(t, err, errors.ErrBusyEntity)
+	require.ErrorIs(t, err, errors.ErrValidation)
+	assert.NotErrorIs(t, err, errors.ErrQuotaExceeded)
+
+	var apiErr *errors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "BUSY_ENTITY", apiErr.Code)
+}
+
+func TestVmwareError_kind(t *testing.T) {
+	tests := []struct {
+		message string
+		kind    error
+	}{
+		{"VM quota exceeded for the organization", errors.ErrQuotaExceeded},
+		{"The limit is reached for the number of edge gateways", errors.ErrQuotaExceeded},
+		// A message mentioning a quota or a limit is not a quota error.
+		{"The storage quota of the VDC is 100 GB", nil},
+		{"Invalid limit parameter", nil},
+		// A throttling message is not a quota error.
+		{"API rate limit exceeded, retry later", nil},
+		{"Rate-limit reached for the organization", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			e := &vmwareError{Message: tt.message}
+			assert.Equal(t, tt.kind, e.kind())
+		})
+	}
+}
+
 func TestVmwareJobParser_EmptyResponse(t *testing.T) {
 	v := &vmware{}
 	resp := &resty.Response{
@@ -203,3 +260,50 @@ func TestVmwareJobParser_InvalidResponseType(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, job)
 }
+
+func TestVmwareJobParser_Details(t *testing.T) {
+	v := &vmware{}
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: http.StatusOK,
+		},
+		Request: &resty.Request{
+			URL: "http://example.com/job",
+			Result: &vmwareJobAPIResponse{
+				ID:        "urn:vcloud:task:d3c42a20-96b9-4452-91dd-f71b71dfe314",
+				Status:    "error",
+				Operation: "Updating VDC vdc-a",
+				Progress:  40,
+				Details:   "disk full",
+				StartTime: "2025-06-01T10:00:00.000Z",
+				EndTime:   "2025-06-01T10:01:30.000Z",
+				Owner: &vmwareEntityReference{
+					ID:   "urn:vcloud:vdc:8f2a5f3b-0d6d-4a43-8e4b-5f3a0a9c6f10",
+					Name: "vdc-a",
+					Type: "application/vnd.vmware.vcloud.vdc+xml",
+				},
+				Error: &vmwareError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "disk full",
+				},
+			},
+		},
+	}
+	job, err := v.JobParser(resp)
+
+	var jobErr *JobFailedError
+	assert.ErrorAs(t, err, &jobErr)
+	assert.Same(t, job, jobErr.Job)
+	assert.Equal(t, "Updating VDC vdc-a", job.Operation)
+	assert.Equal(t, 40, job.Progress)
+	assert.Equal(t, "disk full", job.Details)
+	assert.Equal(t, 90*time.Second, job.EndTime.Sub(job.StartTime))
+	assert.Equal(t, &JobOwner{
+		ID:   "urn:vcloud:vdc:8f2a5f3b-0d6d-4a43-8e4b-5f3a0a9c6f10",
+		Name: "vdc-a",
+		Type: "application/vnd.vmware.vcloud.vdc+xml",
+	}, job.Owner)
+
+	// The dates are absent while the task is queued.
+	assert.True(t, parseVmwareJobTime("").IsZero())
+}
@@ -0,0 +1,242 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"resty.dev/v3"
+)
+
+type (
+	// Telemetry receives the traces and the metrics of the client.
+	// It is implemented by an adapter of the observability library of the application
+	// (e.g. OpenTelemetry), so the SDK does not depend on it. See WithTelemetry.
+	Telemetry interface {
+		// StartSpan starts a span as a child of the span stored in ctx.
+		// The returned context holds the new span.
+		StartSpan(ctx context.Context, name string, attrs ...TelemetryAttribute) (context.Context, TelemetrySpan)
+
+		// RecordDuration records a duration in the histogram of the metric.
+		RecordDuration(ctx context.Context, metric string, d time.Duration, attrs ...TelemetryAttribute)
+
+		// AddCount adds n to the counter of the metric.
+		AddCount(ctx context.Context, metric string, n int64, attrs ...TelemetryAttribute)
+	}
+
+	// TelemetrySpan is a span started by Telemetry.StartSpan.
+	TelemetrySpan interface {
+		// SetAttributes adds attributes to the span.
+		SetAttributes(attrs ...TelemetryAttribute)
+		// RecordError marks the span as failed with the error.
+		RecordError(err error)
+		// End ends the span.
+		End()
+	}
+
+	// TelemetryAttribute is a key-value pair describing a span or a measure.
+	// Value is a string, an int, an int64, a bool or a time.Duration.
+	TelemetryAttribute struct {
+		Key   string
+		Value any
+	}
+)
+
+// Names of the metrics recorded by the client.
+const (
+	// MetricRequestDuration is the histogram of the durations of the calls of Client.Do, retries and job polling included.
+	MetricRequestDuration = "cav.client.request.duration"
+	// MetricRequestRetries is the counter of the retries of the requests.
+	MetricRequestRetries = "cav.client.request.retries"
+	// MetricBusyEntityWait is the histogram of the waits before retrying a request on a busy entity.
+	MetricBusyEntityWait = "cav.client.busy_entity.wait"
+	// MetricJobDuration is the histogram of the durations of the job polling.
+	MetricJobDuration = "cav.client.job.duration"
+)
+
+// Keys of the attributes of the spans and the metrics.
+const (
+	AttributeEndpoint    = "cav.endpoint"
+	AttributeSubClient   = "cav.subclient"
+	AttributeMethod      = "http.request.method"
+	AttributeStatusCode  = "http.response.status_code"
+	AttributeAttempt     = "cav.attempt"
+	AttributeRetryReason = "cav.retry.reason"
+	AttributeJobStatus   = "cav.job.status"
+	AttributeOutcome     = "cav.outcome"
+)
+
+// noopTelemetry is the telemetry used when no telemetry is set.
+type noopTelemetry struct{}
+
+func (noopTelemetry) StartSpan(ctx context.Context, _ string, _ ...TelemetryAttribute) (context.Context, TelemetrySpan) {
+	return ctx, noopSpan{}
+}
+
+func (noopTelemetry) RecordDuration(context.Context, string, time.Duration, ...TelemetryAttribute) {}
+
+func (noopTelemetry) AddCount(context.Context, string, int64, ...TelemetryAttribute) {}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...TelemetryAttribute) {}
+func (noopSpan) RecordError(error)                   {}
+func (noopSpan) End()                                {}
+
+// telemetryCall holds the telemetry of a call of Client.Do.
+// It is stored in the request context to be used by the retry condition and the job middleware.
+type telemetryCall struct {
+	telemetry Telemetry
+	// ctx holds the span of the call, the spans of the retries and of the job are its children.
+	ctx       context.Context
+	endpoint  string
+	subclient string
+	method    string
+
+	// span is the span of the current retry or job poll.
+	// The response middlewares are not run on network errors, so an open span
+	// is ended when the next one starts or when the call ends.
+	mu   sync.Mutex
+	span TelemetrySpan
+}
+
+// attrs returns the attributes identifying the call followed by the extra attributes.
+func (c *telemetryCall) attrs(extra ...TelemetryAttribute) []TelemetryAttribute {
+	return append([]TelemetryAttribute{
+		{Key: AttributeEndpoint, Value: c.endpoint},
+		{Key: AttributeSubClient, Value: c.subclient},
+	}, extra...)
+}
+
+// startSpan starts the span of a retry or of a job poll as a child of the span of parent.
+func (c *telemetryCall) startSpan(parent context.Context, name string, attempt int) {
+	c.endSpan(nil, nil)
+
+	_, span := c.telemetry.StartSpan(parent, name, c.attrs(TelemetryAttribute{Key: AttributeAttempt, Value: attempt})...)
+
+	c.mu.Lock()
+	c.span = span
+	c.mu.Unlock()
+}
+
+// endSpan ends the span of the current retry or job poll, if any.
+func (c *telemetryCall) endSpan(resp *resty.Response, err error) {
+	c.mu.Lock()
+	span := c.span
+	c.span = nil
+	c.mu.Unlock()
+
+	if span != nil {
+		endSpan(span, resp, err)
+	}
+}
+
+// retryMiddleware starts a span for each retry of the request, the first attempt is covered by the span of the call.
+func (c *telemetryCall) retryMiddleware(_ *resty.Client, r *resty.Request) error {
+	if r.Attempt > 1 {
+		c.startSpan(c.ctx, c.endpoint+" retry", r.Attempt)
+	}
+	return nil
+}
+
+// pollMiddleware returns a request middleware starting a span for each poll of the job.
+func (c *telemetryCall) pollMiddleware(jobCtx context.Context) resty.RequestMiddleware {
+	return func(_ *resty.Client, r *resty.Request) error {
+		c.startSpan(jobCtx, c.endpoint+" job poll", r.Attempt)
+		return nil
+	}
+}
+
+// endSpanMiddleware ends the span of the current retry or job poll.
+func (c *telemetryCall) endSpanMiddleware(_ *resty.Client, resp *resty.Response) error {
+	c.endSpan(resp, nil)
+	return nil
+}
+
+// retried records a retry decision.
+func (c *telemetryCall) retried(decision RetryDecision) {
+	c.telemetry.AddCount(c.ctx, MetricRequestRetries, 1, c.attrs(TelemetryAttribute{Key: AttributeRetryReason, Value: string(decision.Reason)})...)
+	if decision.Reason == RetryReasonBusyEntity {
+		c.telemetry.RecordDuration(c.ctx, MetricBusyEntityWait, decision.Wait, c.attrs()...)
+	}
+}
+
+// startJob starts the span of the job polling. The returned function ends it and records the job duration.
+func (c *telemetryCall) startJob() (context.Context, func(job *Job, err error)) {
+	ctx, span := c.telemetry.StartSpan(c.ctx, c.endpoint+" job", c.attrs()...)
+	start := time.Now()
+
+	return ctx, func(job *Job, err error) {
+		c.endSpan(nil, nil)
+
+		status := "unknown"
+		if job != nil {
+			status = job.Status.String()
+		}
+		span.SetAttributes(TelemetryAttribute{Key: AttributeJobStatus, Value: status})
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+
+		c.telemetry.RecordDuration(c.ctx, MetricJobDuration, time.Since(start), c.attrs(TelemetryAttribute{Key: AttributeJobStatus, Value: status})...)
+	}
+}
+
+// endSpan sets the result of the request on the span and ends it.
+func endSpan(span TelemetrySpan, resp *resty.Response, err error) {
+	if resp != nil && resp.RawResponse != nil {
+		span.SetAttributes(TelemetryAttribute{Key: AttributeStatusCode, Value: resp.StatusCode()})
+		if err == nil && resp.IsError() {
+			err = errors.New(resp.Status())
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// startCall starts the span of a call of Client.Do and stores the telemetry of the call in the context.
+// The returned function ends the span and records the duration of the call.
+func (c *client) startCall(ctx context.Context, endpoint *Endpoint) (context.Context, func(resp *resty.Response, err error)) {
+	call := &telemetryCall{
+		telemetry: c.telemetry,
+		endpoint:  endpoint.Name,
+		subclient: string(endpoint.SubClient),
+		method:    endpoint.Method.String(),
+	}
+
+	ctx, span := c.telemetry.StartSpan(ctx, endpoint.Name, call.attrs(TelemetryAttribute{Key: AttributeMethod, Value: call.method})...)
+	call.ctx = ctx
+	ctx = storeTelemetryCallInContext(ctx, call)
+	start := time.Now()
+
+	return ctx, func(resp *resty.Response, err error) {
+		call.endSpan(nil, nil)
+		endSpan(span, resp, err)
+
+		outcome := "success"
+		if err != nil {
+			outcome = "error"
+		}
+		attrs := call.attrs(
+			TelemetryAttribute{Key: AttributeMethod, Value: call.method},
+			TelemetryAttribute{Key: AttributeOutcome, Value: outcome},
+		)
+		if resp != nil && resp.RawResponse != nil {
+			attrs = append(attrs, TelemetryAttribute{Key: AttributeStatusCode, Value: resp.StatusCode()})
+		}
+		c.telemetry.RecordDuration(call.ctx, MetricRequestDuration, time.Since(start), attrs...)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package cav
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTelemetry records the spans and the metrics in memory.
+type recordingTelemetry struct {
+	mu      sync.Mutex
+	spans   []*recordingSpan
+	metrics map[string]int
+	retries map[string]int64
+}
+
+type recordingSpan struct {
+	name   string
+	parent *recordingSpan
+	attrs  map[string]any
+	err    error
+	ended  bool
+}
+
+type recordingSpanKey struct{}
+
+func newRecordingTelemetry() *recordingTelemetry {
+	return &recordingTelemetry{metrics: map[string]int{}, retries: map[string]int64{}}
+}
+
+func (r *recordingTelemetry) StartSpan(ctx context.Context, name string, attrs ...TelemetryAttribute) (context.Context, TelemetrySpan) {
+	span := &recordingSpan{name: name, attrs: map[string]any{}}
+	span.parent, _ = ctx.Value(recordingSpanKey{}).(*recordingSpan)
+	span.SetAttributes(attrs...)
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return context.WithValue(ctx, recordingSpanKey{}, span), span
+}
+
+func (r *recordingTelemetry) RecordDuration(_ context.Context, metric string, _ time.Duration, _ ...TelemetryAttribute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics[metric]++
+}
+
+func (r *recordingTelemetry) AddCount(_ context.Context, metric string, n int64, attrs ...TelemetryAttribute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics[metric]++
+	for _, attr := range attrs {
+		if attr.Key == AttributeRetryReason {
+			r.retries[attr.Value.(string)] += n
+		}
+	}
+}
+
+// spanNames returns the names of the spans whose parent has the given name ("" for the root spans).
+func (r *recordingTelemetry) spanNames(parent string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := []string{}
+	for _, span := range r.spans {
+		switch {
+		case span.parent == nil && parent == "":
+			names = append(names, span.name)
+		case span.parent != nil && span.parent.name == parent:
+			names = append(names, span.name)
+		}
+	}
+	return names
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...TelemetryAttribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordingSpan) RecordError(err error) { s.err = err }
+func (s *recordingSpan) End()                  { s.ended = true }
+
+func init() {
+	Endpoint{
+		DocumentationURL: "https://developer.broadcom.com/xapis/vmware-cloud-director-api/38.1/",
+		Name:             "TestTelemetryJob",
+		Description:      "Test telemetry job",
+		Method:           MethodPOST,
+		SubClient:        ClientVmware,
+		PathTemplate:     "/api/test/telemetry/job",
+		BodyResponseType: Job{},
+	}.Register()
+}
+
+func Test_WithTelemetry(t *testing.T) {
+	s := newSettings(mockOrg)
+
+	assert.NotNil(t, WithTelemetry(nil)(s))
+	assert.Nil(t, WithTelemetry(newRecordingTelemetry())(s))
+	assert.NotNil(t, s.Telemetry)
+}
+
+func Test_Do_Telemetry(t *testing.T) {
+	t.Run("retries", func(t *testing.T) {
+		telemetry := newRecordingTelemetry()
+		client, err := newMockClient(WithTelemetry(telemetry))
+		assert.Nil(t, err, "Error creating mock client")
+
+		retryTransientCalls.Store(0)
+		ep, err := GetEndpoint("TestRetryTransient")
+		assert.Nil(t, err)
+
+		_, err = client.Do(t.Context(), ep)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{"TestRetryTransient"}, telemetry.spanNames(""))
+		assert.Equal(t, []string{"TestRetryTransient retry", "TestRetryTransient retry"}, telemetry.spanNames("TestRetryTransient"))
+		assert.Equal(t, int64(2), telemetry.retries[string(RetryReasonTransientError)])
+		assert.Equal(t, 1, telemetry.metrics[MetricRequestDuration])
+
+		for _, span := range telemetry.spans {
+			assert.True(t, span.ended, "span %s not ended", span.name)
+			assert.Equal(t, "TestRetryTransient", span.attrs[AttributeEndpoint])
+			assert.Equal(t, string(ClientVmware), span.attrs[AttributeSubClient])
+		}
+	})
+
+	t.Run("busy entity", func(t *testing.T) {
+		telemetry := newRecordingTelemetry()
+		client, err := newMockClient(WithTelemetry(telemetry))
+		assert.Nil(t, err, "Error creating mock client")
+
+		ep, err := GetEndpoint("TestRetryBusy")
+		assert.Nil(t, err)
+
+		_, err = client.Do(t.Context(), ep)
+		assert.NotNil(t, err)
+
+		assert.Equal(t, int64(2), telemetry.retries[string(RetryReasonBusyEntity)])
+		assert.Equal(t, 2, telemetry.metrics[MetricBusyEntityWait])
+
+		assert.Equal(t, "TestRetryBusy", telemetry.spans[0].name)
+		assert.NotNil(t, telemetry.spans[0].err)
+		for _, span := range telemetry.spans[1:] {
+			assert.Equal(t, "TestRetryBusy retry", span.name)
+			assert.Equal(t, 409, span.attrs[AttributeStatusCode])
+			assert.NotNil(t, span.err)
+		}
+	})
+
+	t.Run("job", func(t *testing.T) {
+		telemetry := newRecordingTelemetry()
+		client, err := newMockClient(WithTelemetry(telemetry))
+		assert.Nil(t, err, "Error creating mock client")
+
+		ep, err := GetEndpoint("TestTelemetryJob")
+		assert.Nil(t, err)
+
+		_, err = client.Do(t.Context(), ep)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{"TestTelemetryJob job"}, telemetry.spanNames("TestTelemetryJob"))
+		assert.NotEmpty(t, telemetry.spanNames("TestTelemetryJob job"))
+		for _, name := range telemetry.spanNames("TestTelemetryJob job") {
+			assert.Equal(t, "TestTelemetryJob job poll", name)
+		}
+		assert.Equal(t, 1, telemetry.metrics[MetricJobDuration])
+
+		for _, span := range telemetry.spans {
+			assert.True(t, span.ended, "span %s not ended", span.name)
+		}
+	})
+
+	t.Run("caller span", func(t *testing.T) {
+		telemetry := newRecordingTelemetry()
+		client, err := newMockClient(WithTelemetry(telemetry))
+		assert.Nil(t, err, "Error creating mock client")
+
+		ctx, parent := telemetry.StartSpan(t.Context(), "caller")
+		defer parent.End()
+
+		ep, err := GetEndpoint("TestDryRunGet")
+		assert.Nil(t, err)
+
+		_, err = client.Do(ctx, ep, WithPathParam(ep.PathParams[0], "abc"))
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{"TestDryRunGet"}, telemetry.spanNames("caller"))
+	})
+}
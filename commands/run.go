@@ -11,13 +11,22 @@ package commands
 
 import (
 	"context"
-	"errors"
 
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
 	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/xlog"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/pkg/errors"
 )
 
-func (c *Command) Run(ctx context.Context, client, params any) (any, error) {
+// Run validates the params and runs the command.
+// The request options apply to all the calls made by the command.
+// The errors of the validation of the params match errors.ErrValidation.
+func (c *Command) Run(ctx context.Context, client, params any, opts ...cav.RequestOption) (any, error) {
 	c.params = params
+	ctx = cav.ContextWithRequestOptions(ctx, opts...)
+	if c.ModelType != nil && cav.AsyncJobsFromContext(ctx) {
+		// The model is read once the jobs of the command are terminated.
+		ctx = cav.ContextWithRequestOptions(ctx, cav.WithoutAsyncJobs())
+	}
 
 	// If PreParamsRunnerFunc is defined, call it
 	if c.PreParamsRunnerFunc != nil {
@@ -30,7 +39,7 @@ func (c *Command) Run(ctx context.Context, client, params any) (any, error) {
 
 	if len(c.ParamsSpecs) > 0 {
 		if err := buildAndValidateDynamicStruct(c.ParamsSpecs, c.params); err != nil {
-			return nil, err
+			return nil, errors.WithKind(err, errors.ErrValidation)
 		}
 	}
 
@@ -50,7 +59,7 @@ func (c *Command) Run(ctx context.Context, client, params any) (any, error) {
 			return nil, errors.New("client must implement cav.Client interface")
 		}
 		if err := c.ParamsRules.validate(cavClient, c.params); err != nil {
-			return nil, err
+			return nil, errors.WithKind(err, errors.ErrValidation)
 		}
 	}
 
@@ -0,0 +1,63 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package endpoints
+
+import (
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+)
+
+// ListNetbackupProtectionLevels - List the Netbackup protection levels available for the organization
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/Netbackup/getProtectionLevels
+func ListNetbackupProtectionLevels() *cav.Endpoint {
+	return cav.MustGetEndpoint("ListNetbackupProtectionLevels")
+}
+
+// AssignNetbackupVmProtectionLevel - Assign a Netbackup protection level to a VM
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/Netbackup/postVmProtectionLevel
+func AssignNetbackupVmProtectionLevel() *cav.Endpoint {
+	return cav.MustGetEndpoint("AssignNetbackupVmProtectionLevel")
+}
+
+// UnassignNetbackupVmProtectionLevel - Unassign a Netbackup protection level from a VM
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/Netbackup/deleteVmProtectionLevel
+func UnassignNetbackupVmProtectionLevel() *cav.Endpoint {
+	return cav.MustGetEndpoint("UnassignNetbackupVmProtectionLevel")
+}
+
+// AssignNetbackupVappProtectionLevel - Assign a Netbackup protection level to a vApp
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/Netbackup/postVappProtectionLevel
+func AssignNetbackupVappProtectionLevel() *cav.Endpoint {
+	return cav.MustGetEndpoint("AssignNetbackupVappProtectionLevel")
+}
+
+// UnassignNetbackupVappProtectionLevel - Unassign a Netbackup protection level from a vApp
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/Netbackup/deleteVappProtectionLevel
+func UnassignNetbackupVappProtectionLevel() *cav.Endpoint {
+	return cav.MustGetEndpoint("UnassignNetbackupVappProtectionLevel")
+}
+
+// ListNetbackupVmBackups - List the Netbackup backups of a VM
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/Netbackup/getVmBackups
+func ListNetbackupVmBackups() *cav.Endpoint {
+	return cav.MustGetEndpoint("ListNetbackupVmBackups")
+}
+
+// RestoreNetbackupVm - Restore a VM from a Netbackup backup
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/Netbackup/postVmRestore
+func RestoreNetbackupVm() *cav.Endpoint {
+	return cav.MustGetEndpoint("RestoreNetbackupVm")
+}
@@ -0,0 +1,119 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package endpoints
+
+import (
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+)
+
+// ListS3Buckets - List the S3 buckets
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBuckets.html
+func ListS3Buckets() *cav.Endpoint {
+	return cav.MustGetEndpoint("ListS3Buckets")
+}
+
+// CreateS3Bucket - Create a S3 bucket
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_CreateBucket.html
+func CreateS3Bucket() *cav.Endpoint {
+	return cav.MustGetEndpoint("CreateS3Bucket")
+}
+
+// DeleteS3Bucket - Delete a S3 bucket
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucket.html
+func DeleteS3Bucket() *cav.Endpoint {
+	return cav.MustGetEndpoint("DeleteS3Bucket")
+}
+
+// GetS3BucketVersioning - Get the versioning configuration of a S3 bucket
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketVersioning.html
+func GetS3BucketVersioning() *cav.Endpoint {
+	return cav.MustGetEndpoint("GetS3BucketVersioning")
+}
+
+// PutS3BucketVersioning - Set the versioning configuration of a S3 bucket
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketVersioning.html
+func PutS3BucketVersioning() *cav.Endpoint {
+	return cav.MustGetEndpoint("PutS3BucketVersioning")
+}
+
+// GetS3BucketLifecycle - Get the lifecycle configuration of a S3 bucket
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLifecycleConfiguration.html
+func GetS3BucketLifecycle() *cav.Endpoint {
+	return cav.MustGetEndpoint("GetS3BucketLifecycle")
+}
+
+// PutS3BucketLifecycle - Set the lifecycle configuration of a S3 bucket
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+func PutS3BucketLifecycle() *cav.Endpoint {
+	return cav.MustGetEndpoint("PutS3BucketLifecycle")
+}
+
+// DeleteS3BucketLifecycle - Delete the lifecycle configuration of a S3 bucket
+//
+// DocumentationURL: https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketLifecycle.html
+func DeleteS3BucketLifecycle() *cav.Endpoint {
+	return cav.MustGetEndpoint("DeleteS3BucketLifecycle")
+}
+
+// GetS3BucketUsage - Get the usage of a S3 bucket
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/S3/getS3BucketUsage
+func GetS3BucketUsage() *cav.Endpoint {
+	return cav.MustGetEndpoint("GetS3BucketUsage")
+}
+
+// ListS3Users - List the S3 users of the organization
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/S3/getS3Users
+func ListS3Users() *cav.Endpoint {
+	return cav.MustGetEndpoint("ListS3Users")
+}
+
+// CreateS3User - Create a S3 user
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/S3/postS3User
+func CreateS3User() *cav.Endpoint {
+	return cav.MustGetEndpoint("CreateS3User")
+}
+
+// DeleteS3User - Delete a S3 user and its keys
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/S3/deleteS3User
+func DeleteS3User() *cav.Endpoint {
+	return cav.MustGetEndpoint("DeleteS3User")
+}
+
+// ListS3UserKeys - List the keys of a S3 user
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/S3/getS3UserKeys
+func ListS3UserKeys() *cav.Endpoint {
+	return cav.MustGetEndpoint("ListS3UserKeys")
+}
+
+// CreateS3UserKey - Create a key for a S3 user
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/S3/postS3UserKey
+func CreateS3UserKey() *cav.Endpoint {
+	return cav.MustGetEndpoint("CreateS3UserKey")
+}
+
+// DeleteS3UserKey - Delete a key of a S3 user
+//
+// DocumentationURL: https://swagger.cloudavenue.orange-business.com/#/S3/deleteS3UserKey
+func DeleteS3UserKey() *cav.Endpoint {
+	return cav.MustGetEndpoint("DeleteS3UserKey")
+}
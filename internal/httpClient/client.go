@@ -10,13 +10,32 @@
 package httpclient
 
 import (
+	"net/http"
+
 	"resty.dev/v3"
 )
 
 var DebugMode bool
 
 func NewHTTPClient() *resty.Client {
-	return resty.New().
+	return configure(resty.New())
+}
+
+// NewPooledHTTPClient returns a new HTTP client whose transport is built from the given settings.
+// The client is intended to be long-lived and shared between requests to reuse the connections.
+// If settings is nil, the resty defaults are used.
+func NewPooledHTTPClient(settings *resty.TransportSettings) *resty.Client {
+	return configure(resty.NewWithTransportSettings(settings))
+}
+
+// NewHTTPClientWithTransport returns a new HTTP client using the given transport.
+func NewHTTPClientWithTransport(transport http.RoundTripper) *resty.Client {
+	return configure(resty.New().SetTransport(transport))
+}
+
+// configure applies the default configuration of the SDK to the HTTP client.
+func configure(c *resty.Client) *resty.Client {
+	return c.
 		SetLogger(logger()).
 		SetHeader("User-Agent", "GoCloudAvenueSDK/2.0").
 		SetResponseBodyUnlimitedReads(true).
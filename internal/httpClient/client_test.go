@@ -9,7 +9,13 @@
 
 package httpclient
 
-import "testing"
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"resty.dev/v3"
+)
 
 func Test_NewHTTPClient(t *testing.T) {
 	client := NewHTTPClient()
@@ -20,3 +26,36 @@ func Test_NewHTTPClient(t *testing.T) {
 		t.Error("NewHTTPClient() should not be in debug mode by default")
 	}
 }
+
+func Test_NewPooledHTTPClient(t *testing.T) {
+	client := NewPooledHTTPClient(&resty.TransportSettings{
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 5,
+		IdleConnTimeout:     30 * time.Second,
+	})
+	if client == nil {
+		t.Fatal("NewPooledHTTPClient() returned nil")
+	}
+
+	transport, ok := client.Transport().(*http.Transport)
+	if !ok {
+		t.Fatal("NewPooledHTTPClient() should use a *http.Transport")
+	}
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 5 || transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("NewPooledHTTPClient() transport settings not applied: %d %d %s", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if client.Header().Get("User-Agent") == "" {
+		t.Error("NewPooledHTTPClient() should set the User-Agent header")
+	}
+}
+
+func Test_NewHTTPClientWithTransport(t *testing.T) {
+	transport := &http.Transport{}
+	client := NewHTTPClientWithTransport(transport)
+	if client == nil {
+		t.Fatal("NewHTTPClientWithTransport() returned nil")
+	}
+	if client.Transport() != transport {
+		t.Error("NewHTTPClientWithTransport() should use the given transport")
+	}
+}
@@ -11,7 +11,6 @@ package iendpoints
 
 import (
 	"fmt"
-	"regexp"
 
 	"resty.dev/v3"
 
@@ -63,16 +62,15 @@ func init() {
 			},
 			{
 				Name:        "filter",
-				Description: "The filter to apply to the query",
+				Description: "FIQL filter to apply to the query, e.g. name==tn01e02ocb0001234spt101.",
 				Required:    false,
-				ValidatorFunc: func(value string) error {
-					// check if the value is a valid key==value pair
-					x := regexp.MustCompile(`^[a-zA-Z0-9_]+==.*`)
-					if !x.MatchString(value) {
-						return fmt.Errorf("invalid filter format, expected key==value")
-					}
-
-					return nil
+				FilterFields: []cav.FilterField{
+					filterFieldString("name"),
+					filterFieldString("orgVdcName"),
+					filterFieldBool("isBusy"),
+					filterFieldString("gatewayStatus"),
+					filterFieldNumber("numberOfExtNetworks"),
+					filterFieldNumber("numberOfOrgNetworks"),
 				},
 			},
 		},
@@ -140,6 +138,9 @@ func init() {
 		QueryParams:      nil,
 		BodyRequestType:  itypes.ApiRequestEdgeGateway{},
 		BodyResponseType: cav.Job{},
+
+		// The T0 lists the edge gateways.
+		InvalidatedFamilies: []string{"T0"},
 	}.Register()
 
 	// DeleteEdgeGateway
@@ -167,6 +168,9 @@ func init() {
 		QueryParams:      nil,
 		BodyRequestType:  nil,
 		BodyResponseType: cav.Job{},
+
+		// The T0 lists the edge gateways.
+		InvalidatedFamilies: []string{"T0"},
 	}.Register()
 
 	// ListEdgeGateway
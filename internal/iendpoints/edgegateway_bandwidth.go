@@ -49,5 +49,8 @@ func init() {
 		JobOptions: &cav.JobOptions{
 			PollInterval: time.Second * 1,
 		},
+
+		// The T0 lists the bandwidth of the edge gateways.
+		InvalidatedFamilies: []string{"T0"},
 	}.Register()
 }
@@ -26,5 +26,8 @@ func init() {
 		PathTemplate:     "/api/customers/v2.0/services",
 		BodyResponseType: cav.Job{},
 		BodyRequestType:  itypes.ApiRequestEdgegatewayPublicIP{},
+
+		// The network services of the edge gateways and of the T0 list the public IPs.
+		InvalidatedFamilies: []string{"EdgeGatewayServices", "T0"},
 	}.Register()
 }
@@ -191,6 +191,8 @@ func init() {
 		PathTemplate:     "/api/customers/v2.0/services",
 		BodyResponseType: cav.Job{},
 		BodyRequestType:  itypes.ApiRequestNetworkServicesCavSvc{},
+
+		InvalidatedFamilies: []string{"EdgeGatewayServices", "T0"},
 	}.Register()
 
 	cav.Endpoint{
@@ -208,5 +210,7 @@ func init() {
 			},
 		},
 		BodyResponseType: cav.Job{},
+
+		InvalidatedFamilies: []string{"EdgeGatewayServices", "T0"},
 	}.Register()
 }
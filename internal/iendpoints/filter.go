@@ -0,0 +1,65 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package iendpoints
+
+import (
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/common-go/extractor"
+	"github.com/orange-cloudavenue/common-go/validators"
+)
+
+// equalityOperators are the operators of the fields which cannot be ordered, e.g. booleans.
+var equalityOperators = []cav.FilterOperator{cav.FilterEqual, cav.FilterNotEqual}
+
+// filterFieldString returns a filter field compared with a string, the wildcard * is allowed.
+func filterFieldString(name string) cav.FilterField {
+	return cav.FilterField{
+		Name:      name,
+		Operators: equalityOperators,
+		Wildcard:  true,
+	}
+}
+
+// filterFieldNumber returns a filter field compared with a number, all the operators are allowed.
+func filterFieldNumber(name string) cav.FilterField {
+	return cav.FilterField{
+		Name: name,
+		ValidatorFunc: func(value string) error {
+			return validators.New().Var(value, "number")
+		},
+	}
+}
+
+// filterFieldBool returns a filter field compared with true or false.
+func filterFieldBool(name string) cav.FilterField {
+	return cav.FilterField{
+		Name:      name,
+		Operators: equalityOperators,
+		ValidatorFunc: func(value string) error {
+			return validators.New().Var(value, "oneof=true false")
+		},
+	}
+}
+
+// filterFieldURN returns a filter field compared with the URN of the type (e.g. vdc).
+// If toUUID is true, the URN is replaced by its UUID as the /api/query endpoints expect.
+func filterFieldURN(name, urnType string, toUUID bool) cav.FilterField {
+	f := cav.FilterField{
+		Name:      name,
+		Operators: equalityOperators,
+		ValidatorFunc: func(value string) error {
+			return validators.New().Var(value, "urn="+urnType)
+		},
+	}
+	if toUUID {
+		f.TransformFunc = extractor.ExtractUUID
+	}
+	return f
+}
@@ -0,0 +1,206 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package iendpoints
+
+import (
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/common-go/extractor"
+	"github.com/orange-cloudavenue/common-go/validators"
+)
+
+//go:generate endpoint-generator -path netbackup.go -output netbackup
+
+func init() {
+	// * ListNetbackupProtectionLevels
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/getProtectionLevels",
+		Name:             "ListNetbackupProtectionLevels",
+		Description:      "List the Netbackup protection levels available for the organization",
+		Method:           cav.MethodGET,
+		SubClient:        cav.ClientNetbackup,
+		PathTemplate:     "/NetBackupSelfService/Api/v6/protectionlevels",
+		BodyResponseType: itypes.ApiResponseListNetbackupProtectionLevels{},
+	}.Register()
+
+	// * AssignNetbackupVmProtectionLevel
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/postVmProtectionLevel",
+		Name:             "AssignNetbackupVmProtectionLevel",
+		Description:      "Assign a Netbackup protection level to a VM",
+		Method:           cav.MethodPOST,
+		SubClient:        cav.ClientNetbackup,
+		PathTemplate:     "/NetBackupSelfService/Api/v6/vcloud/vms/{vmId}/protection/levels",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "vmId",
+				Description: "The ID of the VM.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,urn=vm")
+				},
+				TransformFunc: func(value string) (string, error) {
+					// Transform the value to a uuidv4 format
+					return extractor.ExtractUUID(value)
+				},
+			},
+		},
+		BodyRequestType:  itypes.ApiRequestNetbackupProtectionLevel{},
+		BodyResponseType: cav.Job{},
+	}.Register()
+
+	// * UnassignNetbackupVmProtectionLevel
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/deleteVmProtectionLevel",
+		Name:             "UnassignNetbackupVmProtectionLevel",
+		Description:      "Unassign a Netbackup protection level from a VM",
+		Method:           cav.MethodDELETE,
+		SubClient:        cav.ClientNetbackup,
+		PathTemplate:     "/NetBackupSelfService/Api/v6/vcloud/vms/{vmId}/protection/levels/{protectionLevelId}",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "vmId",
+				Description: "The ID of the VM.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,urn=vm")
+				},
+				TransformFunc: func(value string) (string, error) {
+					// Transform the value to a uuidv4 format
+					return extractor.ExtractUUID(value)
+				},
+			},
+			{
+				Name:        "protectionLevelId",
+				Description: "The ID of the protection level.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,number")
+				},
+			},
+		},
+		BodyResponseType: cav.Job{},
+	}.Register()
+
+	// * AssignNetbackupVappProtectionLevel
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/postVappProtectionLevel",
+		Name:             "AssignNetbackupVappProtectionLevel",
+		Description:      "Assign a Netbackup protection level to a vApp",
+		Method:           cav.MethodPOST,
+		SubClient:        cav.ClientNetbackup,
+		PathTemplate:     "/NetBackupSelfService/Api/v6/vcloud/vapps/{vappId}/protection/levels",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "vappId",
+				Description: "The ID of the vApp.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,urn=vapp")
+				},
+				TransformFunc: func(value string) (string, error) {
+					// Transform the value to a uuidv4 format
+					return extractor.ExtractUUID(value)
+				},
+			},
+		},
+		BodyRequestType:  itypes.ApiRequestNetbackupProtectionLevel{},
+		BodyResponseType: cav.Job{},
+	}.Register()
+
+	// * UnassignNetbackupVappProtectionLevel
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/deleteVappProtectionLevel",
+		Name:             "UnassignNetbackupVappProtectionLevel",
+		Description:      "Unassign a Netbackup protection level from a vApp",
+		Method:           cav.MethodDELETE,
+		SubClient:        cav.ClientNetbackup,
+		PathTemplate:     "/NetBackupSelfService/Api/v6/vcloud/vapps/{vappId}/protection/levels/{protectionLevelId}",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "vappId",
+				Description: "The ID of the vApp.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,urn=vapp")
+				},
+				TransformFunc: func(value string) (string, error) {
+					// Transform the value to a uuidv4 format
+					return extractor.ExtractUUID(value)
+				},
+			},
+			{
+				Name:        "protectionLevelId",
+				Description: "The ID of the protection level.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,number")
+				},
+			},
+		},
+		BodyResponseType: cav.Job{},
+	}.Register()
+
+	// * ListNetbackupVmBackups
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/getVmBackups",
+		Name:             "ListNetbackupVmBackups",
+		Description:      "List the Netbackup backups of a VM",
+		Method:           cav.MethodGET,
+		SubClient:        cav.ClientNetbackup,
+		PathTemplate:     "/NetBackupSelfService/Api/v6/vcloud/vms/{vmId}/backups",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "vmId",
+				Description: "The ID of the VM.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,urn=vm")
+				},
+				TransformFunc: func(value string) (string, error) {
+					// Transform the value to a uuidv4 format
+					return extractor.ExtractUUID(value)
+				},
+			},
+		},
+		BodyResponseType: itypes.ApiResponseListNetbackupBackups{},
+	}.Register()
+
+	// * RestoreNetbackupVm
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/Netbackup/postVmRestore",
+		Name:             "RestoreNetbackupVm",
+		Description:      "Restore a VM from a Netbackup backup",
+		Method:           cav.MethodPOST,
+		SubClient:        cav.ClientNetbackup,
+		PathTemplate:     "/NetBackupSelfService/Api/v6/vcloud/vms/{vmId}/backups/{backupId}/restore",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "vmId",
+				Description: "The ID of the backed up VM.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,urn=vm")
+				},
+				TransformFunc: func(value string) (string, error) {
+					// Transform the value to a uuidv4 format
+					return extractor.ExtractUUID(value)
+				},
+			},
+			{
+				Name:        "backupId",
+				Description: "The ID of the backup to restore.",
+				Required:    true,
+			},
+		},
+		BodyRequestType:  itypes.ApiRequestRestoreNetbackupVM{},
+		BodyResponseType: cav.Job{},
+	}.Register()
+}
@@ -0,0 +1,266 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package iendpoints
+
+import (
+	"fmt"
+	"regexp"
+
+	"resty.dev/v3"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/cav"
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/internal/itypes"
+	"github.com/orange-cloudavenue/common-go/validators"
+)
+
+//go:generate endpoint-generator -path s3.go -output s3
+
+// regexS3BucketName matches the names allowed by S3 for the buckets.
+var regexS3BucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// s3BucketNamePathParam is the path parameter of the bucket name shared by the bucket endpoints.
+var s3BucketNamePathParam = cav.PathParam{
+	Name:        "bucketName",
+	Description: "The name of the bucket.",
+	Required:    true,
+	ValidatorFunc: func(value string) error {
+		if !regexS3BucketName.MatchString(value) {
+			return fmt.Errorf("invalid bucket name %q: 3 to 63 lowercase letters, numbers, dots and hyphens are allowed", value)
+		}
+		return nil
+	},
+}
+
+// s3SubResource returns a request middleware adding the sub-resource query parameter
+// (e.g. ?versioning) used by S3 to select the configuration of the bucket.
+func s3SubResource(name string) resty.RequestMiddleware {
+	return func(_ *resty.Client, req *resty.Request) error {
+		req.SetQueryParam(name, "")
+		return nil
+	}
+}
+
+// s3XMLBody is a request middleware setting the content type of the XML documents sent to S3.
+func s3XMLBody(_ *resty.Client, req *resty.Request) error {
+	req.SetHeader("Content-Type", "application/xml")
+	return nil
+}
+
+func init() {
+	// * ListS3Buckets
+	cav.Endpoint{
+		DocumentationURL: "https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBuckets.html",
+		Name:             "ListS3Buckets",
+		Description:      "List the S3 buckets",
+		Method:           cav.MethodGET,
+		SubClient:        cav.ClientS3,
+		PathTemplate:     "/",
+		BodyResponseType: itypes.ApiResponseListS3Buckets{},
+	}.Register()
+
+	// * CreateS3Bucket
+	cav.Endpoint{
+		DocumentationURL: "https://docs.aws.amazon.com/AmazonS3/latest/API/API_CreateBucket.html",
+		Name:             "CreateS3Bucket",
+		Description:      "Create a S3 bucket",
+		Method:           cav.MethodPUT,
+		SubClient:        cav.ClientS3,
+		PathTemplate:     "/{bucketName}",
+		PathParams:       []cav.PathParam{s3BucketNamePathParam},
+	}.Register()
+
+	// * DeleteS3Bucket
+	cav.Endpoint{
+		DocumentationURL: "https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucket.html",
+		Name:             "DeleteS3Bucket",
+		Description:      "Delete a S3 bucket",
+		Method:           cav.MethodDELETE,
+		SubClient:        cav.ClientS3,
+		PathTemplate:     "/{bucketName}",
+		PathParams:       []cav.PathParam{s3BucketNamePathParam},
+	}.Register()
+
+	// * GetS3BucketVersioning
+	cav.Endpoint{
+		DocumentationURL:   "https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketVersioning.html",
+		Name:               "GetS3BucketVersioning",
+		Description:        "Get the versioning configuration of a S3 bucket",
+		Method:             cav.MethodGET,
+		SubClient:          cav.ClientS3,
+		PathTemplate:       "/{bucketName}",
+		PathParams:         []cav.PathParam{s3BucketNamePathParam},
+		RequestMiddlewares: []resty.RequestMiddleware{s3SubResource("versioning")},
+		BodyResponseType:   itypes.ApiS3BucketVersioning{},
+	}.Register()
+
+	// * PutS3BucketVersioning
+	cav.Endpoint{
+		DocumentationURL:   "https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketVersioning.html",
+		Name:               "PutS3BucketVersioning",
+		Description:        "Set the versioning configuration of a S3 bucket",
+		Method:             cav.MethodPUT,
+		SubClient:          cav.ClientS3,
+		PathTemplate:       "/{bucketName}",
+		PathParams:         []cav.PathParam{s3BucketNamePathParam},
+		RequestMiddlewares: []resty.RequestMiddleware{s3SubResource("versioning"), s3XMLBody},
+		BodyRequestType:    itypes.ApiS3BucketVersioning{},
+	}.Register()
+
+	// * GetS3BucketLifecycle
+	cav.Endpoint{
+		DocumentationURL:   "https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLifecycleConfiguration.html",
+		Name:               "GetS3BucketLifecycle",
+		Description:        "Get the lifecycle configuration of a S3 bucket",
+		Method:             cav.MethodGET,
+		SubClient:          cav.ClientS3,
+		PathTemplate:       "/{bucketName}",
+		PathParams:         []cav.PathParam{s3BucketNamePathParam},
+		RequestMiddlewares: []resty.RequestMiddleware{s3SubResource("lifecycle")},
+		BodyResponseType:   itypes.ApiS3BucketLifecycle{},
+	}.Register()
+
+	// * PutS3BucketLifecycle
+	cav.Endpoint{
+		DocumentationURL:   "https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html",
+		Name:               "PutS3BucketLifecycle",
+		Description:        "Set the lifecycle configuration of a S3 bucket",
+		Method:             cav.MethodPUT,
+		SubClient:          cav.ClientS3,
+		PathTemplate:       "/{bucketName}",
+		PathParams:         []cav.PathParam{s3BucketNamePathParam},
+		RequestMiddlewares: []resty.RequestMiddleware{s3SubResource("lifecycle"), s3XMLBody},
+		BodyRequestType:    itypes.ApiS3BucketLifecycle{},
+	}.Register()
+
+	// * DeleteS3BucketLifecycle
+	cav.Endpoint{
+		DocumentationURL:   "https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketLifecycle.html",
+		Name:               "DeleteS3BucketLifecycle",
+		Description:        "Delete the lifecycle configuration of a S3 bucket",
+		Method:             cav.MethodDELETE,
+		SubClient:          cav.ClientS3,
+		PathTemplate:       "/{bucketName}",
+		PathParams:         []cav.PathParam{s3BucketNamePathParam},
+		RequestMiddlewares: []resty.RequestMiddleware{s3SubResource("lifecycle")},
+	}.Register()
+
+	// * GetS3BucketUsage
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/getS3BucketUsage",
+		Name:             "GetS3BucketUsage",
+		Description:      "Get the usage of a S3 bucket",
+		Method:           cav.MethodGET,
+		SubClient:        cav.ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/buckets/{bucketName}/usage",
+		PathParams:       []cav.PathParam{s3BucketNamePathParam},
+		BodyResponseType: itypes.ApiResponseGetS3BucketUsage{},
+	}.Register()
+
+	// * ListS3Users
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/getS3Users",
+		Name:             "ListS3Users",
+		Description:      "List the S3 users of the organization",
+		Method:           cav.MethodGET,
+		SubClient:        cav.ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/users",
+		BodyResponseType: itypes.ApiResponseListS3Users{},
+	}.Register()
+
+	// * CreateS3User
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/postS3User",
+		Name:             "CreateS3User",
+		Description:      "Create a S3 user",
+		Method:           cav.MethodPOST,
+		SubClient:        cav.ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/users",
+		BodyRequestType:  itypes.ApiRequestCreateS3User{},
+		BodyResponseType: itypes.ApiResponseS3User{},
+	}.Register()
+
+	// * DeleteS3User
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/deleteS3User",
+		Name:             "DeleteS3User",
+		Description:      "Delete a S3 user and its keys",
+		Method:           cav.MethodDELETE,
+		SubClient:        cav.ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/users/{userName}",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "userName",
+				Description: "The name of the S3 user.",
+				Required:    true,
+			},
+		},
+	}.Register()
+
+	// * ListS3UserKeys
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/getS3UserKeys",
+		Name:             "ListS3UserKeys",
+		Description:      "List the keys of a S3 user",
+		Method:           cav.MethodGET,
+		SubClient:        cav.ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/users/{userName}/keys",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "userName",
+				Description: "The name of the S3 user.",
+				Required:    true,
+			},
+		},
+		BodyResponseType: itypes.ApiResponseListS3UserKeys{},
+	}.Register()
+
+	// * CreateS3UserKey
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/postS3UserKey",
+		Name:             "CreateS3UserKey",
+		Description:      "Create a key for a S3 user",
+		Method:           cav.MethodPOST,
+		SubClient:        cav.ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/users/{userName}/keys",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "userName",
+				Description: "The name of the S3 user.",
+				Required:    true,
+			},
+		},
+		BodyResponseType: itypes.ApiResponseS3UserKey{},
+	}.Register()
+
+	// * DeleteS3UserKey
+	cav.Endpoint{
+		DocumentationURL: "https://swagger.cloudavenue.orange-business.com/#/S3/deleteS3UserKey",
+		Name:             "DeleteS3UserKey",
+		Description:      "Delete a key of a S3 user",
+		Method:           cav.MethodDELETE,
+		SubClient:        cav.ClientCerberus,
+		PathTemplate:     "/api/customers/v2.0/s3/users/{userName}/keys/{accessKey}",
+		PathParams: []cav.PathParam{
+			{
+				Name:        "userName",
+				Description: "The name of the S3 user.",
+				Required:    true,
+			},
+			{
+				Name:        "accessKey",
+				Description: "The access key to delete.",
+				Required:    true,
+				ValidatorFunc: func(value string) error {
+					return validators.New().Var(value, "required,alphanum")
+				},
+			},
+		},
+	}.Register()
+}
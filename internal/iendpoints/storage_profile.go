@@ -11,7 +11,6 @@ package iendpoints
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -23,7 +22,6 @@ import (
 	"github.com/orange-cloudavenue/common-go/extractor"
 	"github.com/orange-cloudavenue/common-go/generator"
 	"github.com/orange-cloudavenue/common-go/urn"
-	"github.com/orange-cloudavenue/common-go/validators"
 )
 
 //go:generate endpoint-generator -path storage_profile.go -output storage_profile
@@ -40,65 +38,14 @@ func init() {
 		QueryParams: []cav.QueryParam{
 			{
 				Name:        "filter",
-				Description: "Filter to apply to the list of VDC Storage Profile. Format: key==value. Supported keys: vdc, vdcName, name, id.",
-				ValidatorFunc: func(value string) error {
-					// Support multiple filters separated by ';'
-					filters := strings.Split(value, ";")
-					for _, filter := range filters {
-						valueSplit := strings.Split(filter, "==")
-						if len(valueSplit) != 2 {
-							return errors.New("filter must be in the format 'key==value' or 'key1==value1;key2==value2'")
-						}
-						switch valueSplit[0] {
-						case "vdc":
-							if err := validators.New().Var(valueSplit[1], "urn=vdc"); err != nil {
-								return err
-							}
-						case "vdcName", "name":
-							// No specific format required
-						case "id":
-							if err := validators.New().Var(valueSplit[1], "urn=vdcstorageProfile"); err != nil {
-								return err
-							}
-						default:
-							return fmt.Errorf("filter key '%s' is not allowed", valueSplit[0])
-						}
-					}
-					return nil
-				},
-				TransformFunc: func(value string) (string, error) {
-					// Support multiple filters separated by ';'
-					filters := strings.Split(value, ";")
-					var transformed []string
-					for _, filter := range filters {
-						valueSplit := strings.Split(filter, "==")
-						if len(valueSplit) != 2 {
-							return "", errors.New("filter must be in the format 'key==value' or 'key1==value1;key2==value2'")
-						}
-						switch valueSplit[0] {
-						case "vdc":
-							v, err := extractor.ExtractUUID(valueSplit[1])
-							if err != nil {
-								return "", err
-							}
-							transformed = append(transformed, fmt.Sprintf("vdc==%s", v))
-						case "vdcName":
-							v := valueSplit[1]
-							transformed = append(transformed, fmt.Sprintf("vdcName==%s", v))
-						case "name":
-							v := valueSplit[1]
-							transformed = append(transformed, fmt.Sprintf("name==%s", v))
-						case "id":
-							v, err := extractor.ExtractUUID(valueSplit[1])
-							if err != nil {
-								return "", err
-							}
-							transformed = append(transformed, fmt.Sprintf("id==%s", v))
-						default:
-							return "", fmt.Errorf("filter key '%s' is not allowed", valueSplit[0])
-						}
-					}
-					return strings.Join(transformed, ";"), nil
+				Description: "FIQL filter to apply to the list of VDC Storage Profiles, e.g. vdcName==my-vdc;name==gold.",
+				FilterFields: []cav.FilterField{
+					filterFieldURN("vdc", "vdc", true),
+					filterFieldString("vdcName"),
+					filterFieldString("name"),
+					filterFieldURN("id", "vdcstorageProfile", true),
+					filterFieldBool("isEnabled"),
+					filterFieldBool("isDefaultStorageProfile"),
 				},
 			},
 			{
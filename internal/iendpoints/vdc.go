@@ -11,10 +11,8 @@ package iendpoints
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
-	"slices"
 	"strings"
 
 	"resty.dev/v3"
@@ -41,19 +39,17 @@ func init() {
 		QueryParams: []cav.QueryParam{
 			{
 				Name:        "filter",
-				Description: "Filter to apply to the list of VDCs. Format: key==value. Allowed keys: name, id.",
-				ValidatorFunc: func(value string) error {
-					valueSplit := strings.Split(value, "==")
-					if len(valueSplit) != 2 {
-						return errors.New("filter must be in the format 'key==value'")
-					}
-
-					allowedKeys := []string{"name", "id"}
-					if !slices.Contains(allowedKeys, valueSplit[0]) {
-						return fmt.Errorf("filter key '%s' is not allowed", valueSplit[0])
-					}
-
-					return nil
+				Description: "FIQL filter to apply to the list of VDCs, e.g. name==vdc-*;numberOfVms=gt=2.",
+				FilterFields: []cav.FilterField{
+					filterFieldString("name"),
+					filterFieldURN("id", "vdc", false),
+					filterFieldString("description"),
+					filterFieldNumber("numberOfVms"),
+					filterFieldNumber("numberOfRunningVms"),
+					filterFieldNumber("numberOfDeployedVApps"),
+					filterFieldNumber("numberOfStorageProfiles"),
+					filterFieldNumber("numberOfDisks"),
+					filterFieldBool("isEnabled"),
 				},
 				TransformFunc: func(value string) (string, error) {
 					// Add ( ) around the filter value
@@ -276,6 +272,9 @@ func init() {
 		PathTemplate:     "/api/customers/v2.0/vdcs",
 		BodyRequestType:  itypes.ApiRequestCreateVDC{},
 		BodyResponseType: cav.Job{},
+
+		// The storage profiles are created, updated and deleted with the VDC.
+		InvalidatedFamilies: []string{"StorageProfile"},
 	}.Register()
 
 	// UpdateVdc
@@ -295,6 +294,9 @@ func init() {
 		},
 		BodyRequestType:  itypes.ApiRequestUpdateVDC{},
 		BodyResponseType: cav.Job{},
+
+		// The storage profiles are created, updated and deleted with the VDC.
+		InvalidatedFamilies: []string{"StorageProfile"},
 	}.Register()
 
 	// DeleteVdc
@@ -313,5 +315,8 @@ func init() {
 			},
 		},
 		BodyResponseType: cav.Job{},
+
+		// The storage profiles are created, updated and deleted with the VDC.
+		InvalidatedFamilies: []string{"StorageProfile"},
 	}.Register()
 }
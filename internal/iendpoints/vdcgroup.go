@@ -11,10 +11,8 @@ package iendpoints
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
-	"slices"
 	"strings"
 	"time"
 
@@ -38,19 +36,11 @@ func init() {
 		QueryParams: []cav.QueryParam{
 			{
 				Name:        "filter",
-				Description: "Filter to apply to the list of Vdcs. Format: key==value. Allowed keys: name, id.",
-				ValidatorFunc: func(value string) error {
-					valueSplit := strings.Split(value, "==")
-					if len(valueSplit) != 2 {
-						return errors.New("filter must be in the format 'key==value'")
-					}
-
-					allowedKeys := []string{"name", "id"}
-					if !slices.Contains(allowedKeys, valueSplit[0]) {
-						return fmt.Errorf("filter key '%s' is not allowed", valueSplit[0])
-					}
-
-					return nil
+				Description: "FIQL filter to apply to the list of Vdc Groups, e.g. name==group-*.",
+				FilterFields: []cav.FilterField{
+					filterFieldString("name"),
+					filterFieldURN("id", "vdcGroup", false),
+					filterFieldString("description"),
 				},
 				TransformFunc: func(value string) (string, error) {
 					// Add ( ) around the filter value
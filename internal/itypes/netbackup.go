@@ -0,0 +1,89 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package itypes
+
+import (
+	"time"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+type (
+	// * ProtectionLevel
+	ApiResponseListNetbackupProtectionLevels struct {
+		Data []ApiResponseNetbackupProtectionLevel `json:"Data" fakesize:"3"`
+	}
+
+	ApiResponseNetbackupProtectionLevel struct {
+		ID          int    `json:"Id" fake:"{number:1,1000}"`
+		Name        string `json:"Name" fake:"{randomstring:[GOLD-D6-H22-R1,SILVER-W1-H22-R1,BRONZE-M1-H22-R1]}"`
+		Description string `json:"Description" fake:"{sentence}"`
+	}
+
+	ApiRequestNetbackupProtectionLevel struct {
+		ProtectionLevelID int `json:"ProtectionLevelId"`
+	}
+
+	// * Backup
+	ApiResponseListNetbackupBackups struct {
+		Data []ApiResponseNetbackupBackup `json:"Data" fakesize:"3"`
+	}
+
+	ApiResponseNetbackupBackup struct {
+		ID                  string    `json:"Id" fake:"{uuid}"`
+		Type                string    `json:"Type" fake:"{randomstring:[Full,Incremental]}"`
+		ProtectionLevelName string    `json:"ProtectionLevelName" fake:"{randomstring:[GOLD-D6-H22-R1,SILVER-W1-H22-R1,BRONZE-M1-H22-R1]}"`
+		BackupTime          time.Time `json:"BackupTime"`
+		ExpirationTime      time.Time `json:"ExpirationTime"`
+	}
+
+	// * Restore
+	ApiRequestRestoreNetbackupVM struct {
+		// RestoreType is InPlace or NewVM.
+		RestoreType string `json:"RestoreType"`
+		NewVMName   string `json:"NewVmName,omitempty"`
+		PowerOn     bool   `json:"PowerOn"`
+	}
+)
+
+func (r *ApiResponseListNetbackupProtectionLevels) ToModel() *types.ModelListNetbackupProtectionLevels {
+	model := &types.ModelListNetbackupProtectionLevels{
+		ProtectionLevels: make([]types.ModelNetbackupProtectionLevel, 0, len(r.Data)),
+	}
+
+	for _, pl := range r.Data {
+		model.ProtectionLevels = append(model.ProtectionLevels, types.ModelNetbackupProtectionLevel{
+			ID:          pl.ID,
+			Name:        pl.Name,
+			Description: pl.Description,
+		})
+	}
+
+	return model
+}
+
+func (r *ApiResponseListNetbackupBackups) ToModel(vmID string) *types.ModelListNetbackupBackups {
+	model := &types.ModelListNetbackupBackups{
+		VMID:    vmID,
+		Backups: make([]types.ModelNetbackupBackup, 0, len(r.Data)),
+	}
+
+	for _, b := range r.Data {
+		model.Backups = append(model.Backups, types.ModelNetbackupBackup{
+			ID:                  b.ID,
+			Type:                b.Type,
+			ProtectionLevelName: b.ProtectionLevelName,
+			CreatedAt:           b.BackupTime,
+			ExpiresAt:           b.ExpirationTime,
+		})
+	}
+
+	return model
+}
@@ -0,0 +1,234 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package itypes
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/orange-cloudavenue/cloudavenue-sdk-go-v2/types"
+)
+
+// The S3 protocol uses XML documents.
+type (
+	// * Bucket
+	ApiResponseListS3Buckets struct {
+		XMLName xml.Name              `xml:"ListAllMyBucketsResult" fake:"skip"`
+		Buckets []ApiResponseS3Bucket `xml:"Buckets>Bucket" fakesize:"3"`
+	}
+
+	ApiResponseS3Bucket struct {
+		Name         string    `xml:"Name" fake:"{regex:[a-z]{6,12}}"`
+		CreationDate time.Time `xml:"CreationDate"`
+	}
+
+	// * BucketVersioning
+	// ApiS3BucketVersioning is the versioning configuration of a bucket, it is used in the requests and the responses.
+	ApiS3BucketVersioning struct {
+		XMLName xml.Name `xml:"VersioningConfiguration" fake:"skip"`
+		Status  string   `xml:"Status,omitempty" fake:"{randomstring:[Enabled,Suspended]}"`
+	}
+
+	// * BucketLifecycle
+	// ApiS3BucketLifecycle is the lifecycle configuration of a bucket, it is used in the requests and the responses.
+	ApiS3BucketLifecycle struct {
+		XMLName xml.Name                   `xml:"LifecycleConfiguration" fake:"skip"`
+		Rules   []ApiS3BucketLifecycleRule `xml:"Rule" fakesize:"2"`
+	}
+
+	ApiS3BucketLifecycleRule struct {
+		ID                             string                                           `xml:"ID" fake:"{regex:rule-[0-9]{3}}"`
+		Prefix                         string                                           `xml:"Filter>Prefix" fake:"{randomstring:[logs/,tmp/]}"`
+		Status                         string                                           `xml:"Status" fake:"{randomstring:[Enabled,Disabled]}"`
+		Expiration                     *ApiS3BucketLifecycleExpiration                  `xml:"Expiration,omitempty"`
+		NoncurrentVersionExpiration    *ApiS3BucketLifecycleNoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
+		AbortIncompleteMultipartUpload *ApiS3BucketLifecycleAbortMultipartUpload        `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	}
+
+	ApiS3BucketLifecycleExpiration struct {
+		Days int `xml:"Days" fake:"{number:1,365}"`
+	}
+
+	ApiS3BucketLifecycleNoncurrentVersionExpiration struct {
+		NoncurrentDays int `xml:"NoncurrentDays" fake:"{number:1,365}"`
+	}
+
+	ApiS3BucketLifecycleAbortMultipartUpload struct {
+		DaysAfterInitiation int `xml:"DaysAfterInitiation" fake:"{number:1,30}"`
+	}
+)
+
+// The Cloud Avenue S3 user API uses JSON documents.
+type (
+	// * BucketUsage
+	ApiResponseGetS3BucketUsage struct {
+		Bucket       string `json:"bucket" fake:"{regex:[a-z]{6,12}}"`
+		ObjectsCount int64  `json:"objectsCount" fake:"{number:0,100000}"`
+		SizeBytes    int64  `json:"sizeBytes" fake:"{number:0,1000000000}"`
+	}
+
+	// * User
+	ApiResponseListS3Users struct {
+		Users []ApiResponseS3User `json:"users" fakesize:"3"`
+	}
+
+	ApiResponseS3User struct {
+		ID   string `json:"id" fake:"{uuid}"`
+		Name string `json:"name" fake:"{regex:[a-z]{6,12}}"`
+	}
+
+	ApiRequestCreateS3User struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	// * UserKey
+	ApiResponseListS3UserKeys struct {
+		Keys []ApiResponseS3UserKey `json:"keys" fakesize:"2"`
+	}
+
+	ApiResponseS3UserKey struct {
+		AccessKey string    `json:"accessKey" fake:"{regex:[A-Z0-9]{20}}"`
+		SecretKey string    `json:"secretKey,omitempty" fake:"{regex:[A-Za-z0-9]{40}}"`
+		Active    bool      `json:"active"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+)
+
+func (r *ApiResponseListS3Buckets) ToModel() *types.ModelListS3Buckets {
+	model := &types.ModelListS3Buckets{
+		Buckets: make([]types.ModelS3Bucket, 0, len(r.Buckets)),
+	}
+
+	for _, b := range r.Buckets {
+		model.Buckets = append(model.Buckets, types.ModelS3Bucket{
+			Name:      b.Name,
+			CreatedAt: b.CreationDate,
+		})
+	}
+
+	return model
+}
+
+func (r *ApiS3BucketVersioning) ToModel(bucketName string) *types.ModelS3BucketVersioning {
+	return &types.ModelS3BucketVersioning{
+		BucketName: bucketName,
+		Status:     r.Status,
+	}
+}
+
+func (r *ApiS3BucketLifecycle) ToModel(bucketName string) *types.ModelS3BucketLifecycle {
+	model := &types.ModelS3BucketLifecycle{
+		BucketName: bucketName,
+		Rules:      make([]types.ModelS3BucketLifecycleRule, 0, len(r.Rules)),
+	}
+
+	for _, rule := range r.Rules {
+		m := types.ModelS3BucketLifecycleRule{
+			ID:      rule.ID,
+			Prefix:  rule.Prefix,
+			Enabled: rule.Status == "Enabled",
+		}
+		if rule.Expiration != nil {
+			m.ExpirationDays = rule.Expiration.Days
+		}
+		if rule.NoncurrentVersionExpiration != nil {
+			m.NoncurrentVersionExpirationDays = rule.NoncurrentVersionExpiration.NoncurrentDays
+		}
+		if rule.AbortIncompleteMultipartUpload != nil {
+			m.AbortIncompleteMultipartUploadDays = rule.AbortIncompleteMultipartUpload.DaysAfterInitiation
+		}
+		model.Rules = append(model.Rules, m)
+	}
+
+	return model
+}
+
+// NewApiS3BucketLifecycle returns the lifecycle configuration of the rules.
+// The actions with 0 days are not set.
+func NewApiS3BucketLifecycle(rules []types.ParamsS3BucketLifecycleRule) *ApiS3BucketLifecycle {
+	lc := &ApiS3BucketLifecycle{
+		Rules: make([]ApiS3BucketLifecycleRule, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		r := ApiS3BucketLifecycleRule{
+			ID:     rule.ID,
+			Prefix: rule.Prefix,
+			Status: "Enabled",
+		}
+		if rule.Disabled {
+			r.Status = "Disabled"
+		}
+		if rule.ExpirationDays > 0 {
+			r.Expiration = &ApiS3BucketLifecycleExpiration{Days: rule.ExpirationDays}
+		}
+		if rule.NoncurrentVersionExpirationDays > 0 {
+			r.NoncurrentVersionExpiration = &ApiS3BucketLifecycleNoncurrentVersionExpiration{NoncurrentDays: rule.NoncurrentVersionExpirationDays}
+		}
+		if rule.AbortIncompleteMultipartUploadDays > 0 {
+			r.AbortIncompleteMultipartUpload = &ApiS3BucketLifecycleAbortMultipartUpload{DaysAfterInitiation: rule.AbortIncompleteMultipartUploadDays}
+		}
+		lc.Rules = append(lc.Rules, r)
+	}
+
+	return lc
+}
+
+func (r *ApiResponseGetS3BucketUsage) ToModel(bucketName string) *types.ModelS3BucketUsage {
+	return &types.ModelS3BucketUsage{
+		BucketName:   bucketName,
+		ObjectsCount: r.ObjectsCount,
+		SizeBytes:    r.SizeBytes,
+	}
+}
+
+func (r *ApiResponseListS3Users) ToModel() *types.ModelListS3Users {
+	model := &types.ModelListS3Users{
+		Users: make([]types.ModelS3User, 0, len(r.Users)),
+	}
+
+	for _, u := range r.Users {
+		model.Users = append(model.Users, *u.ToModel())
+	}
+
+	return model
+}
+
+func (r *ApiResponseS3User) ToModel() *types.ModelS3User {
+	return &types.ModelS3User{
+		ID:   r.ID,
+		Name: r.Name,
+	}
+}
+
+// ToModel returns the keys of the user. The secret keys are never listed.
+func (r *ApiResponseListS3UserKeys) ToModel(userName string) *types.ModelListS3UserKeys {
+	model := &types.ModelListS3UserKeys{
+		UserName: userName,
+		Keys:     make([]types.ModelS3UserKey, 0, len(r.Keys)),
+	}
+
+	for _, k := range r.Keys {
+		key := k.ToModel()
+		key.SecretKey = ""
+		model.Keys = append(model.Keys, *key)
+	}
+
+	return model
+}
+
+func (r *ApiResponseS3UserKey) ToModel() *types.ModelS3UserKey {
+	return &types.ModelS3UserKey{
+		AccessKey: r.AccessKey,
+		SecretKey: r.SecretKey,
+		Active:    r.Active,
+		CreatedAt: r.CreatedAt,
+	}
+}
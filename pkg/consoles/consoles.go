@@ -321,6 +321,22 @@ func (c ConsoleName) GetAPICerberusEndpoint() string {
 	return consoles[c].Services.APICerberus.GetEndpoint()
 }
 
+// GetS3Endpoint - Returns the S3 API endpoint.
+func (c ConsoleName) GetS3Endpoint() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return consoles[c].Services.S3.GetEndpoint()
+}
+
+// GetNetbackupEndpoint - Returns the Netbackup API endpoint.
+func (c ConsoleName) GetNetbackupEndpoint() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return consoles[c].Services.Netbackup.GetEndpoint()
+}
+
 // OverrideEndpoint - Overrides the endpoint for a specific service.
 func (c ConsoleName) OverrideEndpoint(svc Services) {
 	mu.Lock()
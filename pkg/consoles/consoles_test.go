@@ -185,6 +185,44 @@ func TestConsole_GetAPICerberusEndpoint(t *testing.T) {
 	}
 }
 
+func TestConsole_GetNetbackupEndpoint(t *testing.T) {
+	tests := []struct {
+		console  ConsoleName
+		expected string
+	}{
+		{Console1, "https://backup1.cloudavenue.orange-business.com"},
+		{Console2, "https://backup2.cloudavenue.orange-business.com"},
+		{Console4, "https://backup4.cloudavenue.orange-business.com"},
+		{Console5, "https://backup5.cloudavenue-cha.itn.intraorange"},
+		{Console7, "https://backup7.cloudavenue-vdr.itn.intraorange"},
+		{Console8, "https://backup8.cloudavenue-vdr.itn.intraorange"},
+		{Console9, "https://backup9.cloudavenue.orange-business.com"},
+	}
+	for _, tt := range tests {
+		got := tt.console.GetNetbackupEndpoint()
+		if got != tt.expected {
+			t.Errorf("GetNetbackupEndpoint() = %v, want %v", got, tt.expected)
+		}
+	}
+}
+
+func TestConsole_GetS3Endpoint(t *testing.T) {
+	tests := []struct {
+		console  ConsoleName
+		expected string
+	}{
+		{Console1, "https://s3console1.cloudavenue.orange-business.com"},
+		{Console2, "https://s3console2.cloudavenue.orange-business.com"},
+		{Console4, ""},
+	}
+	for _, tt := range tests {
+		got := tt.console.GetS3Endpoint()
+		if got != tt.expected {
+			t.Errorf("GetS3Endpoint() = %v, want %v", got, tt.expected)
+		}
+	}
+}
+
 func TestCheckOrganizationName(t *testing.T) {
 	tests := []struct {
 		name string
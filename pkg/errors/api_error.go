@@ -11,6 +11,7 @@ package errors
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 )
 
@@ -41,11 +42,55 @@ type APIError struct {
 
 	// Method is the HTTP method used for the API request (e.g., GET, POST).
 	Method string
+
+	// Code is the error code returned by the API: the minorErrorCode of VMware Cloud Director
+	// (e.g. BUSY_ENTITY), the code of Cerberus (e.g. cf-0002) or the Code of S3 (e.g. NoSuchBucket).
+	Code string
+
+	// Reason is the reason returned by Cerberus (e.g. Job already exists).
+	Reason string
+
+	// Kind is the sentinel error identified from the error code or the message of the API,
+	// when the status code is not enough (e.g. ErrBusyEntity for a 409). It can be nil.
+	Kind error
 }
 
 // IsNotFound checks if the APIError indicates a "not found" error.
 func (e *APIError) IsNotFound() bool {
-	return e.StatusCode == 404
+	return e.StatusCode == http.StatusNotFound
+}
+
+// Is reports whether the error matches the sentinel error target:
+//   - ErrNotFound for a 404 Not Found or 410 Gone,
+//   - ErrConflict for a 409 Conflict or 412 Precondition Failed,
+//   - ErrUnauthorized for a 401 Unauthorized,
+//   - ErrForbidden for a 403 Forbidden,
+//   - ErrValidation for a 400 Bad Request or 422 Unprocessable Entity,
+//   - ErrTimeout for a 408 Request Timeout or 504 Gateway Timeout,
+//   - the Kind of the error.
+func (e *APIError) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	if e.Kind != nil && target == e.Kind {
+		return true
+	}
+
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrTimeout:
+		return e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusGatewayTimeout
+	}
+	return false
 }
 
 // Error returns the error message for APIError.
@@ -43,3 +43,43 @@ func TestAPIError_IsNotFound(t *testing.T) {
 		t.Error("APIError.IsNotFound() = true, want false")
 	}
 }
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *APIError
+		target error
+		want   bool
+	}{
+		{"Not found", &APIError{StatusCode: 404}, ErrNotFound, true},
+		{"Gone", &APIError{StatusCode: 410}, ErrNotFound, true},
+		{"Conflict", &APIError{StatusCode: 409}, ErrConflict, true},
+		{"Unauthorized", &APIError{StatusCode: 401}, ErrUnauthorized, true},
+		{"Forbidden", &APIError{StatusCode: 403}, ErrForbidden, true},
+		{"Bad request", &APIError{StatusCode: 400}, ErrValidation, true},
+		{"Gateway timeout", &APIError{StatusCode: 504}, ErrTimeout, true},
+		{"Other status", &APIError{StatusCode: 500}, ErrNotFound, false},
+		{"Kind", &APIError{StatusCode: 400, Code: "BUSY_ENTITY", Kind: ErrBusyEntity}, ErrBusyEntity, true},
+		{"Kind and status", &APIError{StatusCode: 400, Kind: ErrBusyEntity}, ErrValidation, true},
+		{"Other kind", &APIError{StatusCode: 500, Kind: ErrBusyEntity}, ErrQuotaExceeded, false},
+		{"Nil", nil, ErrNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Is(tt.target); got != tt.want {
+				t.Errorf("APIError.Is(%v) = %v, want %v", tt.target, got, tt.want)
+			}
+		})
+	}
+
+	// The error is matched once wrapped.
+	wrapped := Newf("get vdc: %w", &APIError{StatusCode: 404})
+	if !Is(wrapped, ErrNotFound) {
+		t.Error("errors.Is(wrapped APIError, ErrNotFound) = false, want true")
+	}
+	var apiErr *APIError
+	if !As(wrapped, &apiErr) || apiErr.StatusCode != 404 {
+		t.Error("errors.As(wrapped APIError) did not return the APIError")
+	}
+}
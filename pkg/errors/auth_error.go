@@ -0,0 +1,45 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package errors
+
+import "fmt"
+
+// AuthError is returned when the client is not able to (re)authenticate
+// against the API, e.g. when a session has expired and the renewal of the
+// credential failed.
+type AuthError struct {
+	// Operation is a short description of the operation that required the authentication.
+	Operation string
+
+	// Err is the underlying error returned by the authentication provider.
+	Err error
+}
+
+// Error returns the error message for AuthError.
+func (e *AuthError) Error() string {
+	if e == nil {
+		return "nil AuthError"
+	}
+
+	return fmt.Sprintf("[%s] authentication error: %v", e.Operation, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *AuthError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// Is reports whether target is ErrUnauthorized, the client is not authenticated.
+func (e *AuthError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
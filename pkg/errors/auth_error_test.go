@@ -0,0 +1,44 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package errors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAuthError_Error(t *testing.T) {
+	cause := errors.New("invalid credentials")
+	err := &AuthError{Operation: "List VDCs", Err: cause}
+
+	want := "[List VDCs] authentication error: invalid credentials"
+	if got := err.Error(); got != want {
+		t.Errorf("AuthError.Error() = %q, want %q", got, want)
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is(AuthError, cause) = false, want true")
+	}
+
+	var nilErr *AuthError
+	if got := nilErr.Error(); got != "nil AuthError" {
+		t.Errorf("AuthError.Error() nil = %q, want %q", got, "nil AuthError")
+	}
+}
+
+func TestAuthError_Is(t *testing.T) {
+	err := Newf("list vdcs: %w", &AuthError{Err: errors.New("invalid credentials")})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("errors.Is(AuthError, ErrUnauthorized) = false, want true")
+	}
+	if errors.Is(err, ErrForbidden) {
+		t.Error("errors.Is(AuthError, ErrForbidden) = true, want false")
+	}
+}
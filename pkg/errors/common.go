@@ -14,8 +14,9 @@ func isErrorType[errType any](err error) bool {
 		return false
 	}
 
-	_, ok := err.(errType)
-	return ok
+	// The error can be wrapped, e.g. in a *cav.JobFailedError.
+	var target errType
+	return As(err, &target)
 }
 
 func IsAPIError(err error) bool {
@@ -25,3 +26,7 @@ func IsAPIError(err error) bool {
 func IsClientError(err error) bool {
 	return isErrorType[*ClientError](err)
 }
+
+func IsAuthError(err error) bool {
+	return isErrorType[*AuthError](err)
+}
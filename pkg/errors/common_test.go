@@ -34,6 +34,9 @@ func TestIsAPIError(t *testing.T) {
 	if IsAPIError(nilErr) {
 		t.Errorf("IsAPIError should return false for nil error")
 	}
+	if !IsAPIError(Newf("wrapped: %w", apiErr)) {
+		t.Errorf("IsAPIError should return true for wrapped APIError")
+	}
 }
 
 func TestIsClientError(t *testing.T) {
@@ -55,3 +58,19 @@ func TestIsClientError(t *testing.T) {
 		t.Errorf("IsClientError should return false for nil error")
 	}
 }
+
+func TestIsAuthError(t *testing.T) {
+	var authErr error = &AuthError{}
+	var apiErr error = &APIError{}
+	var nilErr error
+
+	if !IsAuthError(authErr) {
+		t.Errorf("IsAuthError should return true for AuthError")
+	}
+	if IsAuthError(apiErr) {
+		t.Errorf("IsAuthError should return false for APIError")
+	}
+	if IsAuthError(nilErr) {
+		t.Errorf("IsAuthError should return false for nil error")
+	}
+}
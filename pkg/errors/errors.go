@@ -9,7 +9,50 @@
 
 package errors
 
+var ErrClientNotInitialized = New("client not initialized")
+
+// The sentinel errors classify the errors of the SDK, use errors.Is to check them:
+//
+//	if errors.Is(err, errors.ErrNotFound) {
+//		// The resource does not exist.
+//	}
+//
+// An *APIError matches them from its status code and the error code of the API,
+// see APIError.Is.
 var (
-	ErrClientNotInitialized = New("client not initialized")
-	ErrNotFound             = New("not found")
+	ErrNotFound      = New("not found")
+	ErrConflict      = New("conflict")
+	ErrBusyEntity    = New("entity busy")
+	ErrUnauthorized  = New("unauthorized")
+	ErrForbidden     = New("forbidden")
+	ErrQuotaExceeded = New("quota exceeded")
+	ErrValidation    = New("validation failed")
+	ErrJobFailed     = New("job failed")
+	ErrTimeout       = New("timeout")
 )
+
+// kindError attaches a sentinel error to an error.
+type kindError struct {
+	err  error
+	kind error
+}
+
+// WithKind returns an error with the message of err which matches kind with errors.Is,
+// e.g. WithKind(err, ErrNotFound). err can still be retrieved with errors.Is and errors.As.
+// It returns nil if err is nil.
+func WithKind(err, kind error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{err: err, kind: kind}
+}
+
+// Error returns the message of the error.
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error and its kind.
+func (e *kindError) Unwrap() []error {
+	return []error{e.err, e.kind}
+}
@@ -0,0 +1,39 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package errors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithKind(t *testing.T) {
+	cause := &ClientError{Message: "missing name"}
+	err := WithKind(Newf("invalid params: %w", cause), ErrValidation)
+
+	if got := err.Error(); got != "invalid params: missing name" {
+		t.Errorf("WithKind().Error() = %q, want %q", got, "invalid params: missing name")
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("errors.Is(err, ErrValidation) = false, want true")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = true, want false")
+	}
+
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) || clientErr != cause {
+		t.Error("errors.As(err, *ClientError) did not return the cause")
+	}
+
+	if WithKind(nil, ErrNotFound) != nil {
+		t.Error("WithKind(nil) != nil")
+	}
+}
@@ -40,6 +40,11 @@ type (
 // * Functions Parameters
 
 type (
+	ParamsListEdgeGateway struct {
+		// Limit is the maximum number of edge gateways to return. All the edge gateways are returned if 0.
+		Limit int
+	}
+
 	ParamsEdgeGateway struct {
 		ID   string `fake:"{urn:edgegateway}"`
 		Name string `fake:"{resource_name:edgegateway}"`
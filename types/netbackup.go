@@ -0,0 +1,77 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package types
+
+import "time"
+
+type (
+	// * ProtectionLevel
+	ModelListNetbackupProtectionLevels struct {
+		ProtectionLevels []ModelNetbackupProtectionLevel `documentation:"List of Netbackup protection levels"`
+	}
+
+	ModelNetbackupProtectionLevel struct {
+		ID          int    `documentation:"ID of the protection level"`
+		Name        string `documentation:"Name of the protection level (backup policy)"`
+		Description string `documentation:"Description of the protection level"`
+	}
+
+	// * Backup
+	ModelListNetbackupBackups struct {
+		VMID    string                 `documentation:"ID of the VM"`
+		Backups []ModelNetbackupBackup `documentation:"List of backups of the VM"`
+	}
+
+	ModelNetbackupBackup struct {
+		ID                  string    `documentation:"ID of the backup"`
+		Type                string    `documentation:"Type of the backup (Full or Incremental)"`
+		ProtectionLevelName string    `documentation:"Name of the protection level which created the backup"`
+		CreatedAt           time.Time `documentation:"Date of the backup"`
+		ExpiresAt           time.Time `documentation:"Expiration date of the backup"`
+	}
+)
+
+type (
+	ParamsNetbackupVMProtectionLevel struct {
+		// VMID is the unique identifier of the VM.
+		VMID string
+
+		// ProtectionLevelName is the name of the protection level (backup policy).
+		ProtectionLevelName string
+	}
+
+	ParamsNetbackupVAppProtectionLevel struct {
+		// VAppID is the unique identifier of the vApp.
+		VAppID string
+
+		// ProtectionLevelName is the name of the protection level (backup policy).
+		ProtectionLevelName string
+	}
+
+	ParamsListNetbackupBackups struct {
+		// VMID is the unique identifier of the VM.
+		VMID string
+	}
+
+	ParamsRestoreNetbackupVM struct {
+		// VMID is the unique identifier of the backed up VM.
+		VMID string
+
+		// BackupID is the unique identifier of the backup to restore.
+		BackupID string
+
+		// NewVMName is the name of the VM created by the restore.
+		// If empty, the VM is restored in place.
+		NewVMName string
+
+		// PowerOn powers on the VM after the restore.
+		PowerOn bool
+	}
+)
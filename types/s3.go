@@ -0,0 +1,132 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange
+ * SPDX-License-Identifier: Mozilla Public License 2.0
+ *
+ * This software is distributed under the MPL-2.0 license.
+ * the text of which is available at https://www.mozilla.org/en-US/MPL/2.0/
+ * or see the "LICENSE" file for more details.
+ */
+
+package types
+
+import "time"
+
+type (
+	// * Bucket
+	ModelListS3Buckets struct {
+		Buckets []ModelS3Bucket `documentation:"List of S3 buckets"`
+	}
+
+	ModelS3Bucket struct {
+		Name      string    `documentation:"Name of the bucket"`
+		CreatedAt time.Time `documentation:"Creation date of the bucket"`
+	}
+
+	// * BucketVersioning
+	ModelS3BucketVersioning struct {
+		BucketName string `documentation:"Name of the bucket"`
+		Status     string `documentation:"Versioning status of the bucket (Enabled or Suspended). Empty if the versioning has never been enabled"`
+	}
+
+	// * BucketLifecycle
+	ModelS3BucketLifecycle struct {
+		BucketName string                       `documentation:"Name of the bucket"`
+		Rules      []ModelS3BucketLifecycleRule `documentation:"List of lifecycle rules of the bucket"`
+	}
+
+	ModelS3BucketLifecycleRule struct {
+		ID                                 string `documentation:"ID of the rule"`
+		Prefix                             string `documentation:"Prefix of the objects concerned by the rule. Empty for all the objects"`
+		Enabled                            bool   `documentation:"Whether the rule is enabled"`
+		ExpirationDays                     int    `documentation:"Number of days after which the objects expire. 0 if not set"`
+		NoncurrentVersionExpirationDays    int    `documentation:"Number of days after which the noncurrent versions of the objects expire. 0 if not set"`
+		AbortIncompleteMultipartUploadDays int    `documentation:"Number of days after which the incomplete multipart uploads are aborted. 0 if not set"`
+	}
+
+	// * BucketUsage
+	ModelS3BucketUsage struct {
+		BucketName   string `documentation:"Name of the bucket"`
+		ObjectsCount int64  `documentation:"Number of objects in the bucket"`
+		SizeBytes    int64  `documentation:"Size of the objects of the bucket in bytes"`
+	}
+
+	// * User
+	ModelListS3Users struct {
+		Users []ModelS3User `documentation:"List of S3 users"`
+	}
+
+	ModelS3User struct {
+		ID   string `documentation:"ID of the user"`
+		Name string `documentation:"Name of the user"`
+	}
+
+	// * UserKey
+	ModelListS3UserKeys struct {
+		UserName string           `documentation:"Name of the user"`
+		Keys     []ModelS3UserKey `documentation:"List of the keys of the user"`
+	}
+
+	ModelS3UserKey struct {
+		AccessKey string    `documentation:"Access key"`
+		SecretKey string    `documentation:"Secret key. It is only returned when the key is created"`
+		Active    bool      `documentation:"Whether the key is active"`
+		CreatedAt time.Time `documentation:"Creation date of the key"`
+	}
+)
+
+type (
+	ParamsS3Bucket struct {
+		// BucketName is the name of the bucket.
+		BucketName string
+	}
+
+	ParamsPutS3BucketVersioning struct {
+		// BucketName is the name of the bucket.
+		BucketName string
+
+		// Status is the versioning status of the bucket (Enabled or Suspended).
+		Status string
+	}
+
+	ParamsPutS3BucketLifecycle struct {
+		// BucketName is the name of the bucket.
+		BucketName string
+
+		// Rules is the list of lifecycle rules. It replaces the existing rules.
+		Rules []ParamsS3BucketLifecycleRule
+	}
+
+	ParamsS3BucketLifecycleRule struct {
+		// ID is the ID of the rule.
+		ID string
+
+		// Prefix is the prefix of the objects concerned by the rule.
+		// If empty, the rule applies to all the objects.
+		Prefix string
+
+		// Disabled disables the rule.
+		Disabled bool
+
+		// ExpirationDays is the number of days after which the objects expire.
+		ExpirationDays int
+
+		// NoncurrentVersionExpirationDays is the number of days after which the noncurrent versions expire.
+		NoncurrentVersionExpirationDays int
+
+		// AbortIncompleteMultipartUploadDays is the number of days after which the incomplete multipart uploads are aborted.
+		AbortIncompleteMultipartUploadDays int
+	}
+
+	ParamsS3User struct {
+		// UserName is the name of the user.
+		UserName string
+	}
+
+	ParamsDeleteS3UserKey struct {
+		// UserName is the name of the user.
+		UserName string
+
+		// AccessKey is the access key to delete.
+		AccessKey string
+	}
+)
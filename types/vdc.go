@@ -85,6 +85,11 @@ type (
 		ID string `documentation:"ID of the VDC to filter by"`
 		// Name is the name of the VDC to filter by.
 		Name string `documentation:"Name of the VDC to filter by"`
+		// Filter is a FIQL filter on the attributes of the VDCs, e.g. name==vdc-*;numberOfVms=gt=2.
+		// It is combined with the ID and the Name.
+		Filter string `documentation:"FIQL filter to apply to the VDCs"`
+		// Limit is the maximum number of VDCs to return. All the VDCs are returned if 0.
+		Limit int `documentation:"Maximum number of VDCs to return"`
 	}
 
 	// ParamsGetVDC is the parameters for the GetVDC command.
@@ -35,6 +35,11 @@ type (
 		Class   string
 		VdcID   string
 		VdcName string
+		// Filter is a FIQL filter on the attributes of the storage profiles, e.g. isDefaultStorageProfile==true.
+		// It is combined with the other parameters.
+		Filter string
+		// Limit is the maximum number of storage profiles to return. All the storage profiles are returned if 0.
+		Limit int
 	}
 
 	ParamsAddStorageProfile struct {
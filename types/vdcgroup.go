@@ -38,6 +38,13 @@ type (
 
 		// Name is the name of the Vdc Group to filter by.
 		Name string
+
+		// Filter is a FIQL filter on the attributes of the Vdc Groups, e.g. name==group-*.
+		// It is combined with the ID and the Name.
+		Filter string
+
+		// Limit is the maximum number of Vdc Groups to return. All the Vdc Groups are returned if 0.
+		Limit int
 	}
 
 	ParamsGetVdcGroup struct {